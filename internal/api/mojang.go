@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/download"
 )
 
 const (
@@ -56,21 +57,12 @@ func (c *MojangClient) GetVersionManifest(ctx context.Context) (*core.VersionMan
 		return c.manifest, nil
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.manifestURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := download.Get(ctx, c.httpClient, c.manifestURL)
 	if err != nil {
 		return nil, fmt.Errorf("fetching manifest: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status: %d", resp.StatusCode)
-	}
-
 	var manifest core.VersionManifest
 	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
 		return nil, fmt.Errorf("decoding manifest: %w", err)
@@ -86,21 +78,12 @@ func (c *MojangClient) GetVersionManifest(ctx context.Context) (*core.VersionMan
 // GetVersionDetails fetches detailed version information
 func (c *MojangClient) GetVersionDetails(ctx context.Context, version *core.Version) (*core.VersionDetails, error) {
 	// Fetch from network
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, version.URL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := download.Get(ctx, c.httpClient, version.URL)
 	if err != nil {
 		return nil, fmt.Errorf("fetching version details: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status: %d", resp.StatusCode)
-	}
-
 	var details core.VersionDetails
 	if err := json.NewDecoder(resp.Body).Decode(&details); err != nil {
 		return nil, fmt.Errorf("decoding version details: %w", err)
@@ -15,6 +15,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/aayushdutt/mctui/internal/download"
 )
 
 const (
@@ -276,6 +278,14 @@ func (c *VanillaTweaksClient) DownloadResourcePackZip(ctx context.Context, downl
 	}
 	req.Header.Set("User-Agent", userAgent)
 
+	// Go through the shared download scheduler like every other transfer.
+	sched := download.Shared()
+	release, err := sched.Acquire(ctx, downloadURL)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("downloading: %w", err)
@@ -283,7 +293,7 @@ func (c *VanillaTweaksClient) DownloadResourcePackZip(ctx context.Context, downl
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
+		return &download.StatusError{URL: downloadURL, Code: resp.StatusCode}
 	}
 
 	// Stream to a temp file, then atomically rename into place. Mirrors the
@@ -295,7 +305,7 @@ func (c *VanillaTweaksClient) DownloadResourcePackZip(ctx context.Context, downl
 		return fmt.Errorf("creating file: %w", err)
 	}
 
-	if _, err := io.Copy(f, resp.Body); err != nil {
+	if _, err := io.Copy(f, sched.Throttle(ctx, resp.Body)); err != nil {
 		f.Close()
 		os.Remove(tmpPath)
 		return fmt.Errorf("writing file: %w", err)
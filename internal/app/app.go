@@ -6,13 +6,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"time"
 
 	"github.com/aayushdutt/mctui/internal/api"
 	"github.com/aayushdutt/mctui/internal/config"
 	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/download"
+	"github.com/aayushdutt/mctui/internal/java"
 	"github.com/aayushdutt/mctui/internal/launch"
 	"github.com/aayushdutt/mctui/internal/loader"
+	"github.com/aayushdutt/mctui/internal/memory"
 	"github.com/aayushdutt/mctui/internal/mods"
 	"github.com/aayushdutt/mctui/internal/ui"
 	"github.com/charmbracelet/bubbles/key"
@@ -29,7 +33,11 @@ const (
 	StateMods
 	StateResourcePacks
 	StateSettings
+	StateInstanceSettings
+	StateLogs
 	StateAuth
+	StateRepair
+	StateJava
 )
 
 // Model is the main application model
@@ -41,11 +49,16 @@ type Model struct {
 	// Child models for each view
 	home          *ui.HomeModel
 	wizard        *ui.WizardModel
-	launch        *ui.LaunchModel
 	mods          *ui.ModsModel
 	resourcePacks *ui.ResourcePacksModel
 	auth          *ui.AuthModel
 	settings      *ui.SettingsModel
+	instSettings  *ui.InstanceSettingsModel
+	logs          *ui.LogsModel
+	logsReturn    State // screen to go back to when the log viewer closes
+	repair        *repairSession
+	java          *ui.JavaModel
+	javaInstall   *javaInstall // runs on when the Java screen closes
 
 	// Core services
 	cfg           *config.Config
@@ -55,9 +68,14 @@ type Model struct {
 	modrinth      *api.ModrinthClient
 	vanillaTweaks *api.VanillaTweaksClient
 
-	// Launch state
-	launchStatusChan chan launch.Status
-	launchCtxCancel  context.CancelFunc
+	// Launch state: a session per instance that is launching, running, or showing a failed launch
+	launches    map[string]*launchSession
+	launchFocus string             // instance whose launch screen StateLaunch shows
+	supervisor  *launch.Supervisor // running game processes
+	// instWrites carries instance changes from launch goroutines to the event loop.
+	instWrites chan instanceWriteMsg
+	// launchQuickPlay is true when the pending launch joins the instance's Quick Play target.
+	launchQuickPlay bool
 
 	// Key bindings
 	keys keyMap
@@ -103,8 +121,13 @@ func New() *Model {
 		cfg.Theme = "dark"
 	}
 	cfg.EnsureDirs()
+	configureDownloads(cfg)
+	moves, _ := java.MigrateLegacyRuntimes(cfg.DataDir)
 
 	instances := core.NewInstanceManager(cfg.DataDir)
+	if len(moves) > 0 {
+		repointJavaPaths(cfg, instances, moves)
+	}
 	accounts := core.NewAccountManager(cfg.DataDir)
 	accounts.Load()
 
@@ -117,6 +140,16 @@ func New() *Model {
 	)
 }
 
+// configureDownloads applies the download settings from cfg process-wide.
+func configureDownloads(cfg *config.Config) {
+	download.SetMirrors(cfg.Mirrors)
+	download.SetLimits(download.Limits{
+		BytesPerSec: cfg.DownloadLimitKBps * 1024,
+		PerHost:     cfg.DownloadConnectionsPerHost,
+	})
+	download.UseHashIndex(filepath.Join(cfg.DataDir, download.HashIndexFile))
+}
+
 // newWithDeps builds a Model from already-constructed dependencies. It is the
 // dependency-injection seam New() delegates to: tests construct temp-dir-backed
 // managers and a Modrinth client pointed at a test server, then call this
@@ -125,6 +158,7 @@ func New() *Model {
 func newWithDeps(cfg *config.Config, instances *core.InstanceManager, accounts *core.AccountManager, mojang *api.MojangClient, modrinth *api.ModrinthClient) *Model {
 	home := ui.NewHomeModel()
 	home.SetAccountManager(accounts)
+	home.SetSort(cfg.HomeSort)
 
 	return &Model{
 		state:         StateHome,
@@ -135,6 +169,9 @@ func newWithDeps(cfg *config.Config, instances *core.InstanceManager, accounts *
 		mojang:        mojang,
 		modrinth:      modrinth,
 		vanillaTweaks: api.NewVanillaTweaksClient(),
+		launches:      map[string]*launchSession{},
+		instWrites:    make(chan instanceWriteMsg, 16),
+		supervisor:    launch.NewSupervisor(filepath.Join(cfg.DataDir, launch.RunningStateFile)),
 		keys:          defaultKeyMap(),
 	}
 }
@@ -143,7 +180,10 @@ func newWithDeps(cfg *config.Config, instances *core.InstanceManager, accounts *
 func (m *Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.home.Init(),
-		m.loadInstances(),
+		// Instances must be loaded before games that ended while mctui was
+		// closed can be recorded against them.
+		tea.Sequence(m.loadInstances(), m.reattachGames()),
+		m.waitForInstanceWrite(),
 		tea.Sequence(
 			func() tea.Msg { return ui.ActiveSessionCheckStarted{} },
 			m.checkActiveSessionCmd(),
@@ -262,7 +302,10 @@ func (m *Model) checkActiveSessionCmd() tea.Cmd {
 	}
 }
 
-func (m *Model) gateOnlineLaunch(inst *core.Instance) tea.Cmd {
+// gateOnlineLaunch verifies the active session before an online launch. quickPlay is
+// forwarded when an offline account reroutes to NavigateToLaunch, which resets
+// m.launchQuickPlay from the message.
+func (m *Model) gateOnlineLaunch(inst *core.Instance, quickPlay bool) tea.Cmd {
 	return func() tea.Msg {
 		acc := m.accounts.GetActive()
 		if acc == nil {
@@ -271,7 +314,7 @@ func (m *Model) gateOnlineLaunch(inst *core.Instance) tea.Cmd {
 		switch acc.Type {
 		case core.AccountTypeOffline:
 			// No Minecraft Services token; online launch would run with an empty accessToken.
-			return ui.NavigateToLaunch{Instance: inst, Offline: true}
+			return ui.NavigateToLaunch{Instance: inst, Offline: true, QuickPlay: quickPlay}
 		case core.AccountTypeMSA:
 			// continue below
 		default:
@@ -321,10 +364,19 @@ func (m *Model) loadInstances() tea.Cmd {
 func (m *Model) loadInstancesSelecting(selectID string) tea.Cmd {
 	return func() tea.Msg {
 		err := m.instances.Load()
+		instances := m.instances.List()
+		since := time.Now().Add(-ui.RecentPlayWindow)
+		recent := make(map[string]time.Duration, len(instances))
+		for _, inst := range instances {
+			if sessions, err := core.LoadSessions(inst.Path); err == nil {
+				recent[inst.ID] = core.PlayTimeSince(sessions, since)
+			}
+		}
 		return ui.InstancesLoaded{
-			Instances: m.instances.List(),
-			Error:     err,
-			SelectID:  selectID,
+			Instances:      instances,
+			Error:          err,
+			SelectID:       selectID,
+			RecentPlayTime: recent,
 		}
 	}
 }
@@ -358,8 +410,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.wizard != nil {
 			m.wizard.SetSize(cw, ch)
 		}
-		if m.launch != nil {
-			m.launch.SetSize(cw, ch)
+		for _, s := range m.launches {
+			s.model.SetSize(cw, ch)
 		}
 		if m.mods != nil {
 			m.mods.SetSize(cw, ch)
@@ -373,6 +425,18 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.settings != nil {
 			m.settings.SetSize(cw, ch)
 		}
+		if m.instSettings != nil {
+			m.instSettings.SetSize(cw, ch)
+		}
+		if m.logs != nil {
+			m.logs.SetSize(cw, ch)
+		}
+		if m.repair != nil {
+			m.repair.model.SetSize(cw, ch)
+		}
+		if m.java != nil {
+			m.java.SetSize(cw, ch)
+		}
 
 	// Navigation messages
 	case ui.NavigateToHome:
@@ -386,15 +450,57 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.mods = nil
 		m.resourcePacks = nil
 		m.settings = nil
+		m.instSettings = nil
+		m.logs = nil
+		m.java = nil
+		if m.repair != nil && m.repair.model.Done() {
+			m.repair = nil
+		}
+		m.pruneLaunches()
 		return m, tea.Batch(m.loadInstances(), m.sessionRecheckCmd())
 
 	case ui.NavigateToSettings:
 		m.state = StateSettings
 		m.settings = ui.NewSettingsModel(m.cfg)
+		sys, _ := memory.ReadSystem()
+		m.settings.SetSystemMemory(sys)
 		cw, ch := m.contentSize()
 		m.settings.SetSize(cw, ch)
 		return m, m.settings.Init()
 
+	case ui.NavigateToInstanceSettings:
+		if msg.Instance == nil {
+			return m, nil
+		}
+		m.state = StateInstanceSettings
+		m.instSettings = ui.NewInstanceSettingsModel(msg.Instance, m.cfg)
+		sys, _ := memory.ReadSystem()
+		jars, _ := mods.ListInstalledJars(msg.Instance)
+		m.instSettings.SetMemoryInfo(sys, len(jars))
+		cw, ch := m.contentSize()
+		m.instSettings.SetSize(cw, ch)
+		return m, m.instSettings.Init()
+
+	case ui.NavigateToLogs:
+		if msg.Instance == nil {
+			return m, nil
+		}
+		m.logsReturn = m.state
+		m.state = StateLogs
+		m.logs = ui.NewLogsModel(msg.Instance, msg.Path)
+		cw, ch := m.contentSize()
+		m.logs.SetSize(cw, ch)
+		return m, m.logs.Init()
+
+	case ui.CloseLogs:
+		m.logs = nil
+		// Back to the launch screen while the game is running or its failure is still on show.
+		if s := m.focusedLaunch(); m.logsReturn == StateLaunch && s != nil && !s.model.Closed() {
+			m.state = StateLaunch
+			return m, nil
+		}
+		return m, func() tea.Msg { return ui.NavigateToHome{} }
+
 	case ui.NavigateToNewInstance:
 		m.state = StateNewInstance
 		m.wizard = ui.NewWizardModel(m.cfg.ShowSnapshots)
@@ -425,40 +531,191 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.resourcePacks.SetSize(cw, ch)
 		return m, m.resourcePacks.Init()
 
+	case ui.NavigateToRepair:
+		if msg.Instance == nil {
+			return m, nil
+		}
+		return m, m.startRepair(msg.Instance)
+
+	case ui.RepairStatusUpdate:
+		s := m.repair
+		if s == nil || s.instanceID != msg.InstanceID {
+			return m, nil
+		}
+		_, cmd := s.model.Update(msg)
+		return m, tea.Batch(cmd, waitForRepair(s))
+
+	case ui.RepairComplete:
+		s := m.repair
+		if s == nil || s.instanceID != msg.InstanceID {
+			return m, nil
+		}
+		if msg.Edit != nil {
+			if err := m.applyInstanceWrite(instanceWriteMsg{id: msg.InstanceID, edit: msg.Edit}); err != nil {
+				m.home.SetTransientBanner(fmt.Sprintf("Couldn't save instance: %v", err))
+			}
+		}
+		s.cancel()
+		if s.cancelled {
+			m.repair = nil
+			return m, nil
+		}
+		_, cmd := s.model.Update(msg)
+		return m, cmd
+
+	case ui.CancelRepair:
+		if s := m.repair; s != nil && s.instanceID == msg.InstanceID {
+			s.cancelled = true
+			s.cancel()
+		}
+		m.state = StateHome
+		return m, m.loadInstances()
+
+	case ui.NavigateToJava:
+		return m, m.openJava(msg.Instance)
+
+	case ui.JavaRuntimesLoaded:
+		if m.java != nil {
+			m.java.Update(msg)
+		}
+		return m, nil
+
+	case ui.InstallJava:
+		return m, m.startJavaInstall(msg.Major)
+
+	case ui.JavaInstallStatus:
+		s := m.javaInstall
+		if s == nil {
+			return m, nil
+		}
+		if m.java != nil {
+			m.java.Update(msg)
+		}
+		return m, waitForJavaInstall(s)
+
+	case ui.JavaInstallDone:
+		m.javaInstall = nil
+		if m.java != nil {
+			m.java.Update(msg)
+			return m, m.loadJavaRuntimes()
+		}
+		if msg.Err != nil {
+			m.home.SetTransientBanner(fmt.Sprintf("Couldn't install Java %d: %v", msg.Major, msg.Err))
+		} else {
+			m.home.SetTransientBanner(fmt.Sprintf("Installed Java %d.", msg.Major))
+		}
+		return m, nil
+
+	case ui.DeleteJava:
+		if m.java == nil {
+			return m, nil
+		}
+		return m, m.deleteJava(msg.Runtime)
+
+	case javaDeletedMsg:
+		if m.java == nil {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.java.SetStatus(fmt.Sprintf("Couldn't delete %s: %v", msg.runtime.Label(), msg.err))
+		} else {
+			m.java.SetStatus(fmt.Sprintf("Deleted %s.", msg.runtime.Label()))
+		}
+		return m, m.loadJavaRuntimes()
+
+	case ui.SetInstanceJava:
+		if msg.Instance == nil {
+			return m, nil
+		}
+		msg.Instance.JavaPath = msg.Path
+		status := fmt.Sprintf("%s now picks Java automatically.", msg.Instance.Name)
+		if msg.Path != "" {
+			status = fmt.Sprintf("%s now uses %s.", msg.Instance.Name, msg.Path)
+		}
+		if err := m.instances.Update(msg.Instance); err != nil {
+			status = fmt.Sprintf("Couldn't save %s: %v", msg.Instance.Name, err)
+		}
+		if m.java != nil {
+			m.java.SetStatus(status)
+			m.java.SetUsage(m.instances.List(), m.cfg.JavaPath)
+		}
+		return m, nil
+
+	case ui.SetDefaultJava:
+		m.cfg.JavaPath = msg.Path
+		status := "Instances without their own Java now pick it automatically."
+		if msg.Path != "" {
+			status = fmt.Sprintf("Default Java is now %s.", msg.Path)
+		}
+		if err := m.cfg.Save(); err != nil {
+			status = fmt.Sprintf("Applied for this session, but couldn't write config: %v", err)
+		}
+		if m.java != nil {
+			m.java.SetStatus(status)
+			m.java.SetUsage(m.instances.List(), m.cfg.JavaPath)
+		}
+		return m, nil
+
 	case ui.NavigateToLaunch:
+		if m.showActiveLaunch(msg.Instance) {
+			return m, nil
+		}
+		m.launchQuickPlay = msg.QuickPlay
 		if msg.Offline {
-			m.state = StateLaunch
-			m.launch = ui.NewLaunchModel(msg.Instance, m.cfg)
-			cw, ch := m.contentSize()
-			m.launch.SetSize(cw, ch)
+			s := m.openLaunchScreen(msg.Instance)
 			return m, tea.Batch(
-				m.launch.Init(),
+				s.model.Init(),
 				m.beginLaunch(msg.Instance, true),
 			)
 		}
-		return m, m.gateOnlineLaunch(msg.Instance)
+		return m, m.gateOnlineLaunch(msg.Instance, msg.QuickPlay)
 
 	case ui.ProceedWithLaunch:
-		m.state = StateLaunch
-		m.launch = ui.NewLaunchModel(msg.Instance, m.cfg)
-		cw, ch := m.contentSize()
-		m.launch.SetSize(cw, ch)
+		if m.showActiveLaunch(msg.Instance) {
+			return m, nil
+		}
+		s := m.openLaunchScreen(msg.Instance)
 		return m, tea.Batch(
-			m.launch.Init(),
+			s.model.Init(),
 			m.beginLaunch(msg.Instance, false),
 		)
 
+	case ui.FocusLaunch:
+		if s := m.launches[msg.Instance.ID]; s != nil && !s.cancelled {
+			m.showLaunch(msg.Instance.ID)
+			return m, nil
+		}
+		// No launch screen to return to; follow the game's log instead.
+		inst := msg.Instance
+		return m, func() tea.Msg { return ui.NavigateToLogs{Instance: inst} }
+
+	case ui.StopInstance:
+		id := msg.Instance.ID
+		stop := m.supervisor.Stop
+		if msg.Force {
+			stop = m.supervisor.Kill
+		}
+		err := stop(id)
+		if errors.Is(err, launch.ErrNotRunning) {
+			// The game hasn't started yet; cancel the launch instead.
+			if s := m.launches[id]; s != nil && s.cancel != nil {
+				s.cancelled = true
+				s.cancel()
+				m.syncRunning()
+			}
+			err = nil
+		}
+		if err != nil {
+			m.home.SetTransientBanner(fmt.Sprintf("Couldn't stop %s: %v", msg.Instance.Name, err))
+		}
+		return m, nil
+
 	case ui.SessionGateFailed:
 		if msg.NeedAuth {
 			return m, m.prepareAuthScreen(msg.Instance)
 		}
 		m.state = StateHome
-		if m.launchCtxCancel != nil {
-			m.launchCtxCancel()
-			m.launchCtxCancel = nil
-		}
-		m.launch = nil
-		m.launchStatusChan = nil
+		m.pruneLaunches()
 		m.home.SetTransientBanner("Could not verify Microsoft session. Check your connection or press [o] for offline.")
 		return m, tea.Batch(m.loadInstances(), m.sessionRecheckCmd())
 
@@ -481,6 +738,48 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, m.refreshActiveSession(acc, nil)
 
+	case gamesReattachedMsg:
+		if msg.err != nil {
+			m.home.SetTransientBanner(fmt.Sprintf("Couldn't check for games left running: %v", msg.err))
+		}
+		for _, p := range msg.ended {
+			s := core.NewUnobservedSession(p.Started, p.LastActive(), p.Account, p.Offline)
+			if err := m.instances.RecordSession(p.InstanceID, s); err != nil {
+				m.home.SetTransientBanner(fmt.Sprintf("Couldn't record play session: %v", err))
+			}
+		}
+		var cmds []tea.Cmd
+		if len(msg.ended) > 0 {
+			cmds = append(cmds, m.loadInstances()) // playtime changed
+		}
+		if len(msg.procs) > 0 {
+			m.syncRunning()
+			m.home.SetTransientBanner(fmt.Sprintf("Reattached to %d game(s) still running.", len(msg.procs)))
+		}
+		for _, p := range msg.procs {
+			cmds = append(cmds, m.waitReattached(p.InstanceID))
+		}
+		return m, tea.Batch(cmds...)
+
+	case instanceWriteMsg:
+		if err := m.applyInstanceWrite(msg); err != nil {
+			what := "save instance"
+			if msg.session != nil {
+				what = "record play session"
+			}
+			m.home.SetTransientBanner(fmt.Sprintf("Couldn't %s: %v", what, err))
+		}
+		return m, m.waitForInstanceWrite()
+
+	case reattachedExitMsg:
+		p := msg.proc
+		s := core.NewUnobservedSession(p.Started, time.Now(), p.Account, p.Offline)
+		if err := m.instances.RecordSession(p.InstanceID, s); err != nil {
+			m.home.SetTransientBanner(fmt.Sprintf("Couldn't record play session: %v", err))
+		}
+		m.syncRunning()
+		return m, m.loadInstances()
+
 	case launchRefreshTriggerMsg:
 		// Online launch gate asked us to silently refresh before launching.
 		acc := m.accounts.GetActive()
@@ -540,9 +839,24 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case ui.PersistHomeSort:
+		m.cfg.HomeSort = msg.Value
+		if err := m.cfg.Save(); err != nil {
+			m.home.SetTransientBanner(fmt.Sprintf("Couldn't save sort preference: %v", err))
+		}
+		return m, nil
+
 	case ui.SettingsSaved:
 		m.cfg.JavaPath = msg.JavaPath
 		m.cfg.JVMArgs = msg.JVMArgs
+		m.cfg.MinMemoryMB = msg.MinMemoryMB
+		m.cfg.MaxMemoryMB = msg.MaxMemoryMB
+		m.cfg.Env = msg.Env
+		m.cfg.UnsetEnv = msg.UnsetEnv
+		m.cfg.WindowWidth = msg.WindowWidth
+		m.cfg.WindowHeight = msg.WindowHeight
+		m.cfg.Fullscreen = msg.Fullscreen
+		m.cfg.DetachGames = msg.DetachGames
 		m.cfg.ShowSnapshots = msg.ShowSnapshots
 		m.cfg.MSAClientID = msg.MSAClientID
 		m.cfg.Theme = msg.Theme
@@ -560,6 +874,35 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// MSA client ID may have changed; re-validate the active session.
 		return m, tea.Batch(m.loadInstances(), m.sessionRecheckCmd())
 
+	case ui.InstanceSettingsSaved:
+		m.state = StateHome
+		m.instSettings = nil
+		if inst := msg.Instance; inst != nil {
+			inst.WindowWidth = msg.WindowWidth
+			inst.WindowHeight = msg.WindowHeight
+			inst.Fullscreen = msg.Fullscreen
+			inst.Detach = msg.Detach
+			inst.MinMemoryMB = msg.MinMemoryMB
+			inst.MaxMemoryMB = msg.MaxMemoryMB
+			inst.JVMPreset = msg.JVMPreset
+			inst.JVMArgs = msg.JVMArgs
+			// Arguments of its own already replace the global ones; once set, the
+			// instance goes back to inheriting them when they're cleared.
+			inst.JVMArgsOverride = inst.JVMArgsOverride && len(msg.JVMArgs) == 0
+			inst.PreLaunchCommand = msg.PreLaunchCommand
+			inst.WrapperCommand = msg.WrapperCommand
+			inst.PostExitCommand = msg.PostExitCommand
+			inst.Env = msg.Env
+			inst.UnsetEnv = msg.UnsetEnv
+			if err := m.instances.Update(inst); err != nil {
+				m.home.SetTransientBanner(fmt.Sprintf("Couldn't save instance settings: %v", err))
+			} else {
+				m.home.SetTransientBanner(fmt.Sprintf("Saved settings for %s.", inst.Name))
+			}
+			return m, m.loadInstancesSelecting(inst.ID)
+		}
+		return m, m.loadInstances()
+
 	case ui.ModInstallDoneMsg:
 		if m.mods != nil {
 			newMods, cmd := m.mods.Update(msg)
@@ -568,8 +911,24 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case ui.SaveQuickPlay:
+		if msg.Instance != nil {
+			msg.Instance.QuickPlay = msg.QuickPlay
+			if err := m.instances.Update(msg.Instance); err != nil {
+				m.home.SetTransientBanner(fmt.Sprintf("Couldn't save Quick Play target: %v", err))
+			} else if msg.QuickPlay != nil {
+				m.home.SetTransientBanner(fmt.Sprintf("Quick Play set to %s. Press [c] to connect.", msg.QuickPlay.Label()))
+			}
+			return m, m.loadInstancesSelecting(msg.Instance.ID)
+		}
+		return m, nil
+
 	case ui.DeleteInstance:
 		if msg.Instance != nil {
+			if m.isRunning(msg.Instance.ID) {
+				m.home.SetTransientBanner(fmt.Sprintf("%s is running. Stop it with [x] before deleting.", msg.Instance.Name))
+				return m, nil
+			}
 			if err := m.instances.Delete(msg.Instance.ID); err != nil {
 				m.home.SetTransientBanner(fmt.Sprintf("Couldn't delete instance: %v", err))
 			}
@@ -588,51 +947,88 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Launch status updates - continue subscription
 	case ui.LaunchStatusUpdate:
-		var cmd tea.Cmd
-		if m.launch != nil {
-			_, cmd = m.launch.Update(msg)
+		s := m.launches[msg.InstanceID]
+		if s == nil {
+			return m, nil
 		}
+		_, cmd := s.model.Update(msg)
+		m.syncRunning()
 		// Continue listening for more status updates
-		return m, tea.Batch(cmd, m.waitForLaunchStatus(m.launchStatusChan))
+		return m, tea.Batch(cmd, m.waitForLaunchStatus(msg.InstanceID, s.statusChan))
 
-	// Cancel launch - user pressed ESC
+	// Cancel launch - user pressed ESC before the game started
 	case ui.CancelLaunch:
-		// Cancel the context to stop ongoing operations
-		if m.launchCtxCancel != nil {
-			m.launchCtxCancel()
-			m.launchCtxCancel = nil
+		// Cancel the context to stop ongoing operations. The session stays until
+		// its launcher goroutine reports completion, so its channel keeps draining.
+		if s := m.launches[msg.InstanceID]; s != nil {
+			s.cancelled = true
+			if s.cancel != nil {
+				s.cancel()
+			}
 		}
-		// Clean up
-		m.launchStatusChan = nil
 		// Return to home
 		m.state = StateHome
+		m.syncRunning()
 		return m, tea.Batch(m.loadInstances(), m.sessionRecheckCmd())
 
 	// Launch complete - clean up
 	case ui.LaunchComplete:
-		var cmd tea.Cmd
-		if m.launch != nil {
-			newLaunch, c := m.launch.Update(msg)
-			m.launch = newLaunch.(*ui.LaunchModel)
-			cmd = c
+		s := m.launches[msg.InstanceID]
+		if s == nil {
+			return m, nil
 		}
-		// Clean up
-		m.launchStatusChan = nil
-		if m.launchCtxCancel != nil {
-			m.launchCtxCancel = nil
+		newLaunch, cmd := s.model.Update(msg)
+		s.model = newLaunch.(*ui.LaunchModel)
+		if s.cancel != nil {
+			s.cancel()
+			s.cancel = nil
 		}
+		onScreen := m.launchFocus == msg.InstanceID && (m.state == StateLaunch || m.state == StateLogs)
+		switch {
+		case s.cancelled:
+			delete(m.launches, msg.InstanceID)
+			cmd = nil
+		case !onScreen:
+			// Finished in the background: report it on home and forget the session.
+			delete(m.launches, msg.InstanceID)
+			if msg.Error != nil {
+				m.home.SetTransientBanner(fmt.Sprintf("%s: %v", s.model.GetInstance().Name, msg.Error))
+			}
+			cmd = m.loadInstances()
+		case m.state != StateLaunch:
+			// The launch screen's auto-return only applies while it is on screen;
+			// don't pull the user out of the log viewer when the game exits.
+			cmd = nil
+		}
+		m.syncRunning()
 		return m, cmd
 
 	// Retry launch
 	case ui.RetryLaunch:
-		if m.launch != nil {
-			inst := m.launch.GetInstance()
-			if msg.Offline {
-				return m, m.beginLaunch(inst, true)
-			}
-			return m, m.gateOnlineLaunch(inst)
+		s := m.launches[msg.InstanceID]
+		if s == nil {
+			return m, nil
 		}
-		return m, nil
+		inst := s.model.GetInstance()
+		m.launchQuickPlay = s.quickPlay
+		if msg.Offline {
+			retry := m.openLaunchScreen(inst)
+			return m, tea.Batch(retry.model.Init(), m.beginLaunch(inst, true))
+		}
+		return m, m.gateOnlineLaunch(inst, s.quickPlay)
+
+	case ui.SwitchJava:
+		s := m.launches[msg.InstanceID]
+		if s == nil {
+			return m, nil
+		}
+		inst := s.model.GetInstance()
+		inst.JavaPath = msg.Path
+		// The retry uses the new path either way; a failed save only means the
+		// mismatch comes back next session.
+		_ = m.instances.Update(inst)
+		id := msg.InstanceID
+		return m, func() tea.Msg { return ui.RetryLaunch{InstanceID: id} }
 
 	// Global key handlers
 	case tea.KeyMsg:
@@ -667,9 +1063,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 	case StateLaunch:
-		if m.launch != nil {
-			newLaunch, cmd := m.launch.Update(msg)
-			m.launch = newLaunch.(*ui.LaunchModel)
+		if s := m.focusedLaunch(); s != nil {
+			newLaunch, cmd := s.model.Update(msg)
+			s.model = newLaunch.(*ui.LaunchModel)
 			cmds = append(cmds, cmd)
 		}
 	case StateMods:
@@ -690,18 +1086,43 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.settings = newSettings.(*ui.SettingsModel)
 			cmds = append(cmds, cmd)
 		}
+	case StateInstanceSettings:
+		if m.instSettings != nil {
+			newInstSettings, cmd := m.instSettings.Update(msg)
+			m.instSettings = newInstSettings.(*ui.InstanceSettingsModel)
+			cmds = append(cmds, cmd)
+		}
+	case StateLogs:
+		if m.logs != nil {
+			newLogs, cmd := m.logs.Update(msg)
+			m.logs = newLogs.(*ui.LogsModel)
+			cmds = append(cmds, cmd)
+		}
+	case StateRepair:
+		if m.repair != nil {
+			_, cmd := m.repair.model.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	case StateJava:
+		if m.java != nil {
+			_, cmd := m.java.Update(msg)
+			cmds = append(cmds, cmd)
+		}
 	}
 
 	return m, tea.Batch(cmds...)
 }
 
-// beginLaunch sets up the launch context and status channel on the event loop
-// (so reads/writes of m.launchCtxCancel and m.launchStatusChan stay single-threaded),
-// then hands them to startLaunch's command goroutine.
+// beginLaunch sets up the launch context and status channel of inst's session on
+// the event loop (so reads/writes of the session stay single-threaded), then hands
+// them to startLaunch's command goroutine. The session must already exist (see
+// openLaunchScreen).
 func (m *Model) beginLaunch(inst *core.Instance, offline bool) tea.Cmd {
 	ctx, cancel := context.WithCancel(context.Background())
-	m.launchCtxCancel = cancel
-	m.launchStatusChan = make(chan launch.Status, 10)
+	session := m.launches[inst.ID]
+	session.cancel = cancel
+	session.statusChan = make(chan launch.Status, 10)
+	session.quickPlay = m.launchQuickPlay
 
 	// Snapshot credentials ON the event loop so the command goroutine never reads
 	// the shared *core.Account fields, which Update may concurrently rewrite when a
@@ -717,23 +1138,35 @@ func (m *Model) beginLaunch(inst *core.Instance, offline bool) tea.Cmd {
 		}
 	}
 
-	return m.startLaunch(ctx, m.launchStatusChan, inst, offline, playerName, uuid, accessToken)
+	// Snapshot the Quick Play target too, so later edits on Home can't race the launch goroutine.
+	var quickPlay *core.QuickPlay
+	if m.launchQuickPlay && inst.QuickPlay.Valid() {
+		q := *inst.QuickPlay
+		quickPlay = &q
+	}
+
+	// The launch works on its own copy of the instance and sends the changes it
+	// keeps back as instanceWriteMsg, so the screens' instance is never written
+	// off the event loop.
+	snapshot := *inst
+	return m.startLaunch(ctx, session.statusChan, &snapshot, offline, playerName, uuid, accessToken, quickPlay)
 }
 
-func (m *Model) startLaunch(ctx context.Context, statusChan chan launch.Status, inst *core.Instance, offline bool, playerName, uuid, accessToken string) tea.Cmd {
+func (m *Model) startLaunch(ctx context.Context, statusChan chan launch.Status, inst *core.Instance, offline bool, playerName, uuid, accessToken string, quickPlay *core.QuickPlay) tea.Cmd {
 	return func() tea.Msg {
 		// Find version info (vanilla or merged loader profile)
 		details, err := loader.ResolveVersionDetails(ctx, m.mojang, inst, offline)
 		if err != nil {
-			return ui.LaunchComplete{Error: err}
+			return ui.LaunchComplete{InstanceID: inst.ID, Error: err}
 		}
 		if loader.ParseKind(inst.Loader) == loader.KindFabric {
-			_ = m.instances.Update(inst)
+			loaderVer := inst.LoaderVer
+			_ = m.queueInstanceEdit(inst.ID, func(i *core.Instance) { i.LoaderVer = loaderVer })
 		}
 
 		// Validate version info
 		if details.MainClass == "" {
-			return ui.LaunchComplete{Error: fmt.Errorf("invalid version info: missing main class")}
+			return ui.LaunchComplete{InstanceID: inst.ID, Error: fmt.Errorf("invalid version info: missing main class")}
 		}
 
 		// Player info (playerName/uuid/accessToken) is snapshotted by beginLaunch on
@@ -745,7 +1178,7 @@ func (m *Model) startLaunch(ctx context.Context, statusChan chan launch.Status,
 			if loader.ParseKind(inst.Loader) == loader.KindFabric && inst.InstallStarterFabricMods {
 				if mods.StarterFabricModsComplete(inst) {
 					inst.InstallStarterFabricMods = false
-					_ = m.instances.Update(inst)
+					_ = m.queueInstanceEdit(inst.ID, func(i *core.Instance) { i.InstallStarterFabricMods = false })
 				} else {
 					svc := mods.NewService(m.modrinth)
 					err := svc.InstallStarterFabricMods(ctx, inst, func(i, total int, label string) {
@@ -769,7 +1202,7 @@ func (m *Model) startLaunch(ctx context.Context, statusChan chan launch.Status,
 						return
 					}
 					inst.InstallStarterFabricMods = false
-					_ = m.instances.Update(inst)
+					_ = m.queueInstanceEdit(inst.ID, func(i *core.Instance) { i.InstallStarterFabricMods = false })
 				}
 			}
 
@@ -780,9 +1213,12 @@ func (m *Model) startLaunch(ctx context.Context, statusChan chan launch.Status,
 				PlayerName:       playerName,
 				UUID:             uuid,
 				AccessToken:      accessToken,
+				QuickPlay:        quickPlay,
+				Supervisor:       m.supervisor,
 				Config:           m.cfg,
-				UpdateLastPlayed: m.instances.UpdateLastPlayed,
-				UpdateInstance:   m.instances.Update,
+				UpdateLastPlayed: m.queueLastPlayed,
+				RecordSession:    m.queueSession,
+				UpdateInstance:   m.queueInstanceEdit,
 			}, statusChan)
 
 			err := launcher.Launch(ctx)
@@ -799,34 +1235,34 @@ func (m *Model) startLaunch(ctx context.Context, statusChan chan launch.Status,
 		}()
 
 		// Return first status update command
-		return m.waitForLaunchStatus(statusChan)()
+		return m.waitForLaunchStatus(inst.ID, statusChan)()
 	}
 }
 
-// waitForLaunchStatus creates a command that waits for the next launch status.
-// The channel is captured by value so the command goroutine never reads the
-// m.launchStatusChan field (which the event loop may set to nil on cancel/complete).
-func (m *Model) waitForLaunchStatus(ch chan launch.Status) tea.Cmd {
+// waitForLaunchStatus creates a command that waits for the next status of an
+// instance's launch. The channel is captured by value so the command goroutine
+// never reads the session, which only the event loop touches.
+func (m *Model) waitForLaunchStatus(id string, ch chan launch.Status) tea.Cmd {
 	return func() tea.Msg {
 		if ch == nil {
-			return ui.LaunchComplete{}
+			return ui.LaunchComplete{InstanceID: id}
 		}
 
 		status, ok := <-ch
 		if !ok {
 			// Channel closed, launch complete
-			return ui.LaunchComplete{}
+			return ui.LaunchComplete{InstanceID: id}
 		}
 
 		if status.Error != nil {
-			return ui.LaunchComplete{Error: status.Error}
+			return ui.LaunchComplete{InstanceID: id, Error: status.Error}
 		}
 
 		if status.IsComplete {
-			return ui.LaunchComplete{}
+			return ui.LaunchComplete{InstanceID: id}
 		}
 
-		return ui.LaunchStatusUpdate{Status: status}
+		return ui.LaunchStatusUpdate{InstanceID: id, Status: status}
 	}
 }
 
@@ -849,8 +1285,8 @@ func (m *Model) shellContent() string {
 			return m.wizard.View()
 		}
 	case StateLaunch:
-		if m.launch != nil {
-			return m.launch.View()
+		if s := m.focusedLaunch(); s != nil {
+			return s.model.View()
 		}
 	case StateAuth:
 		if m.auth != nil {
@@ -868,6 +1304,22 @@ func (m *Model) shellContent() string {
 		if m.settings != nil {
 			return m.settings.View()
 		}
+	case StateInstanceSettings:
+		if m.instSettings != nil {
+			return m.instSettings.View()
+		}
+	case StateLogs:
+		if m.logs != nil {
+			return m.logs.View()
+		}
+	case StateRepair:
+		if m.repair != nil {
+			return m.repair.model.View()
+		}
+	case StateJava:
+		if m.java != nil {
+			return m.java.View()
+		}
 	}
 	return "Unknown state"
 }
@@ -11,6 +11,8 @@ import (
 	"github.com/aayushdutt/mctui/internal/api"
 	"github.com/aayushdutt/mctui/internal/config"
 	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/java"
+	"github.com/aayushdutt/mctui/internal/launch"
 	"github.com/aayushdutt/mctui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/x/exp/teatest"
@@ -34,7 +36,6 @@ func newTestModel(t *testing.T) *Model {
 		InstancesDir:       filepath.Join(tmp, "instances"),
 		AssetsDir:          filepath.Join(tmp, "assets"),
 		LibrariesDir:       filepath.Join(tmp, "libraries"),
-		JVMArgs:            config.DefaultJVMArgs(),
 		Theme:              "dark",
 		MSAClientID:        config.DefaultMSAClientID,
 		LaunchLogVerbosity: "error",
@@ -155,8 +156,14 @@ func TestE2E_SettingsThemePersists(t *testing.T) {
 	tm.Send(keyRunes("s"))
 	waitForOutput(t, tm, "Settings")
 
-	// Focus order: JavaPath, JVMArgs, Snapshots, Theme, MSAClientID, Save.
-	// Tab x3 -> Theme row.
+	// Focus order: JavaPath, MaxMemory, MinMemory, JVMArgs, Env, WindowSize, Fullscreen, Detach, Snapshots, Theme, MSAClientID, Save.
+	// Tab x9 -> Theme row.
+	tm.Send(tea.KeyMsg{Type: tea.KeyTab})
+	tm.Send(tea.KeyMsg{Type: tea.KeyTab})
+	tm.Send(tea.KeyMsg{Type: tea.KeyTab})
+	tm.Send(tea.KeyMsg{Type: tea.KeyTab})
+	tm.Send(tea.KeyMsg{Type: tea.KeyTab})
+	tm.Send(tea.KeyMsg{Type: tea.KeyTab})
 	tm.Send(tea.KeyMsg{Type: tea.KeyTab})
 	tm.Send(tea.KeyMsg{Type: tea.KeyTab})
 	tm.Send(tea.KeyMsg{Type: tea.KeyTab})
@@ -237,6 +244,104 @@ func TestE2E_HomeDeleteConfirm(t *testing.T) {
 	})
 }
 
+func TestRepointJavaPaths(t *testing.T) {
+	m := newTestModel(t)
+	legacy, moved := filepath.Join(t.TempDir(), "java"), filepath.Join(m.cfg.DataDir, "java")
+	moves := java.RuntimeMoves{filepath.Join(legacy, "17"): filepath.Join(moved, "17")}
+	m.cfg.JavaPath = filepath.Join(legacy, "17", "bin", "java")
+	seedInstance(t, m.instances, "Moved")
+	seedInstance(t, m.instances, "System")
+	for _, inst := range m.instances.List() {
+		inst.JavaPath = "/usr/bin/java"
+		if inst.Name == "Moved" {
+			inst.JavaPath = filepath.Join(legacy, "17", "bin", "java")
+		}
+		m.instances.Update(inst)
+	}
+
+	repointJavaPaths(m.cfg, m.instances, moves)
+
+	want := filepath.Join(moved, "17", "bin", "java")
+	if m.cfg.JavaPath != want {
+		t.Errorf("config Java = %s, want %s", m.cfg.JavaPath, want)
+	}
+	if saved, err := os.ReadFile(filepath.Join(m.cfg.DataDir, "config.json")); err != nil || !strings.Contains(string(saved), want) {
+		t.Errorf("config not saved with the new path: %v", err)
+	}
+	reloaded := core.NewInstanceManager(m.cfg.DataDir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	for _, inst := range reloaded.List() {
+		wantInst := "/usr/bin/java"
+		if inst.Name == "Moved" {
+			wantInst = want
+		}
+		if inst.JavaPath != wantInst {
+			t.Errorf("%s Java = %s, want %s", inst.Name, inst.JavaPath, wantInst)
+		}
+	}
+}
+
+func TestInstanceWrites_AppliedOnEventLoop(t *testing.T) {
+	m := newTestModel(t)
+	seedInstance(t, m.instances, "Queued")
+	inst := m.instances.List()[0]
+
+	go m.queueInstanceEdit(inst.ID, func(i *core.Instance) { i.JavaPath = "/opt/java/bin/java" })
+	msg := m.waitForInstanceWrite()()
+	if inst.JavaPath != "" {
+		t.Fatal("the edit was made before it reached the event loop")
+	}
+	m.Update(msg)
+	if inst.JavaPath != "/opt/java/bin/java" {
+		t.Errorf("JavaPath = %q after the write was applied", inst.JavaPath)
+	}
+	reloaded := core.NewInstanceManager(m.cfg.DataDir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := reloaded.Get(inst.ID); got == nil || got.JavaPath != "/opt/java/bin/java" {
+		t.Errorf("the edit wasn't saved: %+v", got)
+	}
+}
+
+func TestInstanceWrites_RecordSessionOnEventLoop(t *testing.T) {
+	m := newTestModel(t)
+	seedInstance(t, m.instances, "Played")
+	inst := m.instances.List()[0]
+
+	end := time.Now()
+	go m.queueSession(inst.ID, core.NewSession(end.Add(-90*time.Second), end, 0, "Player", true))
+	msg := m.waitForInstanceWrite()()
+	if inst.PlayTime != 0 {
+		t.Fatal("playtime changed before the session reached the event loop")
+	}
+	m.Update(msg)
+	if inst.PlayTime != 90 {
+		t.Errorf("PlayTime = %d, want 90", inst.PlayTime)
+	}
+	if sessions, err := core.LoadSessions(inst.Path); err != nil || len(sessions) != 1 {
+		t.Errorf("sessions = %v, %v; want the one recorded", sessions, err)
+	}
+}
+
+func TestRepairComplete_AppliesRunChanges(t *testing.T) {
+	m := newTestModel(t)
+	seedInstance(t, m.instances, "Repaired")
+	inst := m.instances.List()[0]
+	m.repair = &repairSession{model: ui.NewRepairModel(inst), instanceID: inst.ID, cancel: func() {}}
+
+	m.Update(ui.RepairComplete{
+		InstanceID: inst.ID,
+		Report:     &launch.RepairReport{Checked: 3},
+		Edit:       func(i *core.Instance) { i.IsFullyDownloaded, i.DownloadCacheKey = true, "1.21.4|vanilla|" },
+	})
+	if !inst.IsFullyDownloaded || inst.DownloadCacheKey != "1.21.4|vanilla|" {
+		t.Errorf("download cache = %v %q, want the repair's", inst.IsFullyDownloaded, inst.DownloadCacheKey)
+	}
+}
+
 // --- helpers ---
 
 // seedInstance creates an instance on disk via the manager before the model boots.
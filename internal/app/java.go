@@ -0,0 +1,154 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aayushdutt/mctui/internal/config"
+	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/java"
+	"github.com/aayushdutt/mctui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// javaInstall is a runtime download started from the Java screen. It outlives
+// the screen, so leaving doesn't abandon a half-installed runtime.
+type javaInstall struct {
+	major  int
+	status chan string
+	done   chan error
+}
+
+// javaDeletedMsg reports a managed runtime's removal from disk.
+type javaDeletedMsg struct {
+	runtime java.Runtime
+	err     error
+}
+
+// repointJavaPaths points the global and per-instance Java paths that lead into
+// runtimes moved by java.MigrateLegacyRuntimes at their new location.
+func repointJavaPaths(cfg *config.Config, instances *core.InstanceManager, moves java.RuntimeMoves) {
+	if path, ok := moves.Rewrite(cfg.JavaPath); ok {
+		cfg.JavaPath = path
+		_ = cfg.Save()
+	}
+	if instances.Load() != nil {
+		return
+	}
+	for _, inst := range instances.List() {
+		if path, ok := moves.Rewrite(inst.JavaPath); ok {
+			inst.JavaPath = path
+			_ = instances.Update(inst)
+		}
+	}
+}
+
+// openJava shows the Java screen, picking for inst when it is set, and starts
+// looking for runtimes.
+func (m *Model) openJava(inst *core.Instance) tea.Cmd {
+	m.state = StateJava
+	m.java = ui.NewJavaModel(inst, m.instances.List(), m.cfg.JavaPath)
+	cw, ch := m.contentSize()
+	m.java.SetSize(cw, ch)
+	if m.javaInstall != nil {
+		m.java.SetInstalling(m.javaInstall.major)
+	}
+	return tea.Batch(m.java.Init(), m.loadJavaRuntimes())
+}
+
+// loadJavaRuntimes probes the managed runtimes and the system's installations,
+// which runs each java once, so it happens off the event loop.
+func (m *Model) loadJavaRuntimes() tea.Cmd {
+	dataDir := m.cfg.DataDir
+	return func() tea.Msg {
+		return ui.JavaRuntimesLoaded{Runtimes: java.FindRuntimes(dataDir)}
+	}
+}
+
+// startJavaInstall downloads the runtime for a Java major version into the
+// managed directory: Mojang's build when it ships one, Adoptium's otherwise.
+func (m *Model) startJavaInstall(major int) tea.Cmd {
+	if m.javaInstall != nil {
+		return nil
+	}
+	s := &javaInstall{
+		major:  major,
+		status: make(chan string, 10),
+		done:   make(chan error, 1),
+	}
+	m.javaInstall = s
+
+	dest := java.ManagedDir(m.cfg.DataDir)
+	go func() {
+		_, err := java.NewDownloader().Install(context.Background(), java.MojangComponent(major), major, dest, func(msg string) {
+			select {
+			case s.status <- msg:
+			default: // the screen only needs the latest
+			}
+		})
+		s.done <- err
+	}()
+	return waitForJavaInstall(s)
+}
+
+// waitForJavaInstall waits for the next status or the result of an install.
+func waitForJavaInstall(s *javaInstall) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case msg := <-s.status:
+			return ui.JavaInstallStatus{Message: msg}
+		case err := <-s.done:
+			return ui.JavaInstallDone{Major: s.major, Err: err}
+		}
+	}
+}
+
+// deleteJava unpins r from every instance and the default, then removes it
+// from disk. It refuses while a game is running on it.
+func (m *Model) deleteJava(r java.Runtime) tea.Cmd {
+	users := m.instancesUsingJava(r.Path)
+	for _, inst := range users {
+		if m.isRunning(inst.ID) {
+			m.java.SetStatus(fmt.Sprintf("%s is running on this Java. Stop it before deleting.", inst.Name))
+			return nil
+		}
+	}
+	for _, inst := range users {
+		if inst.JavaPath == "" {
+			continue // follows the default, cleared below
+		}
+		inst.JavaPath = ""
+		if err := m.instances.Update(inst); err != nil {
+			m.java.SetStatus(fmt.Sprintf("Couldn't update %s: %v", inst.Name, err))
+			return nil
+		}
+	}
+	if r.Path != "" && r.Path == m.cfg.JavaPath {
+		m.cfg.JavaPath = ""
+		if err := m.cfg.Save(); err != nil {
+			m.java.SetStatus(fmt.Sprintf("Couldn't write config: %v", err))
+			return nil
+		}
+	}
+	m.java.SetUsage(m.instances.List(), m.cfg.JavaPath)
+
+	dataDir := m.cfg.DataDir
+	return func() tea.Msg {
+		return javaDeletedMsg{runtime: r, err: java.RemoveManaged(dataDir, r.Name)}
+	}
+}
+
+// instancesUsingJava lists the instances that launch with the java at path:
+// those pinned to it, and when it's the default, those without their own.
+func (m *Model) instancesUsingJava(path string) []*core.Instance {
+	if path == "" {
+		return nil
+	}
+	var users []*core.Instance
+	for _, inst := range m.instances.List() {
+		if inst.JavaPath == path || (inst.JavaPath == "" && m.cfg.JavaPath == path) {
+			users = append(users, inst)
+		}
+	}
+	return users
+}
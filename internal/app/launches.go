@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/launch"
+	"github.com/aayushdutt/mctui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// launchSession is one instance's launch: its launch screen, the status stream
+// from its launcher goroutine, and the cancel func for the launch context.
+// Sessions outlive the launch screen, so a game keeps running (and its status
+// keeps being drained) while the user is elsewhere.
+type launchSession struct {
+	model      *ui.LaunchModel
+	statusChan chan launch.Status
+	cancel     context.CancelFunc
+	quickPlay  bool // joins the instance's Quick Play target; reused on retry
+	cancelled  bool // the user cancelled before the game started; drop on completion
+}
+
+// active reports whether the session is still preparing or playing.
+func (s *launchSession) active() bool {
+	return !s.cancelled && !s.model.Done()
+}
+
+// focusedLaunch is the session shown on the launch screen, if any.
+func (m *Model) focusedLaunch() *launchSession {
+	return m.launches[m.launchFocus]
+}
+
+// showLaunch switches to an instance's launch screen.
+func (m *Model) showLaunch(id string) {
+	m.state = StateLaunch
+	m.launchFocus = id
+}
+
+// openLaunchScreen starts a fresh launch session for inst (replacing a finished
+// one) and shows it. The caller kicks off the launch with beginLaunch.
+func (m *Model) openLaunchScreen(inst *core.Instance) *launchSession {
+	s := &launchSession{model: ui.NewLaunchModel(inst, m.cfg)}
+	cw, ch := m.contentSize()
+	s.model.SetSize(cw, ch)
+	m.launches[inst.ID] = s
+	m.showLaunch(inst.ID)
+	return s
+}
+
+// pruneLaunches forgets finished sessions once their launch screen is no longer shown.
+func (m *Model) pruneLaunches() {
+	for id, s := range m.launches {
+		if s.model.Done() && (m.state != StateLaunch || id != m.launchFocus) {
+			delete(m.launches, id)
+		}
+	}
+}
+
+// syncRunning updates the home list's running badges from the launch sessions
+// and the supervisor.
+func (m *Model) syncRunning() {
+	running := map[string]ui.RunningInstance{}
+	for id, s := range m.launches {
+		if s.active() {
+			running[id] = ui.RunningInstance{}
+		}
+	}
+	for _, p := range m.supervisor.Running() {
+		running[p.InstanceID] = ui.RunningInstance{Started: p.Started}
+	}
+	m.home.SetRunning(running)
+}
+
+// showActiveLaunch shows inst's launch screen instead of starting a second copy
+// when it is already launching or running, reporting whether it did.
+func (m *Model) showActiveLaunch(inst *core.Instance) bool {
+	if s := m.launches[inst.ID]; s != nil && s.active() {
+		m.showLaunch(inst.ID)
+		return true
+	}
+	return false
+}
+
+// isRunning reports whether an instance has a launch in progress or a game running.
+func (m *Model) isRunning(id string) bool {
+	if s := m.launches[id]; s != nil && s.active() {
+		return true
+	}
+	_, ok := m.supervisor.Get(id)
+	return ok
+}
+
+// instanceWriteMsg is a change to a stored instance made by a launch goroutine.
+// It is applied on the event loop, which owns the *core.Instance values the
+// screens show.
+type instanceWriteMsg struct {
+	id      string
+	edit    func(*core.Instance)
+	session *core.Session // a finished play session to record instead of an edit
+}
+
+// queueInstanceEdit hands edit to the event loop to make to instance id and save.
+// It is launch.Options.UpdateInstance for the launches the app starts.
+func (m *Model) queueInstanceEdit(id string, edit func(*core.Instance)) error {
+	m.instWrites <- instanceWriteMsg{id: id, edit: edit}
+	return nil
+}
+
+// queueLastPlayed stamps instance id as played now, through the event loop.
+func (m *Model) queueLastPlayed(id string) error {
+	now := time.Now()
+	return m.queueInstanceEdit(id, func(inst *core.Instance) { inst.LastPlayed = now })
+}
+
+// queueSession records a finished play session for instance id through the
+// event loop. It is launch.Options.RecordSession for the launches the app
+// starts; a failure to save shows on home.
+func (m *Model) queueSession(id string, s core.Session) error {
+	m.instWrites <- instanceWriteMsg{id: id, session: &s}
+	return nil
+}
+
+// waitForInstanceWrite waits for the next queued instance change.
+func (m *Model) waitForInstanceWrite() tea.Cmd {
+	ch := m.instWrites
+	return func() tea.Msg { return <-ch }
+}
+
+// applyInstanceWrite makes a queued change to the stored instance and saves it.
+// An instance deleted since is skipped.
+func (m *Model) applyInstanceWrite(msg instanceWriteMsg) error {
+	if msg.session != nil {
+		return m.instances.RecordSession(msg.id, *msg.session)
+	}
+	inst, ok := m.instances.Get(msg.id)
+	if !ok {
+		return nil
+	}
+	msg.edit(inst)
+	return m.instances.Update(inst)
+}
+
+// gamesReattachedMsg carries the detached games from an earlier run that are
+// still going, and those that ended while mctui was closed.
+type gamesReattachedMsg struct {
+	procs []launch.Process
+	ended []launch.Process
+	err   error
+}
+
+// reattachedExitMsg reports that a reattached game has exited.
+type reattachedExitMsg struct{ proc launch.Process }
+
+// reattachGames picks up detached games a previous mctui left running.
+func (m *Model) reattachGames() tea.Cmd {
+	return func() tea.Msg {
+		procs, ended, err := m.supervisor.Reattach()
+		return gamesReattachedMsg{procs: procs, ended: ended, err: err}
+	}
+}
+
+// waitReattached reports when a reattached game exits.
+func (m *Model) waitReattached(id string) tea.Cmd {
+	return func() tea.Msg {
+		p, ok := m.supervisor.WaitReattached(id)
+		if !ok {
+			return nil
+		}
+		return reattachedExitMsg{proc: p}
+	}
+}
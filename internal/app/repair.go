@@ -0,0 +1,203 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aayushdutt/mctui/internal/api"
+	"github.com/aayushdutt/mctui/internal/config"
+	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/download"
+	"github.com/aayushdutt/mctui/internal/launch"
+	"github.com/aayushdutt/mctui/internal/loader"
+	"github.com/aayushdutt/mctui/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// repairSession is the verify & repair run on screen. It stays until its
+// goroutine reports completion, even once cancelled, so a new run can't be
+// confused with the one winding down.
+type repairSession struct {
+	model      *ui.RepairModel
+	instanceID string
+	statusChan chan launch.Status
+	done       chan ui.RepairComplete
+	cancel     context.CancelFunc
+	cancelled  bool
+}
+
+// startRepair opens the verify & repair screen for inst and starts the run.
+func (m *Model) startRepair(inst *core.Instance) tea.Cmd {
+	if m.isRunning(inst.ID) {
+		m.home.SetTransientBanner(fmt.Sprintf("Stop %s before verifying its files.", inst.Name))
+		return nil
+	}
+	if m.repair != nil && !m.repair.model.Done() {
+		if !m.repair.cancelled {
+			m.state = StateRepair
+			return nil
+		}
+		m.home.SetTransientBanner("Still cancelling the last verify; try again in a moment.")
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &repairSession{
+		model:      ui.NewRepairModel(inst),
+		instanceID: inst.ID,
+		statusChan: make(chan launch.Status, 10),
+		done:       make(chan ui.RepairComplete, 1),
+		cancel:     cancel,
+	}
+	cw, ch := m.contentSize()
+	s.model.SetSize(cw, ch)
+	m.repair = s
+	m.state = StateRepair
+
+	// The run works on its own copy of the instance; RepairComplete brings the
+	// changes back to the event loop.
+	cfg, mojang, snapshot := m.cfg, m.mojang, *inst
+	go func() {
+		report, edit, err := repairInstance(ctx, cfg, mojang, &snapshot, s.statusChan)
+		s.done <- ui.RepairComplete{InstanceID: snapshot.ID, Report: report, Edit: edit, Err: err}
+	}()
+	return tea.Batch(s.model.Init(), waitForRepair(s))
+}
+
+// waitForRepair waits for the next status or the result of a run.
+func waitForRepair(s *repairSession) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case status := <-s.statusChan:
+			return ui.RepairStatusUpdate{InstanceID: s.instanceID, Status: status}
+		case done := <-s.done:
+			return done
+		}
+	}
+}
+
+// repairInstance re-resolves inst's version metadata, rebuilding a loader's
+// cached profile, then verifies every file it needs and downloads whatever is
+// missing or corrupt. inst is the run's own copy: edit makes the changes worth
+// keeping (the loader version and download cache) to the stored instance, and
+// is set even when err is.
+func repairInstance(ctx context.Context, cfg *config.Config, mojang *api.MojangClient, inst *core.Instance, statusChan chan<- launch.Status) (report *launch.RepairReport, edit func(*core.Instance), err error) {
+	var edits []func(*core.Instance)
+	edit = func(stored *core.Instance) {
+		for _, e := range edits {
+			e(stored)
+		}
+	}
+
+	if statusChan != nil {
+		statusChan <- launch.Status{Step: ui.RepairStepResolve, Message: ui.RepairStepResolve + "..."}
+	}
+	details, profile, err := loader.VerifyVersionDetails(ctx, mojang, inst)
+	if err != nil {
+		return nil, edit, fmt.Errorf("%s: %w", ui.RepairStepResolve, err)
+	}
+	if details.MainClass == "" {
+		return nil, edit, fmt.Errorf("%s: invalid version info: missing main class", ui.RepairStepResolve)
+	}
+	if loader.ParseKind(inst.Loader) == loader.KindFabric {
+		loaderVer := inst.LoaderVer
+		edits = append(edits, func(i *core.Instance) { i.LoaderVer = loaderVer })
+	}
+
+	launcher := launch.NewLauncher(&launch.Options{
+		Instance:    inst,
+		VersionInfo: details,
+		Config:      cfg,
+		UpdateInstance: func(_ string, e func(*core.Instance)) error {
+			edits = append(edits, e)
+			return nil
+		},
+	}, statusChan)
+	report, err = launcher.Repair(ctx)
+	if report != nil {
+		report.Profile = profile
+	}
+	return report, edit, err
+}
+
+// Verify runs verify & repair on the instance named ref (its ID or name)
+// without the TUI, writing progress and the report to out. It fails when the
+// instance isn't found, the run couldn't finish, or any file is still broken.
+func Verify(ctx context.Context, ref string, out io.Writer) error {
+	cfg, _ := config.Load()
+	cfg.EnsureDirs()
+	configureDownloads(cfg)
+
+	instances := core.NewInstanceManager(cfg.DataDir)
+	if err := instances.Load(); err != nil {
+		return fmt.Errorf("loading instances: %w", err)
+	}
+	inst := findInstance(instances, ref)
+	if inst == nil {
+		return fmt.Errorf("no instance named %q", ref)
+	}
+
+	statusChan := make(chan launch.Status, 10)
+	printed := make(chan struct{})
+	go func() {
+		defer close(printed)
+		step := ""
+		for s := range statusChan {
+			if s.Step != step {
+				step = s.Step
+				fmt.Fprintf(out, "%s...\n", step)
+			}
+		}
+	}()
+	snapshot := *inst
+	report, edit, err := repairInstance(ctx, cfg, api.NewMojangClient(cfg.DataDir), &snapshot, statusChan)
+	close(statusChan)
+	<-printed
+	edit(inst)
+	if err := instances.Update(inst); err != nil {
+		fmt.Fprintf(out, "Couldn't save %s: %v\n", inst.Name, err)
+	}
+
+	if report != nil {
+		writeRepairReport(out, report)
+	}
+	if err != nil {
+		return err
+	}
+	if len(report.Failed) > 0 {
+		return &download.BatchError{Failures: report.Failed}
+	}
+	return nil
+}
+
+// findInstance looks ref up as an instance ID, then as a name (ignoring case).
+func findInstance(instances *core.InstanceManager, ref string) *core.Instance {
+	if inst, ok := instances.Get(ref); ok {
+		return inst
+	}
+	for _, inst := range instances.List() {
+		if strings.EqualFold(inst.Name, ref) {
+			return inst
+		}
+	}
+	return nil
+}
+
+// writeRepairReport prints a report for the headless verify command.
+func writeRepairReport(out io.Writer, r *launch.RepairReport) {
+	fmt.Fprintln(out, r.Summary())
+	if r.Profile != "" {
+		fmt.Fprintln(out, r.Profile)
+	}
+	for _, p := range r.Missing {
+		fmt.Fprintf(out, "  repaired (missing)  %s\n", p)
+	}
+	for _, p := range r.Corrupt {
+		fmt.Fprintf(out, "  repaired (corrupt)  %s\n", p)
+	}
+	for _, o := range r.Failed {
+		fmt.Fprintf(out, "  failed (%s)  %s: %v\n", o.Kind, o.Item.Path, o.Err)
+	}
+}
@@ -5,6 +5,10 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
+
+	"github.com/aayushdutt/mctui/internal/download"
+	"github.com/aayushdutt/mctui/internal/memory"
 )
 
 // Config holds the application configuration
@@ -19,25 +23,52 @@ type Config struct {
 	JavaPath string   `json:"javaPath"`
 	JVMArgs  []string `json:"jvmArgs"`
 
+	// Java heap size for every instance; instances may override. 0 picks it:
+	// memory.DefaultMinMB for the minimum, a recommendation for the maximum.
+	MinMemoryMB int `json:"minMemoryMB,omitempty"`
+	MaxMemoryMB int `json:"maxMemoryMB,omitempty"`
+
+	// Environment for every game process, applied over mctui's own; instances may override.
+	Env      map[string]string `json:"env,omitempty"`
+	UnsetEnv []string          `json:"unsetEnv,omitempty"` // inherited variables to remove
+
 	// UI preferences
 	Theme         string `json:"theme"`
 	ShowSnapshots bool   `json:"showSnapshots"`
+	HomeSort      string `json:"homeSort,omitempty"` // home list order: "recent" (default) or "playtime"
 
 	// Auth
 	MSAClientID string `json:"msaClientID"`
 
+	// Download limits shared by every transfer. 0 means unlimited / the default per-host limit.
+	DownloadLimitKBps          int64 `json:"downloadLimitKBps,omitempty"`
+	DownloadConnectionsPerHost int   `json:"downloadConnectionsPerHost,omitempty"`
+
+	// Mirrors rewrites download URLs by prefix, e.g. to a LAN cache; the upstream stays the last fallback.
+	Mirrors []download.Mirror `json:"mirrors,omitempty"`
+
+	// Game window defaults; instances may override. Zero width/height keeps the game's own default.
+	WindowWidth  int  `json:"windowWidth,omitempty"`
+	WindowHeight int  `json:"windowHeight,omitempty"`
+	Fullscreen   bool `json:"fullscreen,omitempty"`
+
+	// DetachGames starts games in their own session with output going to the session log,
+	// so they keep running after mctui exits; instances may override.
+	DetachGames bool `json:"detachGames,omitempty"`
+
 	// LaunchLogVerbosity filters game output in the launch view: "error", "warn", or "all".
 	LaunchLogVerbosity string `json:"launchLogVerbosity,omitempty"`
+	// SessionLogRetention is how many full session logs to keep per instance; 0 uses the default.
+	SessionLogRetention int `json:"sessionLogRetention,omitempty"`
 }
 
 const (
 	DefaultMSAClientID = "c36a9fb6-4f2a-41ff-90bd-ae7cc92031eb"
 )
 
-// DefaultJVMArgs returns the default JVM arguments for a new instance/config.
-func DefaultJVMArgs() []string {
-	return []string{"-Xmx2G", "-Xms512M"}
-}
+// legacyDefaultJVMArgs is what JVMArgs defaulted to before memory had
+// settings of its own.
+var legacyDefaultJVMArgs = []string{"-Xmx2G", "-Xms512M"}
 
 // DefaultConfig returns a config with sensible defaults
 func DefaultConfig() *Config {
@@ -47,7 +78,6 @@ func DefaultConfig() *Config {
 		InstancesDir:       filepath.Join(dataDir, "instances"),
 		AssetsDir:          filepath.Join(dataDir, "assets"),
 		LibrariesDir:       filepath.Join(dataDir, "libraries"),
-		JVMArgs:            DefaultJVMArgs(),
 		Theme:              "auto",
 		ShowSnapshots:      false,
 		MSAClientID:        DefaultMSAClientID,
@@ -79,10 +109,31 @@ func Load() (*Config, error) {
 	if cfg.LaunchLogVerbosity == "" {
 		cfg.LaunchLogVerbosity = "error"
 	}
+	cfg.migrateMemoryArgs()
 
 	return cfg, nil
 }
 
+// migrateMemoryArgs moves -Xms/-Xmx out of JVMArgs into the memory settings.
+// The old defaults are dropped instead, so those configs get recommendations.
+func (c *Config) migrateMemoryArgs() {
+	if slices.Equal(c.JVMArgs, legacyDefaultJVMArgs) {
+		c.JVMArgs = nil
+		return
+	}
+	rest, minMB, maxMB := memory.SplitArgs(c.JVMArgs)
+	if minMB == 0 && maxMB == 0 {
+		return
+	}
+	c.JVMArgs = rest
+	if c.MinMemoryMB == 0 {
+		c.MinMemoryMB = minMB
+	}
+	if c.MaxMemoryMB == 0 {
+		c.MaxMemoryMB = maxMB
+	}
+}
+
 // Save writes config to disk
 func (c *Config) Save() error {
 	if err := os.MkdirAll(c.DataDir, 0755); err != nil {
@@ -1,41 +1,42 @@
 package config
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
 
-func TestDefaultJVMArgs_ReturnsExpected(t *testing.T) {
-	got := DefaultJVMArgs()
-	want := []string{"-Xmx2G", "-Xms512M"}
-	if len(got) != len(want) {
-		t.Fatalf("DefaultJVMArgs() = %v, want %v", got, want)
-	}
-	for i := range want {
-		if got[i] != want[i] {
-			t.Fatalf("DefaultJVMArgs()[%d] = %q, want %q", i, got[i], want[i])
-		}
+func TestDefaultConfig_LeavesMemoryToTheRecommendation(t *testing.T) {
+	cfg := DefaultConfig()
+	if len(cfg.JVMArgs) != 0 || cfg.MinMemoryMB != 0 || cfg.MaxMemoryMB != 0 {
+		t.Fatalf("DefaultConfig() = JVMArgs %v, memory %d-%d MB; want no memory pinned", cfg.JVMArgs, cfg.MinMemoryMB, cfg.MaxMemoryMB)
 	}
 }
 
-// DefaultJVMArgs must hand out a fresh slice each call — that's the whole reason
-// it's a function and not a shared package-level var. Mutating one result must
-// not leak into the next caller (or into a Config built from it).
-func TestDefaultJVMArgs_ReturnsFreshSlice(t *testing.T) {
-	a := DefaultJVMArgs()
-	a[0] = "-Xmx8G"
-	b := DefaultJVMArgs()
-	if b[0] != "-Xmx2G" {
-		t.Fatalf("DefaultJVMArgs() shares backing storage: second call returned %q after mutating the first", b[0])
+func TestLoad_MigratesMemoryArgs(t *testing.T) {
+	tests := []struct {
+		jvmArgs      string
+		wantArgs     []string
+		minMB, maxMB int
+	}{
+		// The old default moves to the recommendation rather than pinning 2G.
+		{`["-Xmx2G", "-Xms512M"]`, nil, 0, 0},
+		{`["-Xmx6G", "-XX:+UseG1GC"]`, []string{"-XX:+UseG1GC"}, 0, 6144},
+		{`["-XX:+UseG1GC"]`, []string{"-XX:+UseG1GC"}, 0, 0},
 	}
-}
+	for _, tt := range tests {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+		dir := filepath.Join(os.Getenv("XDG_DATA_HOME"), "mctui")
+		os.MkdirAll(dir, 0755)
+		os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"jvmArgs": `+tt.jvmArgs+`}`), 0644)
 
-func TestDefaultConfig_UsesDefaultJVMArgs(t *testing.T) {
-	cfg := DefaultConfig()
-	want := DefaultJVMArgs()
-	if len(cfg.JVMArgs) != len(want) {
-		t.Fatalf("DefaultConfig().JVMArgs = %v, want %v", cfg.JVMArgs, want)
-	}
-	for i := range want {
-		if cfg.JVMArgs[i] != want[i] {
-			t.Fatalf("DefaultConfig().JVMArgs[%d] = %q, want %q", i, cfg.JVMArgs[i], want[i])
+		cfg, err := Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(cfg.JVMArgs, tt.wantArgs) || cfg.MinMemoryMB != tt.minMB || cfg.MaxMemoryMB != tt.maxMB {
+			t.Errorf("%s: JVMArgs %q, memory %d-%d MB; want %q, %d-%d MB", tt.jvmArgs, cfg.JVMArgs, cfg.MinMemoryMB, cfg.MaxMemoryMB, tt.wantArgs, tt.minMB, tt.maxMB)
 		}
 	}
 }
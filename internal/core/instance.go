@@ -8,7 +8,10 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/aayushdutt/mctui/internal/memory"
 )
 
 // Instance represents a Minecraft instance
@@ -22,6 +25,7 @@ type Instance struct {
 	JavaPath   string    `json:"javaPath"`  // Path to Java executable (optional)
 	JVMArgs    []string  `json:"jvmArgs"`   // Additional JVM arguments
 	LastPlayed time.Time `json:"lastPlayed"`
+
 	// CreatedAt is set when the instance is first saved (wizard / Create). Used for list order when LastPlayed is zero.
 	CreatedAt time.Time `json:"createdAt,omitempty"`
 	PlayTime  int64     `json:"playTime"` // Total playtime in seconds
@@ -35,6 +39,82 @@ type Instance struct {
 	// InstallStarterFabricMods is true when the user opted into the default Fabric bundle at instance creation.
 	// Cleared after that bundle is installed successfully at launch (see mods package).
 	InstallStarterFabricMods bool `json:"installStarterFabricMods,omitempty"`
+
+	// QuickPlay is the world, server, or Realm the home screen's join key launches into.
+	QuickPlay *QuickPlay `json:"quickPlay,omitempty"`
+
+	// Java heap size override in MB; 0 inherits the global setting.
+	MinMemoryMB int `json:"minMemoryMB,omitempty"`
+	MaxMemoryMB int `json:"maxMemoryMB,omitempty"`
+	// JVMPreset names a set of JVM tuning flags (see launch.JVMPresets) added before JVMArgs.
+	JVMPreset string `json:"jvmPreset,omitempty"`
+	// JVMArgsOverride makes JVMArgs replace the global JVM arguments even when
+	// empty, as they did for an instance whose only arguments were heap sizes
+	// before those moved to MinMemoryMB/MaxMemoryMB.
+	JVMArgsOverride bool `json:"jvmArgsOverride,omitempty"`
+
+	// Window size override; zero width/height falls back to the global default.
+	WindowWidth  int   `json:"windowWidth,omitempty"`
+	WindowHeight int   `json:"windowHeight,omitempty"`
+	Fullscreen   *bool `json:"fullscreen,omitempty"` // nil inherits the global default
+
+	// Detach keeps the game running after mctui exits; nil inherits config.DetachGames.
+	Detach *bool `json:"detach,omitempty"`
+
+	// Hook commands run around the game process; see the launch package for the environment they get.
+	PreLaunchCommand string `json:"preLaunchCommand,omitempty"` // runs before the game; failure aborts the launch
+	WrapperCommand   string `json:"wrapperCommand,omitempty"`   // prefixes the java command line, e.g. "gamemoderun"
+	PostExitCommand  string `json:"postExitCommand,omitempty"`  // runs after the game exits
+
+	// Environment overrides, applied after the global ones in config.
+	Env      map[string]string `json:"env,omitempty"`
+	UnsetEnv []string          `json:"unsetEnv,omitempty"` // inherited or global variables to remove
+}
+
+// QuickPlayKind selects what a Quick Play launch joins.
+type QuickPlayKind string
+
+const (
+	QuickPlaySingleplayer QuickPlayKind = "singleplayer" // Target is a world folder name under saves/
+	QuickPlayMultiplayer  QuickPlayKind = "multiplayer"  // Target is host or host:port
+	QuickPlayRealms       QuickPlayKind = "realms"       // Target is a Realm ID
+)
+
+// QuickPlayKinds lists the kinds in picker order.
+var QuickPlayKinds = []QuickPlayKind{QuickPlaySingleplayer, QuickPlayMultiplayer, QuickPlayRealms}
+
+// QuickPlay is a launch target the game joins directly after starting.
+type QuickPlay struct {
+	Kind   QuickPlayKind `json:"kind"`
+	Target string        `json:"target"`
+}
+
+// Valid reports whether q names a known kind and a non-empty target.
+func (q *QuickPlay) Valid() bool {
+	if q == nil || strings.TrimSpace(q.Target) == "" {
+		return false
+	}
+	switch q.Kind {
+	case QuickPlaySingleplayer, QuickPlayMultiplayer, QuickPlayRealms:
+		return true
+	}
+	return false
+}
+
+// Label is a short human-readable description, e.g. "server play.example.net".
+func (q *QuickPlay) Label() string {
+	if q == nil {
+		return ""
+	}
+	switch q.Kind {
+	case QuickPlaySingleplayer:
+		return "world " + q.Target
+	case QuickPlayMultiplayer:
+		return "server " + q.Target
+	case QuickPlayRealms:
+		return "realm " + q.Target
+	}
+	return q.Target
 }
 
 // SanitizeInstanceDirName turns a display name into a filesystem-safe folder base name.
@@ -95,9 +175,13 @@ func RecencyForSort(inst *Instance) time.Time {
 	}
 }
 
-// InstanceManager handles instance CRUD operations
+// InstanceManager handles instance CRUD operations. Its methods are safe to call
+// from any goroutine, but the *Instance values it hands out belong to the UI's
+// event loop: change them only there.
 type InstanceManager struct {
-	basePath  string
+	basePath string
+
+	mu        sync.Mutex // guards instances and the instance.json writes
 	instances map[string]*Instance
 }
 
@@ -111,6 +195,8 @@ func NewInstanceManager(basePath string) *InstanceManager {
 
 // Load reads all instances from disk
 func (im *InstanceManager) Load() error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
 	instancesPath := filepath.Join(im.basePath, "instances")
 
 	entries, err := os.ReadDir(instancesPath)
@@ -137,6 +223,7 @@ func (im *InstanceManager) Load() error {
 		if err := json.Unmarshal(data, &inst); err != nil {
 			continue // Skip malformed configs
 		}
+		inst.migrateMemoryArgs()
 
 		im.instances[inst.ID] = &inst
 	}
@@ -144,8 +231,30 @@ func (im *InstanceManager) Load() error {
 	return nil
 }
 
+// migrateMemoryArgs moves -Xms/-Xmx out of JVMArgs into the memory overrides.
+// Instance arguments replace the global ones, so when nothing else is left the
+// instance keeps overriding them with nothing rather than inheriting them.
+func (inst *Instance) migrateMemoryArgs() {
+	rest, minMB, maxMB := memory.SplitArgs(inst.JVMArgs)
+	if minMB == 0 && maxMB == 0 {
+		return
+	}
+	inst.JVMArgs = rest
+	if len(rest) == 0 {
+		inst.JVMArgsOverride = true
+	}
+	if inst.MinMemoryMB == 0 {
+		inst.MinMemoryMB = minMB
+	}
+	if inst.MaxMemoryMB == 0 {
+		inst.MaxMemoryMB = maxMB
+	}
+}
+
 // List returns all instances
 func (im *InstanceManager) List() []*Instance {
+	im.mu.Lock()
+	defer im.mu.Unlock()
 	result := make([]*Instance, 0, len(im.instances))
 	for _, inst := range im.instances {
 		result = append(result, inst)
@@ -155,12 +264,14 @@ func (im *InstanceManager) List() []*Instance {
 
 // Get returns an instance by ID
 func (im *InstanceManager) Get(id string) (*Instance, bool) {
+	im.mu.Lock()
+	defer im.mu.Unlock()
 	inst, ok := im.instances[id]
 	return inst, ok
 }
 
 // idTaken reports whether an instance ID (folder basename) is already in use,
-// either in memory or on disk.
+// either in memory or on disk; im.mu must be held.
 func (im *InstanceManager) idTaken(id string) bool {
 	if _, ok := im.instances[id]; ok {
 		return true
@@ -188,6 +299,8 @@ func (im *InstanceManager) generateInstanceID(name string) string {
 // (sanitized + de-duplicated); the display Name is kept verbatim and stays editable
 // independently of the folder/ID.
 func (im *InstanceManager) Create(inst *Instance) error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
 	if inst.ID == "" {
 		inst.ID = im.generateInstanceID(inst.Name)
 	}
@@ -214,6 +327,8 @@ func (im *InstanceManager) Create(inst *Instance) error {
 
 // Delete removes an instance
 func (im *InstanceManager) Delete(id string) error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
 	inst, ok := im.instances[id]
 	if !ok {
 		return nil
@@ -228,7 +343,7 @@ func (im *InstanceManager) Delete(id string) error {
 	return nil
 }
 
-// save writes instance config to disk
+// save writes instance config to disk; im.mu must be held.
 func (im *InstanceManager) save(inst *Instance) error {
 	data, err := json.MarshalIndent(inst, "", "  ")
 	if err != nil {
@@ -241,12 +356,16 @@ func (im *InstanceManager) save(inst *Instance) error {
 
 // Update updates an existing instance
 func (im *InstanceManager) Update(inst *Instance) error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
 	im.instances[inst.ID] = inst
 	return im.save(inst)
 }
 
 // UpdateLastPlayed updates the last played timestamp
 func (im *InstanceManager) UpdateLastPlayed(id string) error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
 	inst, ok := im.instances[id]
 	if !ok {
 		return nil
@@ -254,3 +373,18 @@ func (im *InstanceManager) UpdateLastPlayed(id string) error {
 	inst.LastPlayed = time.Now()
 	return im.save(inst)
 }
+
+// RecordSession appends s to the instance's session log and adds its duration to PlayTime.
+func (im *InstanceManager) RecordSession(id string, s Session) error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+	inst, ok := im.instances[id]
+	if !ok {
+		return nil
+	}
+	if err := AppendSession(inst.Path, s); err != nil {
+		return err
+	}
+	inst.PlayTime += s.Seconds
+	return im.save(inst)
+}
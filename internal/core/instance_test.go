@@ -286,3 +286,48 @@ func TestInstanceManager_EmptyDir(t *testing.T) {
 		t.Error("Expected empty list from new directory")
 	}
 }
+
+func TestInstanceManager_LoadMigratesMemoryArgs(t *testing.T) {
+	tmpDir := t.TempDir()
+	dir := filepath.Join(tmpDir, "instances", "modded")
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "instance.json"), []byte(`{"id":"modded","jvmArgs":["-Xms1G","-Xmx6G","-XX:+UseZGC"]}`), 0644)
+
+	mgr := NewInstanceManager(tmpDir)
+	if err := mgr.Load(); err != nil {
+		t.Fatal(err)
+	}
+	inst, _ := mgr.Get("modded")
+	if inst.MinMemoryMB != 1024 || inst.MaxMemoryMB != 6144 {
+		t.Errorf("memory = %d-%d MB, want 1024-6144", inst.MinMemoryMB, inst.MaxMemoryMB)
+	}
+	if len(inst.JVMArgs) != 1 || inst.JVMArgs[0] != "-XX:+UseZGC" {
+		t.Errorf("JVMArgs = %q, want only the non-memory flag left", inst.JVMArgs)
+	}
+}
+
+func TestInstanceManager_LoadMigratesMemoryOnlyArgsAsOverride(t *testing.T) {
+	tmpDir := t.TempDir()
+	for id, args := range map[string]string{
+		"memory-only": `["-Xms1G","-Xmx4G"]`,
+		"inherits":    `[]`,
+	} {
+		dir := filepath.Join(tmpDir, "instances", id)
+		os.MkdirAll(dir, 0755)
+		os.WriteFile(filepath.Join(dir, "instance.json"), []byte(`{"id":"`+id+`","jvmArgs":`+args+`}`), 0644)
+	}
+
+	mgr := NewInstanceManager(tmpDir)
+	if err := mgr.Load(); err != nil {
+		t.Fatal(err)
+	}
+	// The instance's args used to replace the global ones; with only heap sizes
+	// moved out, it must still not inherit them.
+	inst, _ := mgr.Get("memory-only")
+	if len(inst.JVMArgs) != 0 || !inst.JVMArgsOverride || inst.MaxMemoryMB != 4096 {
+		t.Errorf("memory-only: JVMArgs=%q override=%v max=%d, want empty override and 4096 MB", inst.JVMArgs, inst.JVMArgsOverride, inst.MaxMemoryMB)
+	}
+	if inst, _ := mgr.Get("inherits"); inst.JVMArgsOverride {
+		t.Error("an instance without args of its own should keep inheriting the global ones")
+	}
+}
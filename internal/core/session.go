@@ -0,0 +1,110 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// SessionsFile is the per-instance play history, one JSON [Session] per line.
+const SessionsFile = "sessions.jsonl"
+
+// Session is one play session of an instance, from game start to process exit.
+type Session struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Seconds  int64     `json:"seconds"`  // wall-clock duration
+	ExitCode int       `json:"exitCode"` // -1 when killed by a signal
+	Account  string    `json:"account,omitempty"`
+	Offline  bool      `json:"offline,omitempty"`
+
+	// ExitUnknown is set when the game's exit status couldn't be observed, as
+	// for a detached game mctui reattached to; ExitCode is meaningless then.
+	ExitUnknown bool `json:"exitUnknown,omitempty"`
+}
+
+// NewSession builds a Session for a game that ran from start to end.
+func NewSession(start, end time.Time, exitCode int, account string, offline bool) Session {
+	return Session{
+		Start:    start,
+		End:      end,
+		Seconds:  int64(end.Sub(start).Seconds()),
+		ExitCode: exitCode,
+		Account:  account,
+		Offline:  offline,
+	}
+}
+
+// NewUnobservedSession builds a Session for a game whose exit status is unknown.
+func NewUnobservedSession(start, end time.Time, account string, offline bool) Session {
+	s := NewSession(start, end, 0, account, offline)
+	s.ExitUnknown = true
+	return s
+}
+
+// ExitStatus describes how the session ended: "exit 0", "killed", or "exit unknown".
+func (s Session) ExitStatus() string {
+	switch {
+	case s.ExitUnknown:
+		return "exit unknown"
+	case s.ExitCode < 0:
+		return "killed"
+	default:
+		return "exit " + strconv.Itoa(s.ExitCode)
+	}
+}
+
+// AppendSession appends s to the session log in instPath.
+func AppendSession(instPath string, s Session) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(instPath, SessionsFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadSessions reads the session log in instPath, oldest first. A missing log is
+// not an error; malformed lines (e.g. a write cut short by a crash) are skipped.
+func LoadSessions(instPath string) ([]Session, error) {
+	f, err := os.Open(filepath.Join(instPath, SessionsFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []Session
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var s Session
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out, scanner.Err()
+}
+
+// PlayTimeSince sums the playtime of sessions that ended after since.
+func PlayTimeSince(sessions []Session, since time.Time) time.Duration {
+	var total int64
+	for _, s := range sessions {
+		if s.End.After(since) {
+			total += s.Seconds
+		}
+	}
+	return time.Duration(total) * time.Second
+}
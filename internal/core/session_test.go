@@ -0,0 +1,92 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecordSession_AppendsLogAndAddsPlayTime(t *testing.T) {
+	im := NewInstanceManager(t.TempDir())
+	inst := &Instance{Name: "Survival", PlayTime: 60}
+	if err := im.Create(inst); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	first := NewSession(start, start.Add(90*time.Minute), 0, "Steve", false)
+	second := NewSession(start.Add(24*time.Hour), start.Add(24*time.Hour+10*time.Minute), 1, "Steve", true)
+	for _, s := range []Session{first, second} {
+		if err := im.RecordSession(inst.ID, s); err != nil {
+			t.Fatalf("RecordSession: %v", err)
+		}
+	}
+
+	if want := int64(60 + 90*60 + 10*60); inst.PlayTime != want {
+		t.Fatalf("PlayTime = %d, want %d", inst.PlayTime, want)
+	}
+	sessions, err := LoadSessions(inst.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 || sessions[0].Seconds != 90*60 || sessions[1].ExitCode != 1 || !sessions[1].Offline {
+		t.Fatalf("sessions = %+v", sessions)
+	}
+
+	// PlayTime persists to instance.json.
+	reloaded := NewInstanceManager(filepath.Dir(filepath.Dir(inst.Path)))
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := reloaded.Get(inst.ID); got == nil || got.PlayTime != inst.PlayTime {
+		t.Fatalf("reloaded PlayTime = %+v, want %d", got, inst.PlayTime)
+	}
+}
+
+func TestLoadSessions_MissingAndMalformed(t *testing.T) {
+	dir := t.TempDir()
+	if sessions, err := LoadSessions(dir); err != nil || sessions != nil {
+		t.Fatalf("missing log = %v, %v; want nil, nil", sessions, err)
+	}
+
+	data := `{"start":"2025-03-01T12:00:00Z","end":"2025-03-01T12:30:00Z","seconds":1800,"exitCode":0}
+{"start":"2025-03-02T12:00:00Z","end":`
+	if err := os.WriteFile(filepath.Join(dir, SessionsFile), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sessions, err := LoadSessions(dir)
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("truncated log = %+v, %v; want the one complete session", sessions, err)
+	}
+}
+
+func TestPlayTimeSince(t *testing.T) {
+	now := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	sessions := []Session{
+		{End: now.Add(-10 * 24 * time.Hour), Seconds: 3600},
+		{End: now.Add(-2 * 24 * time.Hour), Seconds: 1200},
+		{End: now.Add(-time.Hour), Seconds: 600},
+	}
+	if got, want := PlayTimeSince(sessions, now.Add(-7*24*time.Hour)), 30*time.Minute; got != want {
+		t.Fatalf("PlayTimeSince = %v, want %v", got, want)
+	}
+}
+
+func TestSession_ExitStatus(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		s    Session
+		want string
+	}{
+		{NewSession(now, now, 0, "", false), "exit 0"},
+		{NewSession(now, now, 137, "", false), "exit 137"},
+		{NewSession(now, now, -1, "", false), "killed"},
+		{NewUnobservedSession(now, now, "", false), "exit unknown"},
+	}
+	for _, tt := range tests {
+		if got := tt.s.ExitStatus(); got != tt.want {
+			t.Errorf("ExitStatus() = %q, want %q", got, tt.want)
+		}
+	}
+}
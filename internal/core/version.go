@@ -81,6 +81,12 @@ type Library struct {
 	Downloads *LibraryDownloads `json:"downloads,omitempty"`
 	Rules     []Rule            `json:"rules,omitempty"`
 	Natives   map[string]string `json:"natives,omitempty"`
+	Extract   *ExtractRules     `json:"extract,omitempty"`
+}
+
+// ExtractRules controls how a natives classifier jar is unpacked
+type ExtractRules struct {
+	Exclude []string `json:"exclude,omitempty"` // path prefixes to skip, e.g. "META-INF/"
 }
 
 // LibraryDownloads contains artifact download info
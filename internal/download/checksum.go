@@ -0,0 +1,56 @@
+package download
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"io"
+	"os"
+	"strings"
+)
+
+// checksum is the hash an item is verified against.
+type checksum struct {
+	algo string // "sha1", "sha256" or "sha512"
+	want string // expected hex digest; empty when the item has none
+	new  func() hash.Hash
+}
+
+// checksum picks the strongest hash item carries. Items with none are still
+// hashed with SHA-1, which is cheap, so every download takes one path.
+func (item Item) checksum() checksum {
+	switch {
+	case item.SHA512 != "":
+		return checksum{algo: "sha512", want: item.SHA512, new: sha512.New}
+	case item.SHA256 != "":
+		return checksum{algo: "sha256", want: item.SHA256, new: sha256.New}
+	default:
+		return checksum{algo: "sha1", want: item.SHA1, new: sha1.New}
+	}
+}
+
+// verifies reports whether the item has an expected hash to check against.
+func (c checksum) verifies() bool { return c.want != "" }
+
+// matches reports whether got, a hex digest, is the expected one.
+func (c checksum) matches(got string) bool {
+	return c.verifies() && strings.EqualFold(got, c.want)
+}
+
+// hashFile computes the hex digest of a file with newHash.
+func hashFile(path string, newHash func() hash.Hash) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := newHash()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package download
+
+import (
+	"errors"
+	"syscall"
+)
+
+// isDiskFull reports whether err came from running out of disk space.
+func isDiskFull(err error) bool {
+	return errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EDQUOT)
+}
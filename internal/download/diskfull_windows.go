@@ -0,0 +1,17 @@
+package download
+
+import (
+	"errors"
+	"syscall"
+)
+
+// Win32 error codes for a full disk, not exported by syscall.
+const (
+	errorHandleDiskFull syscall.Errno = 39
+	errorDiskFull       syscall.Errno = 112
+)
+
+// isDiskFull reports whether err came from running out of disk space.
+func isDiskFull(err error) bool {
+	return errors.Is(err, errorDiskFull) || errors.Is(err, errorHandleDiskFull)
+}
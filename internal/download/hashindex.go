@@ -0,0 +1,134 @@
+package download
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// HashIndexFile is the hash index's file name in the data dir.
+const HashIndexFile = "hashes.json"
+
+// hashIndexVersion is bumped when the format changes; other versions are
+// ignored, which costs one full verification.
+const hashIndexVersion = 1
+
+// HashIndex remembers the hashes of files already verified, with the size and
+// modification time each had then, so an unchanged file is trusted without
+// reading it again. A nil *HashIndex trusts nothing and records nothing.
+type HashIndex struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]indexEntry
+	dirty   bool
+}
+
+type indexEntry struct {
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mtime"` // unix nanoseconds
+	Algo    string `json:"algo"`
+	Hash    string `json:"hash"`
+}
+
+type indexFile struct {
+	Version int                   `json:"version"`
+	Files   map[string]indexEntry `json:"files"`
+}
+
+// The process-wide hash index, consulted by every manager.
+var (
+	indexMu     sync.RWMutex
+	sharedIndex *HashIndex
+)
+
+// UseHashIndex loads the index at path and has every manager consult it.
+func UseHashIndex(path string) {
+	idx := LoadHashIndex(path)
+	indexMu.Lock()
+	defer indexMu.Unlock()
+	sharedIndex = idx
+}
+
+func currentHashIndex() *HashIndex {
+	indexMu.RLock()
+	defer indexMu.RUnlock()
+	return sharedIndex
+}
+
+// LoadHashIndex reads the index at path. A missing, unreadable or corrupt
+// index loads empty, so every file is verified in full again.
+func LoadHashIndex(path string) *HashIndex {
+	x := &HashIndex{path: path, entries: map[string]indexEntry{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return x
+	}
+	var f indexFile
+	if err := json.Unmarshal(data, &f); err != nil || f.Version != hashIndexVersion {
+		return x
+	}
+	if f.Files != nil {
+		x.entries = f.Files
+	}
+	return x
+}
+
+// trusted reports whether path, as described by info, was verified to have
+// sum's hash and hasn't changed since.
+func (x *HashIndex) trusted(path string, info os.FileInfo, sum checksum) bool {
+	if x == nil || !sum.verifies() {
+		return false
+	}
+	x.mu.Lock()
+	e, ok := x.entries[filepath.Clean(path)]
+	x.mu.Unlock()
+	return ok && e.Size == info.Size() && e.ModTime == info.ModTime().UnixNano() &&
+		e.Algo == sum.algo && strings.EqualFold(e.Hash, sum.want)
+}
+
+// remember records that path, as described by info, hashes to hash.
+func (x *HashIndex) remember(path string, info os.FileInfo, algo, hash string) {
+	if x == nil {
+		return
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.entries[filepath.Clean(path)] = indexEntry{
+		Size:    info.Size(),
+		ModTime: info.ModTime().UnixNano(),
+		Algo:    algo,
+		Hash:    hash,
+	}
+	x.dirty = true
+}
+
+// Save writes the index if it changed since it was loaded or last saved.
+func (x *HashIndex) Save() error {
+	if x == nil || x.path == "" {
+		return nil
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if !x.dirty {
+		return nil
+	}
+	data, err := json.Marshal(indexFile{Version: hashIndexVersion, Files: x.entries})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(x.path), 0755); err != nil {
+		return err
+	}
+	tmp := x.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, x.path); err != nil {
+		return err
+	}
+	x.dirty = false
+	return nil
+}
@@ -0,0 +1,163 @@
+package download
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// indexedFile writes content to a fresh file and returns an item expecting it.
+func indexedFile(t *testing.T, url string, content []byte) Item {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "obj")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha1.Sum(content)
+	return Item{URL: url, Path: path, SHA1: hex.EncodeToString(sum[:]), Size: int64(len(content))}
+}
+
+func countingServer(t *testing.T, content []byte) (*httptest.Server, *atomic.Int32) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write(content)
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+func TestHashIndex_TrustsUnchangedFiles(t *testing.T) {
+	content := []byte("asset object")
+	server, hits := countingServer(t, content)
+	item := indexedFile(t, server.URL, content)
+	indexPath := filepath.Join(t.TempDir(), HashIndexFile)
+
+	// First run hashes the file and records it.
+	m := NewManager(1)
+	m.index = LoadHashIndex(indexPath)
+	if _, err := m.Download(context.Background(), []Item{item}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(indexPath); err != nil {
+		t.Fatalf("index not saved: %v", err)
+	}
+
+	// Swap the content for same-size garbage, keeping the mtime: a trusted
+	// file isn't read again, so the swap goes unnoticed.
+	info, _ := os.Stat(item.Path)
+	os.WriteFile(item.Path, []byte("xxxxxxxxxxxx"), 0644)
+	os.Chtimes(item.Path, info.ModTime(), info.ModTime())
+
+	m = NewManager(1)
+	m.index = LoadHashIndex(indexPath)
+	if _, err := m.Download(context.Background(), []Item{item}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if hits.Load() != 0 {
+		t.Fatalf("server hit %d times; unchanged file should be trusted", hits.Load())
+	}
+
+	// Touching the file invalidates the entry, so it is hashed, found wrong,
+	// and downloaded again.
+	later := info.ModTime().Add(time.Minute)
+	os.Chtimes(item.Path, later, later)
+	result, err := m.Download(context.Background(), []Item{item}, nil)
+	if err != nil || result.Failed != 0 {
+		t.Fatalf("Download: %v %v", err, result.Err())
+	}
+	if hits.Load() != 1 {
+		t.Fatalf("server hit %d times, want 1 after the file changed", hits.Load())
+	}
+	if got, _ := os.ReadFile(item.Path); string(got) != string(content) {
+		t.Errorf("content = %q, want %q", got, content)
+	}
+}
+
+func TestManager_RehashIgnoresIndex(t *testing.T) {
+	content := []byte("asset object")
+	server, hits := countingServer(t, content)
+	item := indexedFile(t, server.URL, content)
+	indexPath := filepath.Join(t.TempDir(), HashIndexFile)
+
+	m := NewManager(1)
+	m.index = LoadHashIndex(indexPath)
+	if _, err := m.Download(context.Background(), []Item{item}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Corrupt the file behind the index's back.
+	info, _ := os.Stat(item.Path)
+	os.WriteFile(item.Path, []byte("xxxxxxxxxxxx"), 0644)
+	os.Chtimes(item.Path, info.ModTime(), info.ModTime())
+
+	m = NewManager(1)
+	m.index = LoadHashIndex(indexPath)
+	m.Rehash()
+	result, err := m.Download(context.Background(), []Item{item}, nil)
+	if err != nil || result.Failed != 0 {
+		t.Fatalf("Download: %v %v", err, result.Err())
+	}
+	if hits.Load() != 1 {
+		t.Fatalf("server hit %d times, want 1: a rehash should catch the swap", hits.Load())
+	}
+	if got, _ := os.ReadFile(item.Path); string(got) != string(content) {
+		t.Errorf("content = %q, want %q", got, content)
+	}
+}
+
+func TestHashIndex_RecordsDownloads(t *testing.T) {
+	content := []byte("library jar")
+	server, _ := countingServer(t, content)
+	sum := sha1.Sum(content)
+	item := Item{URL: server.URL, Path: filepath.Join(t.TempDir(), "lib.jar"), SHA1: hex.EncodeToString(sum[:])}
+	indexPath := filepath.Join(t.TempDir(), HashIndexFile)
+
+	m := NewManager(1)
+	m.index = LoadHashIndex(indexPath)
+	if _, err := m.Download(context.Background(), []Item{item}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(item.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !LoadHashIndex(indexPath).trusted(item.Path, info, item.checksum()) {
+		t.Error("a verified download should be in the saved index")
+	}
+}
+
+func TestHashIndex_CorruptFallsBackToHashing(t *testing.T) {
+	content := []byte("asset object")
+	server, hits := countingServer(t, content)
+	item := indexedFile(t, server.URL, content)
+	indexPath := filepath.Join(t.TempDir(), HashIndexFile)
+	os.WriteFile(indexPath, []byte("{not json"), 0644)
+
+	idx := LoadHashIndex(indexPath)
+	info, _ := os.Stat(item.Path)
+	if idx.trusted(item.Path, info, item.checksum()) {
+		t.Fatal("a corrupt index should trust nothing")
+	}
+
+	m := NewManager(1)
+	m.index = idx
+	result, err := m.Download(context.Background(), []Item{item}, nil)
+	if err != nil || result.Completed != 1 {
+		t.Fatalf("Download: %v %v", err, result.Err())
+	}
+	if hits.Load() != 0 {
+		t.Error("a valid file should be verified by hashing, not downloaded again")
+	}
+	if !LoadHashIndex(indexPath).trusted(item.Path, info, item.checksum()) {
+		t.Error("the rebuilt index should have been saved over the corrupt one")
+	}
+}
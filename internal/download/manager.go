@@ -2,29 +2,33 @@
 package download
 
 import (
+	"cmp"
 	"context"
-	"crypto/sha1"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/dustin/go-humanize"
-	"github.com/hashicorp/go-retryablehttp"
 )
 
 // Item represents a single download item
 type Item struct {
-	URL      string
-	Path     string // Local destination path
-	SHA1     string // Expected SHA1 hash (optional)
-	Size     int64  // Expected size in bytes
-	Priority int    // Higher = download first
+	URL       string
+	Fallbacks []string // Tried in order when URL fails; mirrors apply to each
+	Path      string   // Local destination path
+	SHA1      string   // Expected SHA-1 hash (optional)
+	SHA256    string   // Expected SHA-256 hash (optional)
+	SHA512    string   // Expected SHA-512 hash (optional); the strongest given is verified
+	Size      int64    // Expected size in bytes
+	Priority  int      // Higher = download first
 }
 
 // Progress tracks download progress
@@ -32,57 +36,59 @@ type Progress struct {
 	TotalBytes      int64
 	DownloadedBytes int64
 	TotalItems      int
-	CompletedItems  int
-	CurrentItem     string
-	Speed           float64 // bytes per second
+	CompletedItems  int            // Verified, including files that were already in place
+	FailedItems     int            // Items that gave up
+	QueuedItems     int            // Items not yet started
+	Active          []ItemProgress // Transfers in flight, in queue order
+	Failed          []ItemProgress // Failed items with their reasons, in queue order
+	Speed           float64        // bytes per second
+	ETA             time.Duration  // Estimated time left; 0 when unknown
 }
 
-// Manager handles parallel downloads
+// Manager handles parallel downloads. Managers share the process-wide
+// [Scheduler], so concurrent batches together respect its limits.
 type Manager struct {
 	httpClient  *http.Client
+	sched       *Scheduler
+	index       *HashIndex
 	workerCount int
+	rehash      bool // hash files already in place even when the index trusts them
 
 	// Progress tracking
-	mu              sync.RWMutex
-	progress        Progress
-	downloadedBytes int64
+	downloadedBytes atomic.Int64
 }
 
-// NewManager creates a new download manager
+// NewManager creates a download manager running up to workerCount transfers of
+// a batch at once, within the shared scheduler's per-host limit.
 func NewManager(workerCount int) *Manager {
 	if workerCount <= 0 {
 		workerCount = 4
 	}
-
-	// Create retryable client with sensible defaults
-	retryClient := retryablehttp.NewClient()
-	retryClient.RetryMax = 3
-	retryClient.RetryWaitMin = 1 * time.Second
-	retryClient.RetryWaitMax = 10 * time.Second
-	retryClient.Logger = nil // Silence default logging
-
-	// Configure underlying transport
-	retryClient.HTTPClient.Transport = &http.Transport{
-		MaxIdleConns:        100,
-		MaxIdleConnsPerHost: 10,
-		IdleConnTimeout:     90 * time.Second,
-	}
-	retryClient.HTTPClient.Timeout = 5 * time.Minute
-
+	sched := Shared()
 	return &Manager{
-		httpClient:  retryClient.StandardClient(),
+		httpClient:  sched.client,
+		sched:       sched,
+		index:       currentHashIndex(),
 		workerCount: workerCount,
 	}
 }
 
+// Rehash makes the manager hash every file already in place instead of
+// trusting the hash index, for when files may have changed behind its back.
+// What it finds still refreshes the index.
+func (m *Manager) Rehash() {
+	m.rehash = true
+}
+
 // Result contains the outcome of a download batch
 type Result struct {
 	Completed int
 	Failed    int
-	Errors    []error
+	Outcomes  []Outcome // One per item, in the order given
 }
 
-// Download downloads all items and returns progress on the channel
+// Download downloads all items, highest Priority first (ties keep their order
+// in items), and returns progress on the channel.
 func (m *Manager) Download(ctx context.Context, items []Item, progressChan chan<- Progress) (*Result, error) {
 	if len(items) == 0 {
 		return &Result{}, nil
@@ -90,33 +96,24 @@ func (m *Manager) Download(ctx context.Context, items []Item, progressChan chan<
 
 	// Calculate total size
 	var totalSize int64
-	for _, item := range items {
+	transfers := make([]*transfer, len(items))
+	for i, item := range items {
 		totalSize += item.Size
+		transfers[i] = &transfer{item: item}
 	}
-
-	m.mu.Lock()
-	m.progress = Progress{
-		TotalBytes: totalSize,
-		TotalItems: len(items),
-	}
-	m.downloadedBytes = 0
-	m.mu.Unlock()
-
-	// Create work channel
-	workChan := make(chan Item, len(items))
-	for _, item := range items {
-		workChan <- item
+	m.downloadedBytes.Store(0)
+
+	// Create work channel, highest priority first
+	queue := slices.Clone(transfers)
+	slices.SortStableFunc(queue, func(a, b *transfer) int {
+		return cmp.Compare(b.item.Priority, a.item.Priority)
+	})
+	workChan := make(chan *transfer, len(queue))
+	for _, t := range queue {
+		workChan <- t
 	}
 	close(workChan)
 
-	// Results collection
-	var (
-		completed int64
-		failed    int64
-		errMu     sync.Mutex
-		errors    []error
-	)
-
 	// Signal for shutting down progress reporter
 	doneSignal := make(chan struct{})
 
@@ -128,34 +125,16 @@ func (m *Manager) Download(ctx context.Context, items []Item, progressChan chan<
 			ticker := time.NewTicker(100 * time.Millisecond)
 			defer ticker.Stop()
 
-			var lastBytes int64
-			lastTime := time.Now()
-
+			r := newReporter(transfers, totalSize, &m.downloadedBytes, time.Now())
 			for {
 				select {
 				case <-ctx.Done():
 					return
 				case <-doneSignal:
 					return
-				case <-ticker.C:
-					m.mu.RLock()
-					p := m.progress
-					currentBytes := atomic.LoadInt64(&m.downloadedBytes)
-					m.mu.RUnlock()
-
-					// Calculate speed
-					now := time.Now()
-					elapsed := now.Sub(lastTime).Seconds()
-					if elapsed > 0 {
-						p.Speed = float64(currentBytes-lastBytes) / elapsed
-						lastBytes = currentBytes
-						lastTime = now
-					}
-					p.DownloadedBytes = currentBytes
-					p.CompletedItems = int(atomic.LoadInt64(&completed))
-
+				case now := <-ticker.C:
 					select {
-					case progressChan <- p:
+					case progressChan <- r.snapshot(now):
 					default:
 					}
 				}
@@ -171,24 +150,18 @@ func (m *Manager) Download(ctx context.Context, items []Item, progressChan chan<
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			for item := range workChan {
+			for t := range workChan {
 				select {
 				case <-ctx.Done():
 					return
 				default:
 				}
 
-				m.mu.Lock()
-				m.progress.CurrentItem = filepath.Base(item.Path)
-				m.mu.Unlock()
-
-				if err := m.downloadItem(ctx, item); err != nil {
-					atomic.AddInt64(&failed, 1)
-					errMu.Lock()
-					errors = append(errors, fmt.Errorf("%s: %w", item.URL, err))
-					errMu.Unlock()
+				t.setState(ItemActive)
+				if err := m.downloadItem(ctx, t); err != nil {
+					t.fail(err)
 				} else {
-					atomic.AddInt64(&completed, 1)
+					t.setState(ItemVerified)
 				}
 			}
 		}()
@@ -197,22 +170,42 @@ func (m *Manager) Download(ctx context.Context, items []Item, progressChan chan<
 	wg.Wait()
 	close(doneSignal)
 	<-progressDone
+	// Best effort: a lost index only costs rehashing next time.
+	_ = m.index.Save()
+
+	result := &Result{Outcomes: make([]Outcome, len(transfers))}
+	for i, t := range transfers {
+		o := Outcome{Item: t.item, Kind: t.kind, Attempts: t.attempts, Err: t.err}
+		if ItemState(t.state.Load()) == ItemQueued {
+			// Never started: the batch was cancelled first.
+			o.Kind, o.Err = FailCancelled, ctx.Err()
+		}
+		if o.Failed() {
+			result.Failed++
+		} else {
+			result.Completed++
+		}
+		result.Outcomes[i] = o
+	}
+	return result, nil
+}
 
-	return &Result{
-		Completed: int(completed),
-		Failed:    int(failed),
-		Errors:    errors,
-	}, nil
+// addBytes records n more bytes of t, or takes them back when negative.
+func (m *Manager) addBytes(t *transfer, n int64) {
+	t.bytes.Add(n)
+	m.downloadedBytes.Add(n)
 }
 
-// downloadItem downloads a single item
-func (m *Manager) downloadItem(ctx context.Context, item Item) error {
+// downloadItem downloads a single item from the first of its sources (see
+// [MirrorURLs]) that works. A partial download left by an earlier attempt or
+// run is resumed when the server supports ranges; its hash is always checked
+// over the whole file. On failure t.kind says why, judged by the last source.
+func (m *Manager) downloadItem(ctx context.Context, t *transfer) error {
+	item := t.item
 	// Check if file already exists with correct hash
-	if item.SHA1 != "" {
-		if hash, err := hashFile(item.Path); err == nil && hash == item.SHA1 {
-			atomic.AddInt64(&m.downloadedBytes, item.Size)
-			return nil // Already downloaded
-		}
+	if m.alreadyVerified(item) {
+		m.addBytes(t, item.Size)
+		return nil // Already downloaded
 	}
 
 	// Ensure directory exists
@@ -220,92 +213,187 @@ func (m *Manager) downloadItem(ctx context.Context, item Item) error {
 		return fmt.Errorf("creating directory: %w", err)
 	}
 
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, item.URL, nil)
+	// Try each source in turn; a mirror serving a bad file falls through to the next.
+	tmpPath := item.Path + ".tmp"
+	var errs []error
+	srcs := sources(item)
+	for _, url := range srcs {
+		err := m.downloadFrom(ctx, url, t, tmpPath)
+		if err == nil {
+			return nil
+		}
+		t.kind = Classify(err)
+		var statusErr *StatusError
+		if len(srcs) > 1 && !errors.As(err, &statusErr) { // a StatusError names its URL already
+			err = fmt.Errorf("%s: %w", url, err)
+		}
+		errs = append(errs, err)
+		if ctx.Err() != nil {
+			t.kind = FailCancelled
+			break
+		}
+	}
+	if len(srcs) == 1 {
+		return errs[0]
+	}
+	return fmt.Errorf("all %d sources failed: %w", len(srcs), errors.Join(errs...))
+}
+
+// alreadyVerified reports whether item's file is already in place with the
+// expected hash. Files unchanged since they were last hashed are trusted from
+// the hash index without being read.
+func (m *Manager) alreadyVerified(item Item) bool {
+	sum := item.checksum()
+	if !sum.verifies() {
+		return false
+	}
+	info, err := os.Stat(item.Path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	if !m.rehash && m.index.trusted(item.Path, info, sum) {
+		return true
+	}
+	hash, err := hashFile(item.Path, sum.new)
+	if err != nil || !sum.matches(hash) {
+		return false
+	}
+	m.index.remember(item.Path, info, sum.algo, hash)
+	return true
+}
+
+// downloadFrom downloads item from one source URL, resuming after dropped
+// connections, then verifies and moves it into place.
+func (m *Manager) downloadFrom(ctx context.Context, url string, t *transfer, tmpPath string) error {
+	item := t.item
+	var (
+		hash string
+		err  error
+	)
+	for attempt := 0; attempt <= maxResumes; attempt++ {
+		t.attempts++
+		hash, err = m.fetch(ctx, url, tmpPath, t)
+		if err == nil || !errors.Is(err, errInterrupted) || ctx.Err() != nil {
+			break
+		}
+	}
 	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
+		return err
 	}
 
-	// Execute request (retries handled by retryablehttp)
-	resp, err := m.httpClient.Do(req)
+	// Verify hash
+	if sum := item.checksum(); sum.verifies() && !sum.matches(hash) {
+		removePartial(tmpPath)
+		return &HashMismatchError{Algorithm: sum.algo, Expected: sum.want, Got: hash}
+	}
+
+	// Move to final location
+	if err := os.Rename(tmpPath, item.Path); err != nil {
+		removePartial(tmpPath)
+		return fmt.Errorf("renaming file: %w", err)
+	}
+	os.Remove(partialMetaPath(tmpPath))
+	if info, err := os.Stat(item.Path); err == nil {
+		m.index.remember(item.Path, info, item.checksum().algo, hash)
+	}
+
+	return nil
+}
+
+// fetch makes one request for url into tmpPath, continuing a partial file with
+// a Range request when possible, and returns the hash of the complete file. If
+// the connection drops mid-body the partial file is kept and the error wraps
+// errInterrupted. Progress is reported against t, counting a resumed partial
+// file's bytes once.
+func (m *Manager) fetch(ctx context.Context, url, tmpPath string, t *transfer) (string, error) {
+	meta, offset := loadPartial(tmpPath, url)
+
+	// Create request
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return fmt.Errorf("downloading: %w", err)
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		req.Header.Set("If-Range", meta.validator())
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	release, err := m.sched.Acquire(ctx, url)
+	if err != nil {
+		return "", err
 	}
+	defer release()
 
-	// Create temp file
-	tmpPath := item.Path + ".tmp"
-	f, err := os.Create(tmpPath)
+	// Execute request (retries handled by retryablehttp)
+	resp, err := m.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("creating file: %w", err)
+		return "", fmt.Errorf("downloading: %w", err)
 	}
+	defer resp.Body.Close()
+	body := m.sched.Throttle(ctx, resp.Body)
+
+	hasher := t.item.checksum().new()
+	var f *os.File
+	switch {
+	case offset > 0 && resumesAt(resp, offset):
+		// Continue the partial file, hashing what is already there.
+		f, err = os.OpenFile(tmpPath, os.O_RDWR, 0644)
+		if err == nil {
+			_, err = io.CopyN(hasher, f, offset)
+		}
+		if err != nil {
+			if f != nil {
+				f.Close()
+			}
+			removePartial(tmpPath)
+			return "", fmt.Errorf("%w: reopening partial file: %v", errInterrupted, err)
+		}
+	case resp.StatusCode == http.StatusOK:
+		// A fresh download, or the remote file changed since the partial one.
+		offset = 0
+		f, err = os.Create(tmpPath)
+		if err != nil {
+			return "", fmt.Errorf("creating file: %w", err)
+		}
+		savePartialMeta(tmpPath, url, resp)
+	case offset > 0 && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// The partial file doesn't fit the remote one; start over.
+		removePartial(tmpPath)
+		return "", fmt.Errorf("%w: range not satisfiable", errInterrupted)
+	default:
+		return "", &StatusError{URL: url, Code: resp.StatusCode}
+	}
+	m.addBytes(t, offset-t.bytes.Load())
 
 	// Download with progress tracking
-	hasher := sha1.New()
 	writer := io.MultiWriter(f, hasher)
 
 	buf := make([]byte, 32*1024)
 	for {
-		n, readErr := resp.Body.Read(buf)
+		n, readErr := body.Read(buf)
 		if n > 0 {
 			if _, writeErr := writer.Write(buf[:n]); writeErr != nil {
 				f.Close()
-				os.Remove(tmpPath)
-				return fmt.Errorf("writing file: %w", writeErr)
+				removePartial(tmpPath)
+				return "", fmt.Errorf("writing file: %w", writeErr)
 			}
-			atomic.AddInt64(&m.downloadedBytes, int64(n))
+			m.addBytes(t, int64(n))
 		}
 		if readErr == io.EOF {
 			break
 		}
 		if readErr != nil {
+			// Keep what arrived so the next attempt resumes from here.
 			f.Close()
-			os.Remove(tmpPath)
-			return fmt.Errorf("reading response: %w", readErr)
+			return "", fmt.Errorf("%w: reading response: %v", errInterrupted, readErr)
 		}
 	}
 
 	if err := f.Close(); err != nil {
-		os.Remove(tmpPath)
-		return fmt.Errorf("closing file: %w", err)
-	}
-
-	// Verify hash
-	if item.SHA1 != "" {
-		hash := hex.EncodeToString(hasher.Sum(nil))
-		if hash != item.SHA1 {
-			os.Remove(tmpPath)
-			return fmt.Errorf("hash mismatch: expected %s, got %s", item.SHA1, hash)
-		}
-	}
-
-	// Move to final location
-	if err := os.Rename(tmpPath, item.Path); err != nil {
-		os.Remove(tmpPath)
-		return fmt.Errorf("renaming file: %w", err)
+		removePartial(tmpPath)
+		return "", fmt.Errorf("closing file: %w", err)
 	}
-
-	return nil
-}
-
-// hashFile computes SHA1 of a file
-func hashFile(path string) (string, error) {
-	f, err := os.Open(path)
-	if err != nil {
-		return "", err
-	}
-	defer f.Close()
-
-	h := sha1.New()
-	if _, err := io.Copy(h, f); err != nil {
-		return "", err
-	}
-
-	return hex.EncodeToString(h.Sum(nil)), nil
+	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
 
 // FormatSpeed formats download speed for display
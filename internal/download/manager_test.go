@@ -3,11 +3,15 @@ package download
 import (
 	"context"
 	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -75,7 +79,7 @@ func TestDownload_SHA1Validation(t *testing.T) {
 		t.Fatalf("Download failed: %v", err)
 	}
 	if result.Failed != 0 {
-		t.Errorf("Expected 0 failures, got %d with errors: %v", result.Failed, result.Errors)
+		t.Errorf("Expected 0 failures, got %d with errors: %v", result.Failed, result.Err())
 	}
 }
 
@@ -102,6 +106,49 @@ func TestDownload_SHA1Mismatch(t *testing.T) {
 	}
 }
 
+func TestDownload_VerifiesStrongestHash(t *testing.T) {
+	content := []byte("Test content for hashing")
+	sum256 := sha256.Sum256(content)
+	sum512 := sha512.Sum512(content)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		item     Item
+		wantAlgo string // "" when the download should succeed
+	}{
+		{"sha256", Item{SHA256: hex.EncodeToString(sum256[:])}, ""},
+		{"sha512 uppercase", Item{SHA512: strings.ToUpper(hex.EncodeToString(sum512[:]))}, ""},
+		// A wrong SHA-1 is ignored when a stronger hash is given.
+		{"sha512 over sha1", Item{SHA1: strings.Repeat("0", 40), SHA512: hex.EncodeToString(sum512[:])}, ""},
+		{"sha256 mismatch", Item{SHA256: strings.Repeat("0", 64)}, "sha256"},
+		{"sha512 mismatch", Item{SHA256: hex.EncodeToString(sum256[:]), SHA512: strings.Repeat("0", 128)}, "sha512"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := tt.item
+			item.URL = server.URL
+			item.Path = filepath.Join(t.TempDir(), "hashed.txt")
+
+			result, err := NewManager(1).Download(context.Background(), []Item{item}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var mismatch *HashMismatchError
+			switch {
+			case tt.wantAlgo == "" && result.Failed != 0:
+				t.Errorf("unexpected failure: %v", result.Err())
+			case tt.wantAlgo != "" && (!errors.As(result.Err(), &mismatch) || mismatch.Algorithm != tt.wantAlgo):
+				t.Errorf("error = %v, want a %s mismatch", result.Err(), tt.wantAlgo)
+			}
+		})
+	}
+}
+
 func TestDownload_SkipsExistingValid(t *testing.T) {
 	content := []byte("Existing content")
 	hash := sha1.Sum(content)
@@ -0,0 +1,110 @@
+package download
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Mirror rewrites URLs starting with From to start with To instead, e.g. From
+// "https://resources.download.minecraft.net/" To "https://mirror.lan/assets/".
+type Mirror struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// The process-wide mirror table, applied to every download and metadata fetch.
+var (
+	mirrorsMu sync.RWMutex
+	mirrors   []Mirror
+)
+
+// SetMirrors replaces the mirror table. Rules are tried in order; several rules
+// for the same upstream give several fallbacks.
+func SetMirrors(ms []Mirror) {
+	mirrorsMu.Lock()
+	defer mirrorsMu.Unlock()
+	mirrors = append([]Mirror(nil), ms...)
+}
+
+// MirrorURLs returns the mirror rewrites of url in table order, followed by url
+// itself, so the upstream stays the last resort.
+func MirrorURLs(url string) []string {
+	mirrorsMu.RLock()
+	defer mirrorsMu.RUnlock()
+	var urls []string
+	for _, m := range mirrors {
+		if m.From != "" && m.To != "" && strings.HasPrefix(url, m.From) {
+			urls = appendUnique(urls, m.To+strings.TrimPrefix(url, m.From))
+		}
+	}
+	return appendUnique(urls, url)
+}
+
+// sources returns every URL to try for item: its URL then each fallback, each
+// preceded by its mirrors.
+func sources(item Item) []string {
+	var urls []string
+	for _, u := range append([]string{item.URL}, item.Fallbacks...) {
+		if u == "" {
+			continue
+		}
+		for _, m := range MirrorURLs(u) {
+			urls = appendUnique(urls, m)
+		}
+	}
+	return urls
+}
+
+func appendUnique(urls []string, url string) []string {
+	for _, u := range urls {
+		if u == url {
+			return urls
+		}
+	}
+	return append(urls, url)
+}
+
+// Get fetches url with client, trying its mirrors first, and returns the first
+// 200 response. It is for metadata that isn't saved as a download [Item].
+func Get(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	var lastErr error
+	for _, u := range MirrorURLs(url) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = err
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		if resp.StatusCode == http.StatusOK {
+			return resp, nil
+		}
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		resp.Body.Close()
+		lastErr = &StatusError{URL: u, Code: resp.StatusCode, Body: strings.TrimSpace(string(b))}
+	}
+	return nil, lastErr
+}
+
+// StatusError is a non-200 response from a download source.
+type StatusError struct {
+	URL  string
+	Code int
+	Body string // start of the response body, if any
+}
+
+func (e *StatusError) Error() string {
+	if e.Body != "" {
+		return fmt.Sprintf("%s: status %d: %s", e.URL, e.Code, e.Body)
+	}
+	return fmt.Sprintf("%s: status %d", e.URL, e.Code)
+}
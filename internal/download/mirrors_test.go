@@ -0,0 +1,91 @@
+package download
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func setMirrors(t *testing.T, ms ...Mirror) {
+	t.Helper()
+	SetMirrors(ms)
+	t.Cleanup(func() { SetMirrors(nil) })
+}
+
+func TestMirrorURLs(t *testing.T) {
+	setMirrors(t,
+		Mirror{From: "https://piston-data.mojang.com/", To: "http://cache.lan/piston/"},
+		Mirror{From: "https://piston-data.mojang.com/", To: "https://bmclapi2.bangbang93.com/"},
+		Mirror{From: "https://maven.fabricmc.net/", To: "http://cache.lan/fabric/"},
+	)
+	got := MirrorURLs("https://piston-data.mojang.com/v1/objects/abc/client.jar")
+	want := []string{
+		"http://cache.lan/piston/v1/objects/abc/client.jar",
+		"https://bmclapi2.bangbang93.com/v1/objects/abc/client.jar",
+		"https://piston-data.mojang.com/v1/objects/abc/client.jar",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MirrorURLs() = %q, want %q", got, want)
+	}
+	if got := MirrorURLs("https://cdn.modrinth.com/x.jar"); len(got) != 1 {
+		t.Errorf("unmatched URL should only yield itself, got %q", got)
+	}
+}
+
+func TestDownload_FallsBackFromBadMirrors(t *testing.T) {
+	content := []byte("the real client jar")
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content)
+	}))
+	defer upstream.Close()
+	corrupt := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("stale copy"))
+	}))
+	defer corrupt.Close()
+	missing := httptest.NewServer(http.NotFoundHandler())
+	defer missing.Close()
+
+	setMirrors(t,
+		Mirror{From: upstream.URL, To: missing.URL},
+		Mirror{From: upstream.URL, To: corrupt.URL},
+	)
+
+	destPath := filepath.Join(t.TempDir(), "client.jar")
+	result, err := NewManager(1).Download(context.Background(), []Item{{
+		URL: upstream.URL + "/client.jar", Path: destPath, SHA1: sha1Hex(content),
+	}}, nil)
+	if err != nil || result.Failed != 0 {
+		t.Fatalf("Download() = %+v, %v", result, err)
+	}
+	if data, _ := os.ReadFile(destPath); string(data) != string(content) {
+		t.Errorf("content = %q, want the upstream file after both mirrors failed", data)
+	}
+}
+
+func TestDownload_TriesItemFallbacks(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer down.Close()
+	backup := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("from backup"))
+	}))
+	defer backup.Close()
+
+	destPath := filepath.Join(t.TempDir(), "lib.jar")
+	mgr := NewManager(1)
+	mgr.httpClient = http.DefaultClient // skip retryablehttp's backoff on the 502
+	result, _ := mgr.Download(context.Background(), []Item{{
+		URL: down.URL, Fallbacks: []string{backup.URL}, Path: destPath,
+	}}, nil)
+	if result.Failed != 0 {
+		t.Fatalf("Download failed: %v", result.Err())
+	}
+	if data, _ := os.ReadFile(destPath); string(data) != "from backup" {
+		t.Errorf("content = %q, want the fallback's file", data)
+	}
+}
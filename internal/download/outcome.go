@@ -0,0 +1,140 @@
+package download
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"path/filepath"
+)
+
+// FailureKind says why an item failed to download.
+type FailureKind int
+
+const (
+	FailOther        FailureKind = iota // None of the below
+	FailNetwork                         // Connection refused, dropped, timed out, ...
+	FailHTTPStatus                      // The server answered with an error status; see [StatusError]
+	FailHashMismatch                    // The file arrived but its hash was wrong; see [HashMismatchError]
+	FailDiskFull                        // No space left to write the file
+	FailPermission                      // The destination isn't writable
+	FailCancelled                       // The batch was cancelled first
+)
+
+func (k FailureKind) String() string {
+	switch k {
+	case FailNetwork:
+		return "network"
+	case FailHTTPStatus:
+		return "HTTP status"
+	case FailHashMismatch:
+		return "hash mismatch"
+	case FailDiskFull:
+		return "disk full"
+	case FailPermission:
+		return "permission denied"
+	case FailCancelled:
+		return "cancelled"
+	default:
+		return "error"
+	}
+}
+
+// Outcome is what happened to one item of a batch.
+type Outcome struct {
+	Item     Item
+	Kind     FailureKind // Why it failed; only meaningful when Err is set
+	Attempts int         // Requests made, counting resumes and fallback sources; 0 when the file was already in place
+	Err      error       // Final error; nil when the item was verified
+}
+
+// Failed reports whether the item didn't make it.
+func (o Outcome) Failed() bool { return o.Err != nil }
+
+// HashMismatchError is a downloaded file whose hash isn't the expected one.
+type HashMismatchError struct {
+	Algorithm string // "sha1", "sha256" or "sha512"
+	Expected  string
+	Got       string
+}
+
+func (e *HashMismatchError) Error() string {
+	return fmt.Sprintf("%s mismatch: expected %s, got %s", e.Algorithm, e.Expected, e.Got)
+}
+
+// Classify sorts a download error into a [FailureKind].
+func Classify(err error) FailureKind {
+	var (
+		hashErr   *HashMismatchError
+		statusErr *StatusError
+		netErr    net.Error
+	)
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return FailCancelled
+	case errors.As(err, &hashErr):
+		return FailHashMismatch
+	case errors.As(err, &statusErr):
+		return FailHTTPStatus
+	case isDiskFull(err):
+		return FailDiskFull
+	case errors.Is(err, fs.ErrPermission):
+		return FailPermission
+	case errors.As(err, &netErr), errors.Is(err, errInterrupted), errors.Is(err, io.ErrUnexpectedEOF):
+		return FailNetwork
+	}
+	return FailOther
+}
+
+// BatchError lists the items of a batch that failed, in the order they were
+// given. errors.Is and errors.As see each item's error.
+type BatchError struct {
+	Failures []Outcome
+}
+
+func (e *BatchError) Error() string {
+	first := e.Failures[0]
+	name := filepath.Base(first.Item.Path)
+	if len(e.Failures) == 1 {
+		return fmt.Sprintf("downloading %s: %v", name, first.Err)
+	}
+	return fmt.Sprintf("%d files failed to download (first, %s: %v)", len(e.Failures), name, first.Err)
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, o := range e.Failures {
+		errs[i] = o.Err
+	}
+	return errs
+}
+
+// Failures returns the outcomes of the items that failed.
+func (r *Result) Failures() []Outcome {
+	var out []Outcome
+	for _, o := range r.Outcomes {
+		if o.Failed() {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// FailedItems returns the items that failed, ready to download again.
+func (r *Result) FailedItems() []Item {
+	var items []Item
+	for _, o := range r.Failures() {
+		items = append(items, o.Item)
+	}
+	return items
+}
+
+// Err returns a [*BatchError] when any item failed, nil otherwise.
+func (r *Result) Err() error {
+	if failures := r.Failures(); len(failures) > 0 {
+		return &BatchError{Failures: failures}
+	}
+	return nil
+}
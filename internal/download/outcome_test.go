@@ -0,0 +1,115 @@
+package download
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want FailureKind
+	}{
+		{"cancelled", fmt.Errorf("downloading: %w", context.Canceled), FailCancelled},
+		{"status", &StatusError{URL: "https://example.com/a", Code: 404}, FailHTTPStatus},
+		{"hash", &HashMismatchError{Expected: "a", Got: "b"}, FailHashMismatch},
+		{"disk full", fmt.Errorf("writing file: %w", &os.PathError{Op: "write", Path: "x", Err: syscall.ENOSPC}), FailDiskFull},
+		{"permission", fmt.Errorf("creating file: %w", &os.PathError{Op: "open", Path: "x", Err: os.ErrPermission}), FailPermission},
+		{"dropped", fmt.Errorf("%w: reading response: EOF", errInterrupted), FailNetwork},
+		{"other", errors.New("something else"), FailOther},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.err); got != tt.want {
+			t.Errorf("%s: Classify = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDownload_ReportsOutcomePerItem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			http.NotFound(w, r)
+		default:
+			w.Write([]byte("content"))
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	items := []Item{
+		{URL: server.URL + "/ok", Path: filepath.Join(dir, "ok.txt")},
+		{URL: server.URL + "/missing", Path: filepath.Join(dir, "missing.txt")},
+		{URL: server.URL + "/bad", Path: filepath.Join(dir, "bad.txt"), SHA1: "0000000000000000000000000000000000000000"},
+	}
+
+	result, err := NewManager(2).Download(context.Background(), items, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Completed != 1 || result.Failed != 2 || len(result.Outcomes) != 3 {
+		t.Fatalf("result = %d completed, %d failed, %d outcomes", result.Completed, result.Failed, len(result.Outcomes))
+	}
+
+	ok, missing, bad := result.Outcomes[0], result.Outcomes[1], result.Outcomes[2]
+	if ok.Failed() || ok.Attempts != 1 || ok.Item.Path != items[0].Path {
+		t.Errorf("ok outcome = %+v", ok)
+	}
+	var statusErr *StatusError
+	if missing.Kind != FailHTTPStatus || !errors.As(missing.Err, &statusErr) || statusErr.Code != http.StatusNotFound {
+		t.Errorf("missing outcome = %+v", missing)
+	}
+	if bad.Kind != FailHashMismatch {
+		t.Errorf("bad outcome = %+v", bad)
+	}
+
+	var batchErr *BatchError
+	if !errors.As(result.Err(), &batchErr) || len(batchErr.Failures) != 2 {
+		t.Fatalf("Err() = %v, want a BatchError with 2 failures", result.Err())
+	}
+	if !errors.As(result.Err(), &statusErr) {
+		t.Error("BatchError should unwrap to the item errors")
+	}
+	retry := result.FailedItems()
+	if len(retry) != 2 || retry[0].URL != items[1].URL || retry[1].URL != items[2].URL {
+		t.Errorf("FailedItems = %+v", retry)
+	}
+}
+
+func TestDownload_CancelledItemsAreReported(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	dir := t.TempDir()
+	items := []Item{
+		{URL: "http://127.0.0.1:1/a", Path: filepath.Join(dir, "a")},
+		{URL: "http://127.0.0.1:1/b", Path: filepath.Join(dir, "b")},
+	}
+	result, err := NewManager(1).Download(ctx, items, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Failed != 2 {
+		t.Fatalf("Failed = %d, want 2", result.Failed)
+	}
+	for _, o := range result.Outcomes {
+		if o.Kind != FailCancelled || !errors.Is(o.Err, context.Canceled) {
+			t.Errorf("outcome = %+v, want cancelled", o)
+		}
+	}
+}
+
+func TestResult_ErrNilWhenAllVerified(t *testing.T) {
+	r := &Result{Completed: 1, Outcomes: []Outcome{{Item: Item{Path: "a"}, Attempts: 1}}}
+	if err := r.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
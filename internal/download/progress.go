@@ -0,0 +1,177 @@
+package download
+
+import (
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ItemState is where an item is in a batch.
+type ItemState int32
+
+const (
+	ItemQueued   ItemState = iota // Waiting for a worker
+	ItemActive                    // Transferring
+	ItemVerified                  // On disk with the expected hash (or already was)
+	ItemFailed                    // Gave up; see ItemProgress.Err
+)
+
+func (s ItemState) String() string {
+	switch s {
+	case ItemActive:
+		return "active"
+	case ItemVerified:
+		return "verified"
+	case ItemFailed:
+		return "failed"
+	default:
+		return "queued"
+	}
+}
+
+// ItemProgress is the state of one item in a batch.
+type ItemProgress struct {
+	Name  string // Base name of the destination file
+	State ItemState
+	Bytes int64   // Bytes of this item downloaded so far
+	Size  int64   // Expected size; 0 when unknown
+	Speed float64 // bytes per second, smoothed
+	Err   error   // Why the item failed, when State is ItemFailed
+}
+
+// speedSmoothing weighs the latest sample when smoothing speeds, so the
+// display and ETA don't jump with every 100ms tick.
+const speedSmoothing = 0.3
+
+// transfer tracks one item of a running batch. Workers update state and bytes;
+// the progress reporter owns lastBytes and speed. kind and attempts belong to
+// the worker downloading the item.
+type transfer struct {
+	item  Item
+	state atomic.Int32
+	bytes atomic.Int64
+
+	errMu sync.Mutex
+	err   error
+
+	kind     FailureKind
+	attempts int
+
+	lastBytes int64
+	speed     float64
+}
+
+func (t *transfer) setState(s ItemState) { t.state.Store(int32(s)) }
+
+func (t *transfer) fail(err error) {
+	t.errMu.Lock()
+	t.err = err
+	t.errMu.Unlock()
+	t.setState(ItemFailed)
+}
+
+func (t *transfer) snapshot() ItemProgress {
+	t.errMu.Lock()
+	err := t.err
+	t.errMu.Unlock()
+	return ItemProgress{
+		Name:  filepath.Base(t.item.Path),
+		State: ItemState(t.state.Load()),
+		Bytes: t.bytes.Load(),
+		Size:  t.item.Size,
+		Speed: t.speed,
+		Err:   err,
+	}
+}
+
+// reporter turns a batch's transfers into Progress snapshots.
+type reporter struct {
+	transfers  []*transfer
+	totalBytes int64
+	downloaded *atomic.Int64
+
+	start     time.Time
+	lastTime  time.Time
+	lastBytes int64
+	rate      float64 // smoothed bytes per second, for the ETA
+}
+
+func newReporter(transfers []*transfer, totalBytes int64, downloaded *atomic.Int64, now time.Time) *reporter {
+	return &reporter{
+		transfers:  transfers,
+		totalBytes: totalBytes,
+		downloaded: downloaded,
+		start:      now,
+		lastTime:   now,
+	}
+}
+
+// snapshot samples the batch at now. Active items are listed in the order the
+// batch was queued, failed ones likewise.
+func (r *reporter) snapshot(now time.Time) Progress {
+	p := Progress{
+		TotalBytes: r.totalBytes,
+		TotalItems: len(r.transfers),
+	}
+	current := r.downloaded.Load()
+	p.DownloadedBytes = current
+
+	elapsed := now.Sub(r.lastTime).Seconds()
+	if elapsed > 0 {
+		p.Speed = float64(current-r.lastBytes) / elapsed
+		r.rate = smooth(r.rate, p.Speed)
+		r.lastBytes = current
+		r.lastTime = now
+	}
+
+	for _, t := range r.transfers {
+		state := ItemState(t.state.Load())
+		if elapsed > 0 {
+			b := t.bytes.Load()
+			if state == ItemActive {
+				t.speed = smooth(t.speed, float64(b-t.lastBytes)/elapsed)
+			}
+			t.lastBytes = b
+		}
+		switch state {
+		case ItemQueued:
+			p.QueuedItems++
+		case ItemActive:
+			p.Active = append(p.Active, t.snapshot())
+		case ItemVerified:
+			p.CompletedItems++
+		case ItemFailed:
+			p.FailedItems++
+			p.Failed = append(p.Failed, t.snapshot())
+		}
+	}
+
+	p.ETA = r.eta(p, now)
+	return p
+}
+
+// eta estimates the time left: from bytes when sizes are known, otherwise
+// from how long finished items have taken so far.
+func (r *reporter) eta(p Progress, now time.Time) time.Duration {
+	if p.TotalBytes > 0 {
+		left := p.TotalBytes - p.DownloadedBytes
+		if left <= 0 || r.rate <= 0 {
+			return 0
+		}
+		return time.Duration(float64(left) / r.rate * float64(time.Second))
+	}
+	done := p.CompletedItems + p.FailedItems
+	left := p.TotalItems - done
+	if done == 0 || left <= 0 {
+		return 0
+	}
+	return now.Sub(r.start) / time.Duration(done) * time.Duration(left)
+}
+
+func smooth(prev, sample float64) float64 {
+	if prev == 0 {
+		return sample
+	}
+	return prev + speedSmoothing*(sample-prev)
+}
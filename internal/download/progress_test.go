@@ -0,0 +1,105 @@
+package download
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDownload_HighestPriorityFirst(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		order = append(order, strings.TrimPrefix(r.URL.Path, "/"))
+		mu.Unlock()
+		w.Write([]byte("x"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	item := func(name string, priority int) Item {
+		return Item{URL: server.URL + "/" + name, Path: filepath.Join(dir, name), Priority: priority}
+	}
+	items := []Item{item("a", 0), item("b", 5), item("c", 0), item("d", 10), item("e", 5)}
+
+	if _, err := NewManager(1).Download(context.Background(), items, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(order, ","), "d,b,e,a,c"; got != want {
+		t.Errorf("download order = %s, want %s", got, want)
+	}
+}
+
+func TestReporter_Snapshot(t *testing.T) {
+	mk := func(name string, size int64, state ItemState, bytes int64) *transfer {
+		tr := &transfer{item: Item{Path: filepath.Join("dir", name), Size: size}}
+		tr.setState(state)
+		tr.bytes.Store(bytes)
+		return tr
+	}
+	failed := mk("bad.jar", 100, ItemQueued, 0)
+	failed.fail(errors.New("unexpected status: 404"))
+	transfers := []*transfer{
+		mk("done.jar", 100, ItemVerified, 100),
+		mk("big.jar", 600, ItemActive, 0),
+		failed,
+		mk("next.jar", 200, ItemQueued, 0),
+	}
+	var downloaded atomic.Int64
+	downloaded.Store(100)
+
+	start := time.Unix(0, 0)
+	r := newReporter(transfers, 1000, &downloaded, start)
+	r.lastBytes = 100
+
+	// One second later big.jar has 200 bytes in.
+	transfers[1].bytes.Store(200)
+	downloaded.Store(300)
+	p := r.snapshot(start.Add(time.Second))
+
+	if p.CompletedItems != 1 || p.FailedItems != 1 || p.QueuedItems != 1 || len(p.Active) != 1 {
+		t.Fatalf("counts = %d verified, %d failed, %d queued, %d active", p.CompletedItems, p.FailedItems, p.QueuedItems, len(p.Active))
+	}
+	a := p.Active[0]
+	if a.Name != "big.jar" || a.Bytes != 200 || a.Size != 600 || a.Speed != 200 {
+		t.Errorf("active = %+v", a)
+	}
+	if len(p.Failed) != 1 || p.Failed[0].Name != "bad.jar" || p.Failed[0].Err == nil {
+		t.Errorf("failed = %+v", p.Failed)
+	}
+	if p.Speed != 200 {
+		t.Errorf("speed = %v, want 200", p.Speed)
+	}
+	// 700 bytes left at 200 B/s.
+	if p.ETA != 3500*time.Millisecond {
+		t.Errorf("ETA = %v, want 3.5s", p.ETA)
+	}
+}
+
+func TestReporter_ETAFromItemsWhenSizesUnknown(t *testing.T) {
+	transfers := make([]*transfer, 4)
+	for i := range transfers {
+		transfers[i] = &transfer{}
+	}
+	transfers[0].setState(ItemVerified)
+	var downloaded atomic.Int64
+
+	start := time.Unix(0, 0)
+	r := newReporter(transfers, 0, &downloaded, start)
+	p := r.snapshot(start.Add(2 * time.Second))
+
+	// One item took 2s; three remain.
+	if p.ETA != 6*time.Second {
+		t.Errorf("ETA = %v, want 6s", p.ETA)
+	}
+}
@@ -0,0 +1,94 @@
+package download
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// maxResumes is how many times one item resumes after its connection drops
+// mid-body, on top of the request retries retryablehttp does itself.
+const maxResumes = 3
+
+// errInterrupted marks a transfer that stopped part way; the partial file is
+// kept so the next attempt can resume it.
+var errInterrupted = errors.New("download interrupted")
+
+// partialMeta is stored next to a partial .tmp file and identifies the remote
+// file it came from, so a resume can ask the server to confirm it hasn't changed.
+type partialMeta struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+func partialMetaPath(tmpPath string) string {
+	return tmpPath + ".meta"
+}
+
+// validator returns the If-Range value for a resume, or "" if the server gave
+// nothing usable. If-Range needs a strong ETag, so weak ones fall back to
+// Last-Modified.
+func (p partialMeta) validator() string {
+	if p.ETag != "" && !strings.HasPrefix(p.ETag, "W/") {
+		return p.ETag
+	}
+	return p.LastModified
+}
+
+// loadPartial returns the resumable partial download for url at tmpPath and its
+// size. The size is 0 if there is none, it came from another URL, or the server
+// gave no validator to resume it safely.
+func loadPartial(tmpPath, url string) (partialMeta, int64) {
+	data, err := os.ReadFile(partialMetaPath(tmpPath))
+	if err != nil {
+		return partialMeta{}, 0
+	}
+	var meta partialMeta
+	if json.Unmarshal(data, &meta) != nil || meta.URL != url || meta.validator() == "" {
+		return partialMeta{}, 0
+	}
+	info, err := os.Stat(tmpPath)
+	if err != nil {
+		return partialMeta{}, 0
+	}
+	return meta, info.Size()
+}
+
+// savePartialMeta records resp's validators for a download starting into tmpPath.
+// Without a validator the partial file can't be resumed, so no meta is kept.
+func savePartialMeta(tmpPath, url string, resp *http.Response) {
+	meta := partialMeta{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+	if meta.validator() == "" {
+		os.Remove(partialMetaPath(tmpPath))
+		return
+	}
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(partialMetaPath(tmpPath), data, 0644)
+}
+
+// removePartial deletes a partial download and its meta.
+func removePartial(tmpPath string) {
+	os.Remove(tmpPath)
+	os.Remove(partialMetaPath(tmpPath))
+}
+
+// resumesAt reports whether resp continues the file from byte offset.
+func resumesAt(resp *http.Response, offset int64) bool {
+	if resp.StatusCode != http.StatusPartialContent {
+		return false
+	}
+	var start, end int64
+	_, err := fmt.Sscanf(resp.Header.Get("Content-Range"), "bytes %d-%d", &start, &end)
+	return err == nil && start == offset
+}
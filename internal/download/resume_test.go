@@ -0,0 +1,111 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func sha1Hex(b []byte) string {
+	sum := sha1.Sum(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func serveWithETag(content []byte, etag string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", etag)
+		http.ServeContent(w, r, "file", time.Time{}, bytes.NewReader(content))
+	}
+}
+
+func TestDownload_ResumesPartialFile(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	var rangeHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rangeHeader = r.Header.Get("Range")
+		serveWithETag(content, `"v1"`)(w, r)
+	}))
+	defer server.Close()
+
+	destPath := filepath.Join(t.TempDir(), "client.jar")
+	tmpPath := destPath + ".tmp"
+	os.WriteFile(tmpPath, content[:4000], 0644)
+	os.WriteFile(partialMetaPath(tmpPath), []byte(`{"url":"`+server.URL+`","etag":"\"v1\""}`), 0644)
+
+	result, err := NewManager(1).Download(context.Background(), []Item{{
+		URL: server.URL, Path: destPath, SHA1: sha1Hex(content), Size: int64(len(content)),
+	}}, nil)
+	if err != nil || result.Failed != 0 {
+		t.Fatalf("Download() = %+v, %v", result, err)
+	}
+	if rangeHeader != "bytes=4000-" {
+		t.Errorf("Range = %q, want the partial file resumed", rangeHeader)
+	}
+	if data, _ := os.ReadFile(destPath); !bytes.Equal(data, content) {
+		t.Error("resumed file content mismatch")
+	}
+	if _, err := os.Stat(partialMetaPath(tmpPath)); !os.IsNotExist(err) {
+		t.Error("partial meta should be removed after a completed download")
+	}
+}
+
+func TestDownload_RestartsWhenRemoteChanged(t *testing.T) {
+	content := bytes.Repeat([]byte("abcdefghij"), 500)
+	server := httptest.NewServer(serveWithETag(content, `"v2"`))
+	defer server.Close()
+
+	destPath := filepath.Join(t.TempDir(), "asset")
+	tmpPath := destPath + ".tmp"
+	os.WriteFile(tmpPath, []byte("stale bytes from an older version"), 0644)
+	os.WriteFile(partialMetaPath(tmpPath), []byte(`{"url":"`+server.URL+`","etag":"\"v1\""}`), 0644)
+
+	result, _ := NewManager(1).Download(context.Background(), []Item{{
+		URL: server.URL, Path: destPath, SHA1: sha1Hex(content),
+	}}, nil)
+	if result.Failed != 0 {
+		t.Fatalf("Download failed: %v", result.Err())
+	}
+	if data, _ := os.ReadFile(destPath); !bytes.Equal(data, content) {
+		t.Error("a changed remote file should be downloaded from scratch")
+	}
+}
+
+func TestDownload_ResumesAfterDroppedConnection(t *testing.T) {
+	content := bytes.Repeat([]byte("minecraft!"), 2000)
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			// Send half the body, then drop the connection.
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			w.Write(content[:len(content)/2])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		if r.Header.Get("Range") == "" {
+			t.Error("retry after a dropped connection should resume with a Range request")
+		}
+		serveWithETag(content, `"v1"`)(w, r)
+	}))
+	defer server.Close()
+
+	destPath := filepath.Join(t.TempDir(), "runtime.zip")
+	result, _ := NewManager(1).Download(context.Background(), []Item{{
+		URL: server.URL, Path: destPath, SHA1: sha1Hex(content),
+	}}, nil)
+	if result.Failed != 0 {
+		t.Fatalf("Download failed: %v", result.Err())
+	}
+	if data, _ := os.ReadFile(destPath); !bytes.Equal(data, content) {
+		t.Error("resumed file content mismatch")
+	}
+}
@@ -0,0 +1,223 @@
+package download
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// DefaultPerHost is how many connections the scheduler allows to one host when
+// [Limits.PerHost] is unset.
+const DefaultPerHost = 8
+
+// throttleChunk bounds each rate-limited read, so concurrent transfers
+// interleave instead of one large read taking the whole budget.
+const throttleChunk = 32 * 1024
+
+// Limits configures the shared [Scheduler].
+type Limits struct {
+	BytesPerSec int64 // total download rate across all transfers; 0 is unlimited
+	PerHost     int   // concurrent connections per host; 0 uses DefaultPerHost
+}
+
+// Scheduler is shared by every download in the process, whichever subsystem or
+// [Manager] starts it: transfers share one HTTP client, a cap on total
+// throughput, and a connection limit per host. Limits are per host rather than
+// per batch, so a mod install still gets connections while a large asset
+// download runs against Mojang's CDN. It is safe for concurrent use.
+type Scheduler struct {
+	client *http.Client
+
+	mu      sync.Mutex
+	perHost int
+	hosts   map[string]*hostSlots
+	limiter *rateLimiter // nil when unlimited
+}
+
+// hostSlots counts a host's open connections; wait is closed when one frees up.
+type hostSlots struct {
+	active int
+	wait   chan struct{}
+}
+
+var shared = NewScheduler(Limits{})
+
+// Shared returns the process-wide scheduler that [NewManager] uses.
+func Shared() *Scheduler {
+	return shared
+}
+
+// SetLimits reconfigures the shared scheduler. Transfers in flight pick up the
+// new rate on their next read.
+func SetLimits(l Limits) {
+	shared.SetLimits(l)
+}
+
+// NewScheduler returns a scheduler with its own HTTP client. Most callers want
+// [Shared] instead.
+func NewScheduler(l Limits) *Scheduler {
+	// Create retryable client with sensible defaults
+	retryClient := retryablehttp.NewClient()
+	retryClient.RetryMax = 3
+	retryClient.RetryWaitMin = 1 * time.Second
+	retryClient.RetryWaitMax = 10 * time.Second
+	retryClient.Logger = nil // Silence default logging
+	// Hand back the last response once retries run out, so callers can
+	// report its status instead of a bare "giving up" error.
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+
+	// Configure underlying transport
+	retryClient.HTTPClient.Transport = &http.Transport{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	retryClient.HTTPClient.Timeout = 5 * time.Minute
+
+	s := &Scheduler{
+		client: retryClient.StandardClient(),
+		hosts:  map[string]*hostSlots{},
+	}
+	s.SetLimits(l)
+	return s
+}
+
+// SetLimits replaces the scheduler's limits.
+func (s *Scheduler) SetLimits(l Limits) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.perHost = l.PerHost
+	if s.perHost <= 0 {
+		s.perHost = DefaultPerHost
+	}
+	s.limiter = nil
+	if l.BytesPerSec > 0 {
+		s.limiter = newRateLimiter(float64(l.BytesPerSec))
+	}
+	// Wake waiters so a raised per-host limit takes effect immediately.
+	for _, h := range s.hosts {
+		close(h.wait)
+		h.wait = make(chan struct{})
+	}
+}
+
+// Acquire waits for a connection slot to rawURL's host. The caller must call
+// release once the transfer is done.
+func (s *Scheduler) Acquire(ctx context.Context, rawURL string) (release func(), err error) {
+	host := rawURL
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	for {
+		s.mu.Lock()
+		h := s.hosts[host]
+		if h == nil {
+			h = &hostSlots{wait: make(chan struct{})}
+			s.hosts[host] = h
+		}
+		if h.active < s.perHost {
+			h.active++
+			s.mu.Unlock()
+			var once sync.Once
+			return func() { once.Do(func() { s.release(host) }) }, nil
+		}
+		wait := h.wait
+		s.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (s *Scheduler) release(host string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := s.hosts[host]
+	if h == nil {
+		return
+	}
+	h.active--
+	close(h.wait)
+	h.wait = make(chan struct{})
+	if h.active <= 0 {
+		delete(s.hosts, host)
+	}
+}
+
+// Throttle wraps r so reads count against the scheduler's rate limit.
+func (s *Scheduler) Throttle(ctx context.Context, r io.Reader) io.Reader {
+	return &throttledReader{ctx: ctx, r: r, s: s}
+}
+
+func (s *Scheduler) currentLimiter() *rateLimiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.limiter
+}
+
+type throttledReader struct {
+	ctx context.Context
+	r   io.Reader
+	s   *Scheduler
+}
+
+func (t *throttledReader) Read(p []byte) (int, error) {
+	limiter := t.s.currentLimiter()
+	if limiter == nil {
+		return t.r.Read(p)
+	}
+	if len(p) > throttleChunk {
+		p = p[:throttleChunk]
+	}
+	n, err := t.r.Read(p)
+	if n > 0 {
+		if werr := limiter.wait(t.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+// rateLimiter is a token bucket refilled at rate bytes per second, holding at
+// most one second's worth. Callers take tokens after reading and sleep off any
+// debt, so concurrent readers share the rate roughly evenly.
+type rateLimiter struct {
+	rate float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64) *rateLimiter {
+	return &rateLimiter{rate: rate, tokens: rate, last: time.Now()}
+}
+
+func (l *rateLimiter) wait(ctx context.Context, n int) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.rate, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens -= float64(n)
+	debt := -l.tokens
+	l.mu.Unlock()
+	if debt <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(time.Duration(debt / l.rate * float64(time.Second)))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,68 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestScheduler_PerHostLimit(t *testing.T) {
+	s := NewScheduler(Limits{PerHost: 1})
+	ctx := context.Background()
+
+	release, err := s.Acquire(ctx, "https://resources.download.minecraft.net/ab/abcd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Another host is unaffected by the busy one.
+	other, err := s.Acquire(ctx, "https://cdn.modrinth.com/data/x.jar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other()
+
+	blocked, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := s.Acquire(blocked, "https://resources.download.minecraft.net/cd/cdef"); err == nil {
+		t.Fatal("second connection to a host at its limit should wait")
+	}
+
+	got := make(chan struct{})
+	go func() {
+		r, err := s.Acquire(ctx, "https://resources.download.minecraft.net/cd/cdef")
+		if err == nil {
+			r()
+		}
+		close(got)
+	}()
+	release()
+	select {
+	case <-got:
+	case <-time.After(time.Second):
+		t.Fatal("releasing a slot should let a waiter through")
+	}
+}
+
+func TestScheduler_ThrottlesTotalRate(t *testing.T) {
+	const rate = 64 * 1024
+	s := NewScheduler(Limits{BytesPerSec: rate})
+	data := bytes.Repeat([]byte("x"), rate+rate/2) // one second's burst plus half a second
+
+	start := time.Now()
+	n, err := io.Copy(io.Discard, s.Throttle(context.Background(), bytes.NewReader(data)))
+	if err != nil || n != int64(len(data)) {
+		t.Fatalf("copy = %d, %v", n, err)
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("read %d bytes at %d B/s in %v, want about 500ms", len(data), rate, elapsed)
+	}
+
+	s.SetLimits(Limits{})
+	start = time.Now()
+	io.Copy(io.Discard, s.Throttle(context.Background(), bytes.NewReader(data)))
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("unlimited read took %v", elapsed)
+	}
+}
@@ -0,0 +1,113 @@
+package java
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Requirement is the range of Java an instance can run on.
+type Requirement struct {
+	Min       int    // oldest major version; 0 accepts any
+	Max       int    // newest major version; 0 is unbounded
+	Need64Bit bool   // e.g. a heap too large for a 32-bit JVM
+	MinReason string // what set Min, e.g. "Minecraft 1.21.4"
+	MaxReason string // what set Max
+}
+
+// RequireAtLeast raises the minimum to major when that is stricter.
+func (r *Requirement) RequireAtLeast(major int, reason string) {
+	if major > r.Min {
+		r.Min = major
+		r.MinReason = reason
+	}
+}
+
+// RequireAtMost lowers the maximum to major when that is stricter.
+func (r *Requirement) RequireAtMost(major int, reason string) {
+	if major > 0 && (r.Max == 0 || major < r.Max) {
+		r.Max = major
+		r.MaxReason = reason
+	}
+}
+
+// Satisfiable reports whether any Java version meets r.
+func (r Requirement) Satisfiable() bool {
+	return r.Max == 0 || r.Min <= r.Max
+}
+
+// String describes the range, e.g. "Java 17 or newer" or "Java 8".
+func (r Requirement) String() string {
+	switch {
+	case r.Max == 0:
+		return fmt.Sprintf("Java %d or newer", max(r.Min, 8))
+	case r.Min == r.Max:
+		return fmt.Sprintf("Java %d", r.Min)
+	case r.Min <= 8:
+		return fmt.Sprintf("Java %d or older", r.Max)
+	}
+	return fmt.Sprintf("Java %d to %d", r.Min, r.Max)
+}
+
+// Problem says why inst can't meet r, or "" when it can.
+func (r Requirement) Problem(inst *Installation) string {
+	switch {
+	case r.Min > 0 && inst.MajorVersion < r.Min:
+		return fmt.Sprintf("Java %d is too old: %s needs Java %d or newer", inst.MajorVersion, r.MinReason, r.Min)
+	case r.Max > 0 && inst.MajorVersion > r.Max:
+		return fmt.Sprintf("Java %d is too new: %s needs Java %d or older", inst.MajorVersion, r.MaxReason, r.Max)
+	case r.Need64Bit && !inst.Is64Bit:
+		return "32-bit Java can't run it; pick a 64-bit Java"
+	}
+	return ""
+}
+
+// Inspect runs the java at path and parses its version.
+func (d *Detector) Inspect(path string) (*Installation, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	inst := d.checkJava(path)
+	if inst == nil {
+		return nil, fmt.Errorf("%s didn't report a Java version", path)
+	}
+	return inst, nil
+}
+
+// FindCompatible finds the installation on the system that meets r, preferring
+// the oldest major version that does, which is closest to what the game was
+// built for.
+func (d *Detector) FindCompatible(r Requirement) *Installation {
+	var best *Installation
+	for _, inst := range d.FindAll() {
+		if r.Problem(&inst) != "" {
+			continue
+		}
+		if best == nil || inst.MajorVersion < best.MajorVersion {
+			inst := inst
+			best = &inst
+		}
+	}
+	return best
+}
+
+// PickRuntime picks a runtime meeting r from runtimes: managed ones first,
+// then the oldest compatible major version. Returns nil when none does.
+func PickRuntime(runtimes []Runtime, r Requirement) *Runtime {
+	var fits []Runtime
+	for _, rt := range runtimes {
+		if rt.Path != "" && r.Problem(&rt.Installation) == "" {
+			fits = append(fits, rt)
+		}
+	}
+	if len(fits) == 0 {
+		return nil
+	}
+	sort.SliceStable(fits, func(i, j int) bool {
+		if fits[i].Managed != fits[j].Managed {
+			return fits[i].Managed
+		}
+		return fits[i].MajorVersion < fits[j].MajorVersion
+	})
+	return &fits[0]
+}
@@ -0,0 +1,56 @@
+package java
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRequirement(t *testing.T) {
+	var r Requirement
+	r.RequireAtLeast(8, "Minecraft 1.12.2")
+	r.RequireAtMost(8, "Minecraft 1.12.2")
+	if r.String() != "Java 8" {
+		t.Errorf("String = %q", r.String())
+	}
+	if p := r.Problem(&Installation{MajorVersion: 17, Is64Bit: true}); !strings.Contains(p, "too new: Minecraft 1.12.2 needs Java 8 or older") {
+		t.Errorf("Problem = %q", p)
+	}
+
+	r = Requirement{}
+	r.RequireAtLeast(17, "Minecraft 1.20.1")
+	r.RequireAtLeast(21, "Sodium")
+	r.RequireAtLeast(17, "Lithium") // looser, ignored
+	r.Need64Bit = true
+	if r.Min != 21 || r.MinReason != "Sodium" || r.String() != "Java 21 or newer" {
+		t.Errorf("r = %+v (%s)", r, r)
+	}
+	if p := r.Problem(&Installation{MajorVersion: 21}); !strings.Contains(p, "32-bit") {
+		t.Errorf("Problem = %q, want the 32-bit one", p)
+	}
+	if p := r.Problem(&Installation{MajorVersion: 25, Is64Bit: true}); p != "" {
+		t.Errorf("Problem = %q, want none", p)
+	}
+
+	r.RequireAtMost(17, "OldMod")
+	if r.Satisfiable() {
+		t.Error("Java 21+ and 17- together shouldn't be satisfiable")
+	}
+}
+
+func TestPickRuntime(t *testing.T) {
+	runtimes := []Runtime{
+		{Installation: Installation{Path: "/usr/jdk25", MajorVersion: 25, Is64Bit: true}},
+		{Installation: Installation{Path: "/usr/jdk17", MajorVersion: 17, Is64Bit: true}},
+		{Installation: Installation{Path: "/data/java/delta", MajorVersion: 21, Is64Bit: true}, Managed: true},
+		{Installation: Installation{MajorVersion: 17}, Managed: true}, // broken
+	}
+	if rt := PickRuntime(runtimes, Requirement{Min: 17}); rt == nil || rt.Path != "/data/java/delta" {
+		t.Errorf("Min 17 picked %+v, want the managed one", rt)
+	}
+	if rt := PickRuntime(runtimes, Requirement{Min: 8, Max: 17}); rt == nil || rt.Path != "/usr/jdk17" {
+		t.Errorf("Max 17 picked %+v", rt)
+	}
+	if rt := PickRuntime(runtimes, Requirement{Min: 8, Max: 8}); rt != nil {
+		t.Errorf("Java 8 picked %+v, want none", rt)
+	}
+}
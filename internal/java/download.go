@@ -5,6 +5,8 @@ import (
 	"archive/zip"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,16 +15,19 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/aayushdutt/mctui/internal/download"
 	"github.com/hashicorp/go-retryablehttp"
 )
 
 // adoptiumBaseURL is the production Adoptium API host.
 const adoptiumBaseURL = "https://api.adoptium.net"
 
-// Downloader handles downloading Java runtimes from Adoptium
+// Downloader handles downloading Java runtimes, from Mojang's runtime manifest
+// or Adoptium
 type Downloader struct {
-	client  *retryablehttp.Client
-	baseURL string
+	client             *retryablehttp.Client
+	baseURL            string // Adoptium API
+	runtimeManifestURL string // Mojang's all-platform runtime index
 }
 
 // NewDownloader creates a new Java downloader
@@ -39,8 +44,9 @@ func NewDownloaderWithBaseURL(baseURL string) *Downloader {
 	client := retryablehttp.NewClient()
 	client.Logger = nil // specific logger can be added if needed
 	return &Downloader{
-		client:  client,
-		baseURL: baseURL,
+		client:             client,
+		baseURL:            baseURL,
+		runtimeManifestURL: mojangRuntimeManifestURL,
 	}
 }
 
@@ -49,7 +55,7 @@ func NewDownloaderWithBaseURL(baseURL string) *Downloader {
 func (d *Downloader) DownloadRuntime(ctx context.Context, version int, destBaseDir string, progressCb func(string)) (string, error) {
 	// 1. Resolve URL
 	progressCb(fmt.Sprintf("Resolving Java %d...", version))
-	downloadURL, filename, err := d.resolveAdoptiumURL(ctx, version)
+	pkg, err := d.resolveAdoptiumPackage(ctx, version)
 	if err != nil {
 		return "", fmt.Errorf("resolving java version: %w", err)
 	}
@@ -60,11 +66,11 @@ func (d *Downloader) DownloadRuntime(ctx context.Context, version int, destBaseD
 		return "", fmt.Errorf("creating dir: %w", err)
 	}
 
-	downloadPath := filepath.Join(versionDir, filename)
+	downloadPath := filepath.Join(versionDir, pkg.Name)
 
-	// 3. Download
+	// 3. Download, verifying the published checksum before anything is extracted
 	progressCb(fmt.Sprintf("Downloading Java %d...", version))
-	if err := d.downloadFile(ctx, downloadURL, downloadPath); err != nil {
+	if err := d.downloadFile(ctx, pkg, downloadPath); err != nil {
 		return "", fmt.Errorf("downloading file: %w", err)
 	}
 	defer os.Remove(downloadPath) // Clean up archive
@@ -79,7 +85,21 @@ func (d *Downloader) DownloadRuntime(ctx context.Context, version int, destBaseD
 	return d.FindJavaExecutable(versionDir)
 }
 
-func (d *Downloader) resolveAdoptiumURL(ctx context.Context, version int) (string, string, error) {
+// adoptiumRelease is one entry of Adoptium's feature_releases response.
+type adoptiumRelease struct {
+	Binaries []struct {
+		Package adoptiumPackage `json:"package"`
+	} `json:"binaries"`
+}
+
+// adoptiumPackage is the archive of an Adoptium release.
+type adoptiumPackage struct {
+	Link     string `json:"link"`
+	Name     string `json:"name"`
+	Checksum string `json:"checksum"` // SHA-256 of the archive, hex
+}
+
+func (d *Downloader) resolveAdoptiumPackage(ctx context.Context, version int) (adoptiumPackage, error) {
 	osName := runtime.GOOS
 	if osName == "darwin" {
 		osName = "mac"
@@ -96,65 +116,55 @@ func (d *Downloader) resolveAdoptiumURL(ctx context.Context, version int) (strin
 
 	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return "", "", err
+		return adoptiumPackage{}, err
 	}
 
 	resp, err := d.client.Do(req)
 	if err != nil {
-		return "", "", err
+		return adoptiumPackage{}, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return "", "", fmt.Errorf("api returned status %d", resp.StatusCode)
+		return adoptiumPackage{}, fmt.Errorf("api returned status %d", resp.StatusCode)
 	}
 
-	var releases []interface{}
+	var releases []adoptiumRelease
 	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
-		return "", "", err
+		return adoptiumPackage{}, err
 	}
 
 	if len(releases) == 0 {
-		return "", "", fmt.Errorf("no releases found for java %d on %s/%s", version, osName, arch)
+		return adoptiumPackage{}, fmt.Errorf("no releases found for java %d on %s/%s", version, osName, arch)
 	}
-
-	// Extract URL and Filename
-	// Structure: [ { binaries: [ { package: { link: "...", name: "..." } } ] } ]
-	rel := releases[0].(map[string]interface{})
-	binaries := rel["binaries"].([]interface{})
-	if len(binaries) == 0 {
-		return "", "", fmt.Errorf("no binaries in release")
+	if len(releases[0].Binaries) == 0 {
+		return adoptiumPackage{}, fmt.Errorf("no binaries in release")
 	}
-	binary := binaries[0].(map[string]interface{})
-	pkg := binary["package"].(map[string]interface{})
-
-	link, _ := pkg["link"].(string)
-	name, _ := pkg["name"].(string)
-
-	return link, name, nil
-}
-
-func (d *Downloader) downloadFile(ctx context.Context, url, dest string) error {
-	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return err
+	pkg := releases[0].Binaries[0].Package
+	if pkg.Link == "" || pkg.Name == "" {
+		return adoptiumPackage{}, fmt.Errorf("release package has no download link")
 	}
-
-	resp, err := d.client.Do(req)
-	if err != nil {
-		return err
+	// Without the published checksum the archive couldn't be verified before it
+	// is extracted, so don't install it at all.
+	if sum, err := hex.DecodeString(pkg.Checksum); err != nil || len(sum) != sha256.Size {
+		return adoptiumPackage{}, fmt.Errorf("release package %s has no valid SHA-256 checksum", pkg.Name)
 	}
-	defer resp.Body.Close()
+	return pkg, nil
+}
 
-	f, err := os.Create(dest)
+// downloadFile fetches the runtime archive through the download manager, so it
+// resumes after dropped connections, honors the mirror table and checks the
+// archive against Adoptium's SHA-256.
+func (d *Downloader) downloadFile(ctx context.Context, pkg adoptiumPackage, dest string) error {
+	result, err := download.NewManager(1).Download(ctx, []download.Item{{
+		URL:    pkg.Link,
+		Path:   dest,
+		SHA256: pkg.Checksum,
+	}}, nil)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
-
-	// Just a simple copy for now, could add progress tracking wrapper if needed
-	_, err = io.Copy(f, resp.Body)
-	return err
+	return result.Err()
 }
 
 func (d *Downloader) extractArchive(src, dest string) error {
@@ -5,11 +5,18 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/aayushdutt/mctui/internal/download"
 )
 
 // newTestDownloader points the downloader at srvURL and disables retryablehttp's
@@ -27,20 +34,23 @@ func TestResolveAdoptiumURL_ok(t *testing.T) {
 			t.Errorf("path = %q, want adoptium feature_releases path", r.URL.Path)
 		}
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`[{"binaries":[{"package":{"link":"https://cdn/jre.tar.gz","name":"jre.tar.gz"}}]}]`))
+		_, _ = w.Write([]byte(`[{"binaries":[{"package":{"link":"https://cdn/jre.tar.gz","name":"jre.tar.gz","checksum":"` + strings.Repeat("ab", 32) + `"}}]}]`))
 	}))
 	defer ts.Close()
 
 	d := newTestDownloader(ts.URL)
-	link, name, err := d.resolveAdoptiumURL(context.Background(), 21)
+	pkg, err := d.resolveAdoptiumPackage(context.Background(), 21)
 	if err != nil {
-		t.Fatalf("resolveAdoptiumURL: %v", err)
+		t.Fatalf("resolveAdoptiumPackage: %v", err)
+	}
+	if pkg.Link != "https://cdn/jre.tar.gz" {
+		t.Errorf("link = %q, want https://cdn/jre.tar.gz", pkg.Link)
 	}
-	if link != "https://cdn/jre.tar.gz" {
-		t.Errorf("link = %q, want https://cdn/jre.tar.gz", link)
+	if pkg.Name != "jre.tar.gz" {
+		t.Errorf("name = %q, want jre.tar.gz", pkg.Name)
 	}
-	if name != "jre.tar.gz" {
-		t.Errorf("name = %q, want jre.tar.gz", name)
+	if pkg.Checksum != strings.Repeat("ab", 32) {
+		t.Errorf("checksum = %q, want the published SHA-256", pkg.Checksum)
 	}
 }
 
@@ -52,6 +62,8 @@ func TestResolveAdoptiumURL_errors(t *testing.T) {
 	}{
 		{name: "non-200", status: 500, body: ""},
 		{name: "empty releases", status: 200, body: `[]`},
+		{name: "missing checksum", status: 200, body: `[{"binaries":[{"package":{"link":"https://cdn/jre.tar.gz","name":"jre.tar.gz"}}]}]`},
+		{name: "malformed checksum", status: 200, body: `[{"binaries":[{"package":{"link":"https://cdn/jre.tar.gz","name":"jre.tar.gz","checksum":"abc123"}}]}]`},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -62,7 +74,7 @@ func TestResolveAdoptiumURL_errors(t *testing.T) {
 			defer ts.Close()
 
 			d := newTestDownloader(ts.URL)
-			if _, _, err := d.resolveAdoptiumURL(context.Background(), 21); err == nil {
+			if _, err := d.resolveAdoptiumPackage(context.Background(), 21); err == nil {
 				t.Fatal("expected error, got nil")
 			}
 		})
@@ -89,8 +101,10 @@ func TestDownloadRuntime_endToEnd(t *testing.T) {
 	mux.HandleFunc("/archive/jre.tar.gz", func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write(archive)
 	})
+	sum := sha256.Sum256(archive)
 	mux.HandleFunc("/v3/assets/feature_releases/", func(w http.ResponseWriter, r *http.Request) {
-		_, _ = w.Write([]byte(fmt.Sprintf(`[{"binaries":[{"package":{"link":%q,"name":"jre.tar.gz"}}]}]`, srv.URL+"/archive/jre.tar.gz")))
+		_, _ = w.Write([]byte(fmt.Sprintf(`[{"binaries":[{"package":{"link":%q,"name":"jre.tar.gz","checksum":%q}}]}]`,
+			srv.URL+"/archive/jre.tar.gz", hex.EncodeToString(sum[:]))))
 	})
 
 	d := newTestDownloader(srv.URL)
@@ -103,6 +117,32 @@ func TestDownloadRuntime_endToEnd(t *testing.T) {
 	}
 }
 
+func TestDownloadRuntime_checksumMismatch(t *testing.T) {
+	archive := makeTarGz(t, map[string]string{"jdk-21.0.4/bin/java": "#!/bin/sh\n"})
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	mux.HandleFunc("/archive/jre.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(archive)
+	})
+	mux.HandleFunc("/v3/assets/feature_releases/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(fmt.Sprintf(`[{"binaries":[{"package":{"link":%q,"name":"jre.tar.gz","checksum":%q}}]}]`,
+			srv.URL+"/archive/jre.tar.gz", strings.Repeat("0", 64))))
+	})
+
+	dest := t.TempDir()
+	d := newTestDownloader(srv.URL)
+	_, err := d.DownloadRuntime(context.Background(), 21, dest, func(string) {})
+	var mismatch *download.HashMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Algorithm != "sha256" {
+		t.Fatalf("DownloadRuntime error = %v, want a sha256 mismatch", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "21", "bin")); !os.IsNotExist(err) {
+		t.Error("a runtime failing its checksum must not be extracted")
+	}
+}
+
 func makeTarGz(t *testing.T, files map[string]string) []byte {
 	t.Helper()
 	var buf bytes.Buffer
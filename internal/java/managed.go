@@ -0,0 +1,206 @@
+package java
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// mojangComponents maps Java major versions to the Mojang runtime component
+// that ships them.
+var mojangComponents = map[int]string{
+	8:  "jre-legacy",
+	16: "java-runtime-alpha",
+	17: "java-runtime-gamma",
+	21: "java-runtime-delta",
+	25: "java-runtime-epsilon",
+}
+
+// MojangComponent is the Mojang runtime component for a Java major version, or
+// "" when Mojang ships none and Adoptium provides it instead.
+func MojangComponent(major int) string {
+	return mojangComponents[major]
+}
+
+// ManagedDir is where mctui installs the runtimes it downloads, one directory
+// per Mojang component or Adoptium major version.
+func ManagedDir(dataDir string) string {
+	return filepath.Join(dataDir, "java")
+}
+
+// legacyManagedDir is where managed runtimes lived before they moved into the
+// data directory.
+func legacyManagedDir() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "mctui", "java")
+}
+
+// RuntimeMoves maps each runtime directory MigrateLegacyRuntimes moved to its
+// new location.
+type RuntimeMoves map[string]string
+
+// Rewrite returns path with the directory of a moved runtime swapped for its new
+// one, and whether it changed. Paths into runtimes left behind stay as they are.
+func (m RuntimeMoves) Rewrite(path string) (string, bool) {
+	if path == "" {
+		return path, false
+	}
+	for from, to := range m {
+		if rel, err := filepath.Rel(from, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.Join(to, rel), true
+		}
+	}
+	return path, false
+}
+
+// MigrateLegacyRuntimes moves runtimes from the old per-user config location
+// into ManagedDir(dataDir) and reports where each went, so Java paths pointing
+// into them can be rewritten. A runtime that can't be moved (another
+// filesystem, or one of the same name already there) is left behind and
+// downloaded again when it's needed.
+func MigrateLegacyRuntimes(dataDir string) (RuntimeMoves, error) {
+	legacy := legacyManagedDir()
+	dest := ManagedDir(dataDir)
+	if legacy == "" || filepath.Clean(legacy) == filepath.Clean(dest) {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(legacy)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return nil, err
+	}
+
+	moves := RuntimeMoves{}
+	var errs []error
+	for _, e := range entries {
+		from, target := filepath.Join(legacy, e.Name()), filepath.Join(dest, e.Name())
+		if _, err := os.Lstat(target); err == nil {
+			continue
+		}
+		if err := os.Rename(from, target); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		moves[from] = target
+	}
+	_ = os.Remove(legacy) // only succeeds once everything moved
+	return moves, errors.Join(errs...)
+}
+
+// Runtime is a Java installation mctui can run a game with: one it manages, or
+// one found on the system.
+type Runtime struct {
+	Installation
+	Managed bool   // installed by mctui under ManagedDir
+	Name    string // managed directory name: a Mojang component or major version
+	Dir     string // managed install directory
+}
+
+// Label is a short description, e.g. "Java 21.0.7 (Microsoft)".
+func (r Runtime) Label() string {
+	version := r.Version
+	if version == "" {
+		version = strconv.Itoa(r.MajorVersion)
+	}
+	vendor := r.Vendor
+	if vendor == "" {
+		vendor = "Unknown"
+	}
+	return fmt.Sprintf("Java %s (%s)", version, vendor)
+}
+
+// ListManaged probes every runtime installed under ManagedDir(dataDir). A
+// runtime whose java won't run is still listed, with what its directory tells,
+// so it can be deleted.
+func ListManaged(dataDir string) []Runtime {
+	base := ManagedDir(dataDir)
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		return nil
+	}
+
+	d := NewDownloader()
+	var runtimes []Runtime
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		dir := filepath.Join(base, e.Name())
+		r := Runtime{Managed: true, Name: e.Name(), Dir: dir}
+		exe, err := d.FindJavaExecutable(dir)
+		if err == nil {
+			if inst := (&Detector{}).checkJava(exe); inst != nil {
+				r.Installation = *inst
+			}
+			// Keep the path the launcher records, not the resolved one.
+			r.Path = exe
+		}
+		if r.Version == "" {
+			r.Version = managedVersion(dir, e.Name())
+			r.MajorVersion = parseMajorVersion(r.Version)
+			r.Is64Bit = true
+		}
+		runtimes = append(runtimes, r)
+	}
+	return runtimes
+}
+
+// managedVersion reads a managed runtime's recorded version, falling back to
+// its directory name when that is a major version.
+func managedVersion(dir, name string) string {
+	if v, err := os.ReadFile(filepath.Join(dir, RuntimeVersionFile)); err == nil {
+		return strings.TrimSpace(string(v))
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		return name
+	}
+	return ""
+}
+
+// FindRuntimes lists the managed runtimes, then every system installation the
+// detector finds that isn't one of them.
+func FindRuntimes(dataDir string) []Runtime {
+	runtimes := ListManaged(dataDir)
+	seen := make(map[string]bool, len(runtimes))
+	for _, r := range runtimes {
+		seen[realPath(r.Path)] = true
+	}
+	for _, inst := range NewDetector().FindAll() {
+		if seen[realPath(inst.Path)] {
+			continue
+		}
+		seen[realPath(inst.Path)] = true
+		runtimes = append(runtimes, Runtime{Installation: inst})
+	}
+	return runtimes
+}
+
+func realPath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return path
+}
+
+// RemoveManaged deletes the managed runtime named name.
+func RemoveManaged(dataDir, name string) error {
+	if name == "" || name != filepath.Base(name) || !filepath.IsLocal(name) {
+		return fmt.Errorf("invalid runtime name %q", name)
+	}
+	dir := filepath.Join(ManagedDir(dataDir), name)
+	if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
@@ -0,0 +1,114 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// fakeRuntime installs a java under base/name that prints version as
+// `java -version` would.
+func fakeRuntime(t *testing.T, base, name, version string) string {
+	t.Helper()
+	bin := filepath.Join(base, name, "bin")
+	if err := os.MkdirAll(bin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	exe := filepath.Join(bin, "java")
+	script := "#!/bin/sh\necho 'openjdk version \"" + version + "\" 2025-04-15 LTS' >&2\necho 'OpenJDK 64-Bit Server VM Microsoft-11484014 (build 21.0.7+6-LTS, mixed mode)' >&2\n"
+	if err := os.WriteFile(exe, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return exe
+}
+
+func TestListManaged(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake java is a shell script")
+	}
+	dataDir := t.TempDir()
+	exe := fakeRuntime(t, ManagedDir(dataDir), "java-runtime-delta", "21.0.7")
+	// A runtime whose java is gone still lists, so it can be deleted.
+	broken := filepath.Join(ManagedDir(dataDir), "java-runtime-gamma")
+	os.MkdirAll(broken, 0755)
+	os.WriteFile(filepath.Join(broken, RuntimeVersionFile), []byte("17.0.8"), 0644)
+
+	runtimes := ListManaged(dataDir)
+	if len(runtimes) != 2 {
+		t.Fatalf("got %d runtimes, want 2: %+v", len(runtimes), runtimes)
+	}
+	got := map[string]Runtime{}
+	for _, r := range runtimes {
+		got[r.Name] = r
+	}
+
+	delta := got["java-runtime-delta"]
+	if !delta.Managed || delta.Path != exe || delta.MajorVersion != 21 || delta.Vendor != "Microsoft" || !delta.Is64Bit {
+		t.Errorf("delta = %+v", delta)
+	}
+	if delta.Label() != "Java 21.0.7 (Microsoft)" {
+		t.Errorf("Label = %q", delta.Label())
+	}
+	gamma := got["java-runtime-gamma"]
+	if gamma.Path != "" || gamma.Version != "17.0.8" || gamma.MajorVersion != 17 {
+		t.Errorf("broken runtime = %+v, want version from %s and no path", gamma, RuntimeVersionFile)
+	}
+}
+
+func TestRemoveManaged(t *testing.T) {
+	dataDir := t.TempDir()
+	dir := filepath.Join(ManagedDir(dataDir), "17")
+	os.MkdirAll(filepath.Join(dir, "bin"), 0755)
+
+	for _, name := range []string{"", "..", "../17", "a/b"} {
+		if err := RemoveManaged(dataDir, name); err == nil {
+			t.Errorf("RemoveManaged(%q) should be rejected", name)
+		}
+	}
+	if err := RemoveManaged(dataDir, "17"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("runtime dir still there: %v", err)
+	}
+}
+
+func TestMigrateLegacyRuntimes(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("relies on XDG_CONFIG_HOME")
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	legacy := legacyManagedDir()
+	dataDir := t.TempDir()
+	os.MkdirAll(filepath.Join(legacy, "17", "bin"), 0755)
+	os.MkdirAll(filepath.Join(legacy, "21"), 0755)
+	os.WriteFile(filepath.Join(legacy, "21", "old"), nil, 0644)
+	os.MkdirAll(filepath.Join(ManagedDir(dataDir), "21"), 0755)
+
+	moves, err := MigrateLegacyRuntimes(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(ManagedDir(dataDir), "17", "bin")); err != nil {
+		t.Errorf("17 not moved: %v", err)
+	}
+	// One already in the data directory wins; the old copy stays behind.
+	if _, err := os.Stat(filepath.Join(ManagedDir(dataDir), "21", "old")); !os.IsNotExist(err) {
+		t.Error("an existing runtime should not be replaced")
+	}
+	if _, err := os.Stat(filepath.Join(legacy, "21", "old")); err != nil {
+		t.Errorf("unmoved runtime should stay in place: %v", err)
+	}
+
+	// Paths into a moved runtime follow it; others are left alone.
+	oldJava := filepath.Join(legacy, "17", "bin", "java")
+	if got, ok := moves.Rewrite(oldJava); !ok || got != filepath.Join(ManagedDir(dataDir), "17", "bin", "java") {
+		t.Errorf("Rewrite(%s) = %s, %v", oldJava, got, ok)
+	}
+	for _, path := range []string{filepath.Join(legacy, "21", "bin", "java"), filepath.Join(legacy, "170", "bin", "java"), "/usr/bin/java", ""} {
+		if got, ok := moves.Rewrite(path); ok || got != path {
+			t.Errorf("Rewrite(%s) = %s, %v; want it unchanged", path, got, ok)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package java
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/aayushdutt/mctui/internal/download"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// mojangRuntimeManifestURL lists every Java runtime Mojang ships, by platform
+// and component.
+const mojangRuntimeManifestURL = "https://launchermeta.mojang.com/v1/products/java-runtime/2ec0cc96c44e5a76b9c8b7c39df7210883d12871/all.json"
+
+// RuntimeManifestFile is the per-file manifest kept in an installed runtime's
+// directory, so it can be verified again without the network.
+const RuntimeManifestFile = ".mctui-manifest.json"
+
+// RuntimeVersionFile holds the installed runtime's version name.
+const RuntimeVersionFile = ".version"
+
+// ErrRuntimeUnavailable means Mojang ships no runtime for the component on this platform.
+var ErrRuntimeUnavailable = errors.New("no Mojang Java runtime for this platform")
+
+// runtimeIndex is Mojang's all.json: platform -> component -> releases.
+type runtimeIndex map[string]map[string][]runtimeRelease
+
+type runtimeRelease struct {
+	Manifest struct {
+		SHA1 string `json:"sha1"`
+		Size int64  `json:"size"`
+		URL  string `json:"url"`
+	} `json:"manifest"`
+	Version struct {
+		Name     string `json:"name"`
+		Released string `json:"released"`
+	} `json:"version"`
+}
+
+// runtimeFiles is the per-file manifest of one runtime release.
+type runtimeFiles struct {
+	Files map[string]runtimeFile `json:"files"`
+}
+
+type runtimeFile struct {
+	Type       string `json:"type"` // "file", "directory" or "link"
+	Executable bool   `json:"executable"`
+	Target     string `json:"target"` // for links
+	Downloads  struct {
+		Raw *struct {
+			SHA1 string `json:"sha1"`
+			Size int64  `json:"size"`
+			URL  string `json:"url"`
+		} `json:"raw"`
+	} `json:"downloads"`
+}
+
+// mojangPlatform is Mojang's name for this platform in the runtime index, or
+// "" when it ships nothing for it.
+func mojangPlatform(goos, goarch string) string {
+	switch goos + "/" + goarch {
+	case "linux/amd64":
+		return "linux"
+	case "linux/386":
+		return "linux-i386"
+	case "darwin/amd64":
+		return "mac-os"
+	case "darwin/arm64":
+		return "mac-os-arm64"
+	case "windows/amd64":
+		return "windows-x64"
+	case "windows/386":
+		return "windows-x86"
+	case "windows/arm64":
+		return "windows-arm64"
+	}
+	return ""
+}
+
+// Install provides the Java runtime a version asks for under destBaseDir: the
+// Mojang runtime for component in destBaseDir/<component> when Mojang ships one
+// for this platform, otherwise an Adoptium JRE for majorVersion. Returns the
+// path to the java executable.
+func (d *Downloader) Install(ctx context.Context, component string, majorVersion int, destBaseDir string, progressCb func(string)) (string, error) {
+	if component != "" {
+		exe, err := d.DownloadMojangRuntime(ctx, component, destBaseDir, progressCb)
+		if !errors.Is(err, ErrRuntimeUnavailable) {
+			return exe, err
+		}
+	}
+	return d.DownloadRuntime(ctx, majorVersion, destBaseDir, progressCb)
+}
+
+// DownloadMojangRuntime installs Mojang's runtime for component into
+// destBaseDir/<component>, checking every file's SHA-1. Files already in place
+// and intact are kept, so running it again repairs an install incrementally.
+// Returns the path to the java executable, or ErrRuntimeUnavailable.
+func (d *Downloader) DownloadMojangRuntime(ctx context.Context, component, destBaseDir string, progressCb func(string)) (string, error) {
+	progressCb(fmt.Sprintf("Resolving Java runtime %s...", component))
+	release, err := d.resolveMojangRuntime(ctx, component)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(destBaseDir, component)
+	files, err := d.fetchRuntimeFiles(ctx, release, dir)
+	if err != nil {
+		return "", fmt.Errorf("runtime manifest: %w", err)
+	}
+
+	progressCb(fmt.Sprintf("Downloading Java runtime %s (%s)...", component, release.Version.Name))
+	if err := installRuntimeFiles(ctx, files, dir, func(p download.Progress) {
+		progressCb(fmt.Sprintf("Downloading Java runtime %s: %d/%d files, %s",
+			component, p.CompletedItems, p.TotalItems, download.FormatSpeed(p.Speed)))
+	}); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, RuntimeVersionFile), []byte(release.Version.Name), 0644); err != nil {
+		return "", err
+	}
+
+	return d.FindJavaExecutable(dir)
+}
+
+// resolveMojangRuntime finds the current release of component for this platform.
+func (d *Downloader) resolveMojangRuntime(ctx context.Context, component string) (runtimeRelease, error) {
+	platform := mojangPlatform(runtime.GOOS, runtime.GOARCH)
+	if platform == "" {
+		return runtimeRelease{}, fmt.Errorf("%w (%s/%s)", ErrRuntimeUnavailable, runtime.GOOS, runtime.GOARCH)
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", d.runtimeManifestURL, nil)
+	if err != nil {
+		return runtimeRelease{}, err
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return runtimeRelease{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return runtimeRelease{}, fmt.Errorf("runtime index returned status %d", resp.StatusCode)
+	}
+
+	var index runtimeIndex
+	if err := json.NewDecoder(resp.Body).Decode(&index); err != nil {
+		return runtimeRelease{}, fmt.Errorf("decoding runtime index: %w", err)
+	}
+	releases := index[platform][component]
+	if len(releases) == 0 || releases[0].Manifest.URL == "" {
+		return runtimeRelease{}, fmt.Errorf("%w (%s on %s)", ErrRuntimeUnavailable, component, platform)
+	}
+	return releases[0], nil
+}
+
+// fetchRuntimeFiles downloads release's per-file manifest into dir, checking
+// its SHA-1, and parses it.
+func (d *Downloader) fetchRuntimeFiles(ctx context.Context, release runtimeRelease, dir string) (*runtimeFiles, error) {
+	path := filepath.Join(dir, RuntimeManifestFile)
+	result, err := download.NewManager(1).Download(ctx, []download.Item{{
+		URL:  release.Manifest.URL,
+		Path: path,
+		SHA1: release.Manifest.SHA1,
+		Size: release.Manifest.Size,
+	}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+	return loadRuntimeFiles(path)
+}
+
+func loadRuntimeFiles(path string) (*runtimeFiles, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var files runtimeFiles
+	if err := json.Unmarshal(data, &files); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", filepath.Base(path), err)
+	}
+	return &files, nil
+}
+
+// installRuntimeFiles lays out a runtime in dir: directories first, then the
+// files through the download manager, then executable bits and symlinks.
+func installRuntimeFiles(ctx context.Context, files *runtimeFiles, dir string, progress func(download.Progress)) error {
+	var (
+		items       []download.Item
+		executables []string
+		links       = map[string]string{}
+	)
+	for name, f := range files.Files {
+		if !filepath.IsLocal(filepath.FromSlash(name)) {
+			return fmt.Errorf("runtime manifest: unsafe path %q", name)
+		}
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		switch f.Type {
+		case "directory":
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+		case "file":
+			if f.Downloads.Raw == nil {
+				return fmt.Errorf("runtime manifest: no download for %s", name)
+			}
+			items = append(items, download.Item{
+				URL:  f.Downloads.Raw.URL,
+				Path: path,
+				SHA1: f.Downloads.Raw.SHA1,
+				Size: f.Downloads.Raw.Size,
+			})
+			if f.Executable {
+				executables = append(executables, path)
+			}
+		case "link":
+			if !linkStaysInside(dir, path, f.Target) {
+				return fmt.Errorf("runtime manifest: link %q points outside the runtime", name)
+			}
+			links[path] = f.Target
+		}
+	}
+
+	progressChan := make(chan download.Progress, 10)
+	forwarded := make(chan struct{})
+	go func() {
+		defer close(forwarded)
+		for p := range progressChan {
+			progress(p)
+		}
+	}()
+	result, err := download.NewManager(8).Download(ctx, items, progressChan)
+	close(progressChan)
+	<-forwarded
+	if err != nil {
+		return err
+	}
+	if err := result.Err(); err != nil {
+		return err
+	}
+
+	if runtime.GOOS != "windows" {
+		for _, path := range executables {
+			if err := os.Chmod(path, 0755); err != nil {
+				return err
+			}
+		}
+	}
+	for path, target := range links {
+		if err := restoreLink(path, target); err != nil {
+			return fmt.Errorf("linking %s: %w", filepath.Base(path), err)
+		}
+	}
+	return nil
+}
+
+// linkStaysInside reports whether a symlink at path to target resolves inside
+// dir. Manifest targets are relative to the link; an absolute one, or one that
+// climbs out of dir like "../../..", could point anywhere.
+func linkStaysInside(dir, path, target string) bool {
+	if target == "" || filepath.IsAbs(target) {
+		return false
+	}
+	rel, err := filepath.Rel(dir, filepath.Join(filepath.Dir(path), filepath.FromSlash(target)))
+	return err == nil && filepath.IsLocal(rel)
+}
+
+// restoreLink makes path a symlink to target, replacing whatever is there
+// unless it already is that link.
+func restoreLink(path, target string) error {
+	if current, err := os.Readlink(path); err == nil && current == target {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Symlink(target, path)
+}
@@ -0,0 +1,167 @@
+package java
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func sha1Hex(b []byte) string {
+	sum := sha1.Sum(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// runtimeServer serves a runtime index offering component for this platform,
+// its per-file manifest, and the files. Requests for files are counted.
+func runtimeServer(t *testing.T, component string, files map[string]string) (*Downloader, *atomic.Int32) {
+	t.Helper()
+	platform := mojangPlatform(runtime.GOOS, runtime.GOARCH)
+	if platform == "" {
+		t.Skipf("Mojang ships no runtime for %s/%s", runtime.GOOS, runtime.GOARCH)
+	}
+
+	var fileHits atomic.Int32
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	entries := map[string]any{
+		"bin":     map[string]any{"type": "directory"},
+		"lib/jli": map[string]any{"type": "link", "target": "libjli.so"},
+	}
+	for name, content := range files {
+		entries[name] = map[string]any{
+			"type":       "file",
+			"executable": name == "bin/java",
+			"downloads": map[string]any{"raw": map[string]any{
+				"url": srv.URL + "/files/" + name, "sha1": sha1Hex([]byte(content)), "size": len(content),
+			}},
+		}
+		mux.HandleFunc("/files/"+name, func(w http.ResponseWriter, r *http.Request) {
+			fileHits.Add(1)
+			w.Write([]byte(content))
+		})
+	}
+	manifest, _ := json.Marshal(map[string]any{"files": entries})
+	mux.HandleFunc("/manifest.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(manifest)
+	})
+	mux.HandleFunc("/all.json", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{platform: map[string]any{component: []any{map[string]any{
+			"manifest": map[string]any{"url": srv.URL + "/manifest.json", "sha1": sha1Hex(manifest), "size": len(manifest)},
+			"version":  map[string]any{"name": "21.0.7", "released": "2025-04-15T00:00:00+00:00"},
+		}}}})
+	})
+
+	d := newTestDownloader(srv.URL)
+	d.runtimeManifestURL = srv.URL + "/all.json"
+	return d, &fileHits
+}
+
+func TestDownloadMojangRuntime(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks and executable bits are Unix-only")
+	}
+	files := map[string]string{"bin/java": "#!/bin/sh\n", "lib/libjli.so": "elf"}
+	d, hits := runtimeServer(t, "java-runtime-delta", files)
+	base := t.TempDir()
+
+	exe, err := d.DownloadMojangRuntime(context.Background(), "java-runtime-delta", base, func(string) {})
+	if err != nil {
+		t.Fatalf("DownloadMojangRuntime: %v", err)
+	}
+	dir := filepath.Join(base, "java-runtime-delta")
+	if exe != filepath.Join(dir, "bin", "java") {
+		t.Errorf("exe = %q, want bin/java under %s", exe, dir)
+	}
+	if info, err := os.Stat(exe); err != nil || info.Mode()&0o111 == 0 {
+		t.Errorf("java should be executable: %v %v", info, err)
+	}
+	if target, err := os.Readlink(filepath.Join(dir, "lib", "jli")); err != nil || target != "libjli.so" {
+		t.Errorf("link = %q, %v; want libjli.so", target, err)
+	}
+	if v, _ := os.ReadFile(filepath.Join(dir, RuntimeVersionFile)); string(v) != "21.0.7" {
+		t.Errorf("version file = %q, want 21.0.7", v)
+	}
+
+	// Damage one file and drop the link: a second run repairs just those.
+	os.WriteFile(filepath.Join(dir, "lib", "libjli.so"), []byte("junk"), 0644)
+	os.Remove(filepath.Join(dir, "lib", "jli"))
+	os.Chmod(exe, 0644)
+	hits.Store(0)
+	if _, err := d.DownloadMojangRuntime(context.Background(), "java-runtime-delta", base, func(string) {}); err != nil {
+		t.Fatalf("repair: %v", err)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("repair fetched %d files, want only the damaged one", hits.Load())
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, "lib", "libjli.so")); string(got) != "elf" {
+		t.Errorf("libjli.so = %q, want it restored", got)
+	}
+	if info, _ := os.Stat(exe); info.Mode()&0o111 == 0 {
+		t.Error("executable bit should be restored")
+	}
+	if _, err := os.Readlink(filepath.Join(dir, "lib", "jli")); err != nil {
+		t.Errorf("link should be restored: %v", err)
+	}
+}
+
+func TestDownloadMojangRuntime_unknownComponent(t *testing.T) {
+	d, _ := runtimeServer(t, "java-runtime-delta", map[string]string{"bin/java": "x"})
+	_, err := d.DownloadMojangRuntime(context.Background(), "jre-legacy", t.TempDir(), func(string) {})
+	if !errors.Is(err, ErrRuntimeUnavailable) {
+		t.Fatalf("err = %v, want ErrRuntimeUnavailable", err)
+	}
+
+	// Install falls back to Adoptium, which this server doesn't serve.
+	_, err = d.Install(context.Background(), "jre-legacy", 8, t.TempDir(), func(string) {})
+	if err == nil || errors.Is(err, ErrRuntimeUnavailable) || !strings.Contains(err.Error(), "resolving java version") {
+		t.Fatalf("Install err = %v, want the Adoptium fallback's error", err)
+	}
+}
+
+func TestInstallRuntimeFiles_rejectsEscapingPaths(t *testing.T) {
+	files := &runtimeFiles{Files: map[string]runtimeFile{"../escape": {Type: "directory"}}}
+	if err := installRuntimeFiles(context.Background(), files, t.TempDir(), nil); err == nil {
+		t.Fatal("a path outside the runtime directory should be rejected")
+	}
+}
+
+func TestInstallRuntimeFiles_rejectsEscapingLinks(t *testing.T) {
+	for _, target := range []string{"../../../..", "../../etc/passwd", "/usr/bin/java", ""} {
+		files := &runtimeFiles{Files: map[string]runtimeFile{"bin/java": {Type: "link", Target: target}}}
+		if err := installRuntimeFiles(context.Background(), files, t.TempDir(), nil); err == nil {
+			t.Errorf("link to %q should be rejected", target)
+		}
+	}
+}
+
+func TestLinkStaysInside(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "java-runtime-gamma")
+	tests := []struct {
+		name, target string
+		want         bool
+	}{
+		{"bin/java", "../lib/jspawnhelper", true},
+		{"legal/java.base/LICENSE", "../../LICENSE", true},
+		{"bin/java", "java.real", true},
+		{"bin/java", "../../other-runtime/bin/java", false},
+		{"bin/java", "../..", false},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(dir, filepath.FromSlash(tt.name))
+		if got := linkStaysInside(dir, path, tt.target); got != tt.want {
+			t.Errorf("linkStaysInside(%s -> %s) = %v, want %v", tt.name, tt.target, got, tt.want)
+		}
+	}
+}
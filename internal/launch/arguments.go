@@ -0,0 +1,251 @@
+package launch
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aayushdutt/mctui/internal/core"
+)
+
+// LauncherVersion is reported to the game as ${launcher_version}. main sets it from its build version.
+var LauncherVersion = "dev"
+
+const launcherName = "mctui"
+
+// ruleContext is the environment version JSON rules are evaluated against.
+type ruleContext struct {
+	OSName    string // Mojang OS name: osx, linux, windows
+	OSVersion string // host OS version, matched by os.version regex rules
+	Arch      string // Mojang arch name: x86, x86_64, arm64, arm32
+	Features  core.Features
+}
+
+// mojangArch maps a Go GOARCH value to the architecture names used by version JSON rules.
+func mojangArch(goarch string) string {
+	switch goarch {
+	case "386":
+		return "x86"
+	case "amd64":
+		return "x86_64"
+	case "arm":
+		return "arm32"
+	default:
+		return goarch
+	}
+}
+
+var (
+	hostOSVersionOnce sync.Once
+	hostOSVersionVal  string
+)
+
+// hostOSVersion returns the OS version string used for os.version rules (best effort; "" if unknown).
+func hostOSVersion() string {
+	hostOSVersionOnce.Do(func() {
+		hostOSVersionVal = detectOSVersion()
+	})
+	return hostOSVersionVal
+}
+
+var windowsVerRegex = regexp.MustCompile(`(\d+\.\d+)\.\d+`)
+
+func detectOSVersion() string {
+	switch runtime.GOOS {
+	case "linux":
+		data, err := os.ReadFile("/proc/sys/kernel/osrelease")
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(data))
+	case "darwin":
+		return commandOutput("sw_vers", "-productVersion")
+	case "windows":
+		// "Microsoft Windows [Version 10.0.22631.4317]" -> "10.0", which is what
+		// Java reports as os.version and what Mojang's "^10\\." rules expect.
+		if m := windowsVerRegex.FindStringSubmatch(commandOutput("cmd", "/c", "ver")); len(m) > 1 {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+func commandOutput(name string, args ...string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, name, args...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// rulesAllow evaluates Mojang rules: no rules means allowed; otherwise the last
+// matching rule decides, and nothing matching means disallowed.
+func rulesAllow(rules []core.Rule, rc ruleContext) bool {
+	if len(rules) == 0 {
+		return true
+	}
+	allowed := false
+	for _, rule := range rules {
+		if ruleMatches(rule, rc) {
+			allowed = rule.Action == "allow"
+		}
+	}
+	return allowed
+}
+
+// osVersionRegexps caches compiled os.version patterns; rules are evaluated for every library on
+// every launch and only a handful of distinct patterns exist. Invalid patterns are cached as nil.
+var osVersionRegexps sync.Map // pattern -> *regexp.Regexp
+
+func osVersionRegexp(pattern string) *regexp.Regexp {
+	if v, ok := osVersionRegexps.Load(pattern); ok {
+		return v.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		re = nil
+	}
+	osVersionRegexps.Store(pattern, re)
+	return re
+}
+
+func ruleMatches(rule core.Rule, rc ruleContext) bool {
+	if rule.OS != nil {
+		if rule.OS.Name != "" && rule.OS.Name != rc.OSName {
+			return false
+		}
+		if rule.OS.Arch != "" && rule.OS.Arch != rc.Arch {
+			return false
+		}
+		if rule.OS.Version != "" {
+			re := osVersionRegexp(rule.OS.Version)
+			if re == nil || !re.MatchString(rc.OSVersion) {
+				return false
+			}
+		}
+	}
+	if f := rule.Features; f != nil {
+		// Mojang only ever gates on features being true.
+		have := rc.Features
+		switch {
+		case f.IsDemoUser && !have.IsDemoUser,
+			f.HasCustomRes && !have.HasCustomRes,
+			f.HasQuickPlaysup && !have.HasQuickPlaysup,
+			f.IsQuickPlaySingle && !have.IsQuickPlaySingle,
+			f.IsQuickPlayMulti && !have.IsQuickPlayMulti,
+			f.IsQuickPlayRealms && !have.IsQuickPlayRealms:
+			return false
+		}
+	}
+	return true
+}
+
+// conditionalArg is the object form of a modern argument entry: {"rules": [...], "value": "x" | ["x", "y"]}.
+type conditionalArg struct {
+	Rules []core.Rule     `json:"rules"`
+	Value json.RawMessage `json:"value"`
+}
+
+// expandArguments flattens a modern argument list into raw (unsubstituted) strings,
+// keeping only entries whose rules allow them under rc. Order is preserved.
+func expandArguments(raw []interface{}, rc ruleContext) []string {
+	var out []string
+	for _, arg := range raw {
+		switch v := arg.(type) {
+		case string:
+			out = append(out, v)
+		case map[string]interface{}:
+			data, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			var ca conditionalArg
+			if err := json.Unmarshal(data, &ca); err != nil {
+				continue
+			}
+			if !rulesAllow(ca.Rules, rc) {
+				continue
+			}
+			out = append(out, argValues(ca.Value)...)
+		}
+	}
+	return out
+}
+
+func argValues(raw json.RawMessage) []string {
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return []string{single}
+	}
+	var many []string
+	if err := json.Unmarshal(raw, &many); err == nil {
+		return many
+	}
+	return nil
+}
+
+// legacyJVMArguments are the JVM flags pre-1.13 versions (minecraftArguments) expect the
+// launcher to supply, mirroring what the modern JSON spells out explicitly.
+func legacyJVMArguments(rc ruleContext) []string {
+	var args []string
+	switch rc.OSName {
+	case "osx":
+		args = append(args, "-XstartOnFirstThread")
+	case "windows":
+		args = append(args, "-XX:HeapDumpPath=MojangTricksIntelDriversForPerformance_javaw.exe_minecraft.exe.heapdump")
+	}
+	if rc.Arch == "x86" {
+		args = append(args, "-Xss1M")
+	}
+	return append(args,
+		"-Djava.library.path=${natives_directory}",
+		"-Dminecraft.launcher.brand=${launcher_name}",
+		"-Dminecraft.launcher.version=${launcher_version}",
+		"-cp", "${classpath}",
+	)
+}
+
+// substitute replaces every ${placeholder} in s using vars. Unknown placeholders are left as-is.
+// It makes a single pass over s, so substituted values are never expanded again.
+func substitute(s string, vars map[string]string) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(s[start+2:], '}')
+		if end < 0 {
+			break
+		}
+		end += start + 2
+		b.WriteString(s[:start])
+		if v, ok := vars[s[start+2:end]]; ok {
+			b.WriteString(v)
+		} else {
+			b.WriteString(s[start : end+1])
+		}
+		s = s[end+1:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+func substituteAll(args []string, vars map[string]string) []string {
+	out := make([]string, len(args))
+	for i, a := range args {
+		out[i] = substitute(a, vars)
+	}
+	return out
+}
@@ -0,0 +1,257 @@
+package launch
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aayushdutt/mctui/internal/config"
+	"github.com/aayushdutt/mctui/internal/core"
+)
+
+func TestRulesAllow(t *testing.T) {
+	linux := ruleContext{OSName: "linux", OSVersion: "6.1.0", Arch: "x86_64"}
+	win10 := ruleContext{OSName: "windows", OSVersion: "10.0", Arch: "x86"}
+
+	tests := []struct {
+		name  string
+		rules []core.Rule
+		rc    ruleContext
+		want  bool
+	}{
+		{"no rules", nil, linux, true},
+		{"allow all", []core.Rule{{Action: "allow"}}, linux, true},
+		{"allow other os", []core.Rule{{Action: "allow", OS: &core.OSRule{Name: "osx"}}}, linux, false},
+		{"allow then disallow os", []core.Rule{
+			{Action: "allow"},
+			{Action: "disallow", OS: &core.OSRule{Name: "linux"}},
+		}, linux, false},
+		{"arch match", []core.Rule{{Action: "allow", OS: &core.OSRule{Arch: "x86"}}}, win10, true},
+		{"arch mismatch", []core.Rule{{Action: "allow", OS: &core.OSRule{Arch: "x86"}}}, linux, false},
+		{"version regex", []core.Rule{{Action: "allow", OS: &core.OSRule{Name: "windows", Version: `^10\.`}}}, win10, true},
+		{"version regex miss", []core.Rule{{Action: "allow", OS: &core.OSRule{Name: "linux", Version: `^10\.`}}}, linux, false},
+		{"invalid version regex", []core.Rule{{Action: "allow", OS: &core.OSRule{Name: "windows", Version: `^10\.(`}}}, win10, false},
+		{"feature absent", []core.Rule{{Action: "allow", Features: &core.Features{IsDemoUser: true}}}, linux, false},
+		{"feature present", []core.Rule{{Action: "allow", Features: &core.Features{HasCustomRes: true}}},
+			ruleContext{OSName: "linux", Features: core.Features{HasCustomRes: true}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rulesAllow(tt.rules, tt.rc); got != tt.want {
+				t.Errorf("rulesAllow = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOSVersionRegexp_CompilesOnce(t *testing.T) {
+	first := osVersionRegexp(`^6\.`)
+	if first == nil || !first.MatchString("6.1.0") {
+		t.Fatalf("osVersionRegexp = %v", first)
+	}
+	if again := osVersionRegexp(`^6\.`); again != first {
+		t.Error("pattern was compiled again instead of reused")
+	}
+	if bad := osVersionRegexp(`(`); bad != nil {
+		t.Errorf("invalid pattern = %v, want nil", bad)
+	}
+}
+
+func TestExpandArguments(t *testing.T) {
+	var raw []interface{}
+	err := json.Unmarshal([]byte(`[
+		"--username", "${auth_player_name}",
+		{"rules": [{"action": "allow", "features": {"is_demo_user": true}}], "value": "--demo"},
+		{"rules": [{"action": "allow", "os": {"name": "osx"}}], "value": ["-XstartOnFirstThread"]},
+		{"rules": [{"action": "allow", "os": {"arch": "x86"}}], "value": "-Xss1M"}
+	]`), &raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := expandArguments(raw, ruleContext{OSName: "osx", Arch: "arm64"})
+	want := []string{"--username", "${auth_player_name}", "-XstartOnFirstThread"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expandArguments = %q, want %q", got, want)
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	vars := map[string]string{
+		"game_directory":   "/home/${user}/game",
+		"auth_player_name": "${game_directory}",
+		"user":             "steve",
+	}
+	tests := []struct{ in, want string }{
+		{"--gameDir ${game_directory}", "--gameDir /home/${user}/game"},
+		{"${auth_player_name}:${user}", "${game_directory}:steve"},
+		{"${unknown} ${user}", "${unknown} steve"},
+		{"unterminated ${user", "unterminated ${user"},
+		{"plain", "plain"},
+	}
+	for _, tt := range tests {
+		for range 20 {
+			if got := substitute(tt.in, vars); got != tt.want {
+				t.Fatalf("substitute(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestBuildArguments_ModernUsesVersionJVMArgs(t *testing.T) {
+	tmp := t.TempDir()
+	var args core.Arguments
+	err := json.Unmarshal([]byte(`{
+		"jvm": [
+			"-DFabricMcEmu= net.minecraft.client.main.Main ",
+			"-Djava.library.path=${natives_directory}",
+			"-Dminecraft.launcher.brand=${launcher_name}",
+			"-cp", "${classpath}"
+		],
+		"game": ["--username", "${auth_player_name}", "--version", "${version_name}",
+			{"rules": [{"action": "allow", "features": {"is_demo_user": true}}], "value": "--demo"}]
+	}`), &args)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := NewLauncher(&Options{
+		Instance:    &core.Instance{Path: tmp, JVMArgs: []string{"-Xmx4G"}},
+		VersionInfo: &core.VersionDetails{ID: "1.21.4", MainClass: "net.fabricmc.loader.impl.launch.knot.KnotClient", Arguments: &args},
+		PlayerName:  "Steve",
+		Config:      &config.Config{LibrariesDir: filepath.Join(tmp, "libraries"), AssetsDir: filepath.Join(tmp, "assets")},
+	}, nil)
+
+	got := l.buildArguments()
+	joined := strings.Join(got, " ")
+
+	if got[0] != "-DFabricMcEmu= net.minecraft.client.main.Main " {
+		t.Errorf("first arg = %q, want Fabric JVM arg preserved", got[0])
+	}
+	if !strings.Contains(joined, "-Djava.library.path="+filepath.Join(tmp, "natives")) {
+		t.Errorf("natives dir not substituted: %s", joined)
+	}
+	if !strings.Contains(joined, "-Dminecraft.launcher.brand=mctui") {
+		t.Errorf("launcher name not substituted: %s", joined)
+	}
+	if strings.Count(joined, "-Djava.library.path") != 1 {
+		t.Errorf("library path emitted more than once: %s", joined)
+	}
+	if strings.Contains(joined, "--demo") {
+		t.Errorf("demo arg should be rule-gated off: %s", joined)
+	}
+	mainIdx := indexOf(got, "net.fabricmc.loader.impl.launch.knot.KnotClient")
+	xmxIdx := indexOf(got, "-Xmx4G")
+	if xmxIdx < 0 || mainIdx < 0 || xmxIdx > mainIdx {
+		t.Errorf("user JVM args must precede the main class: %q", got)
+	}
+	if got[len(got)-1] != "1.21.4" || got[len(got)-2] != "--version" {
+		t.Errorf("game args should follow main class in order: %q", got)
+	}
+}
+
+func TestBuildArguments_LegacySuppliesCoreJVMFlags(t *testing.T) {
+	tmp := t.TempDir()
+	l := NewLauncher(&Options{
+		Instance: &core.Instance{Path: tmp},
+		VersionInfo: &core.VersionDetails{
+			ID:                 "1.8.9",
+			MainClass:          "net.minecraft.client.main.Main",
+			MinecraftArguments: "--username ${auth_player_name} --session ${auth_session} --assetsDir ${assets_root}",
+		},
+		PlayerName:  "Alex",
+		AccessToken: "tok",
+		UUID:        "uuid",
+		Config:      &config.Config{LibrariesDir: tmp, AssetsDir: "/assets"},
+	}, nil)
+
+	got := l.buildArguments()
+	if indexOf(got, "-cp") < 0 {
+		t.Fatalf("legacy launch must supply -cp: %q", got)
+	}
+	if indexOf(got, "-Djava.library.path="+filepath.Join(tmp, "natives")) < 0 {
+		t.Errorf("legacy launch must supply java.library.path: %q", got)
+	}
+	if indexOf(got, "token:tok:uuid") < 0 {
+		t.Errorf("auth_session not substituted: %q", got)
+	}
+	if indexOf(got, "/assets") < 0 {
+		t.Errorf("assets_root not substituted: %q", got)
+	}
+}
+
+func indexOf(args []string, want string) int {
+	for i, a := range args {
+		if a == want {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestBuildGameArguments_WindowSettings(t *testing.T) {
+	var modern core.Arguments
+	err := json.Unmarshal([]byte(`{"game": [
+		"--version", "${version_name}",
+		{"rules": [{"action": "allow", "features": {"has_custom_resolution": true}}],
+		 "value": ["--width", "${resolution_width}", "--height", "${resolution_height}"]}
+	]}`), &modern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	on, off := true, false
+
+	tests := []struct {
+		name    string
+		version *core.VersionDetails
+		inst    core.Instance
+		cfg     config.Config
+		want    []string
+	}{
+		{
+			name:    "modern without size",
+			version: &core.VersionDetails{ID: "1.21.4", Arguments: &modern},
+			want:    []string{"--version", "1.21.4"},
+		},
+		{
+			name:    "modern global size",
+			version: &core.VersionDetails{ID: "1.21.4", Arguments: &modern},
+			cfg:     config.Config{WindowWidth: 1280, WindowHeight: 720},
+			want:    []string{"--version", "1.21.4", "--width", "1280", "--height", "720"},
+		},
+		{
+			name:    "instance overrides size and fullscreen",
+			version: &core.VersionDetails{ID: "1.21.4", Arguments: &modern},
+			inst:    core.Instance{WindowWidth: 1920, WindowHeight: 1080, Fullscreen: &on},
+			cfg:     config.Config{WindowWidth: 1280, WindowHeight: 720},
+			want:    []string{"--version", "1.21.4", "--width", "1920", "--height", "1080", "--fullscreen"},
+		},
+		{
+			name:    "instance disables global fullscreen",
+			version: &core.VersionDetails{ID: "1.21.4", Arguments: &modern},
+			inst:    core.Instance{Fullscreen: &off},
+			cfg:     config.Config{Fullscreen: true},
+			want:    []string{"--version", "1.21.4"},
+		},
+		{
+			name:    "legacy size and fullscreen",
+			version: &core.VersionDetails{ID: "1.8.9", MinecraftArguments: "--version ${version_name}"},
+			cfg:     config.Config{WindowWidth: 854, WindowHeight: 480, Fullscreen: true},
+			want:    []string{"--version", "1.8.9", "--width", "854", "--height", "480", "--fullscreen"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inst := tt.inst
+			inst.Path = t.TempDir()
+			cfg := tt.cfg
+			l := NewLauncher(&Options{Instance: &inst, VersionInfo: tt.version, Config: &cfg}, nil)
+			got := l.buildGameArguments(l.ruleContext(), l.argumentVars())
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("args = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package launch
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CrashKind says which kind of file a [CrashReport] was parsed from.
+type CrashKind string
+
+const (
+	CrashKindGame CrashKind = "crash-report" // .minecraft/crash-reports/crash-*.txt
+	CrashKindJVM  CrashKind = "jvm"          // hs_err_pid*.log from a fatal JVM error
+)
+
+// CrashReport is the summary of a crash report or JVM fatal error log written during a session.
+type CrashReport struct {
+	Path          string
+	Kind          CrashKind
+	Description   string   // "Description:" line, or the JVM's fatal error headline
+	Exception     string   // first line of the exception, or the JVM's problematic frame
+	SuspectedMods []string // from the "Suspected Mods" section, when the loader adds one
+}
+
+// Summary is a one-line description of the crash.
+func (r *CrashReport) Summary() string {
+	switch {
+	case r.Description != "":
+		return r.Description
+	case r.Kind == CrashKindJVM:
+		return "fatal JVM error"
+	default:
+		return "crash report written"
+	}
+}
+
+// GameExitError is returned by [Launcher.Launch] when the game exits abnormally
+// and left something to explain why: a crash report or JVM error log, and/or log
+// lines matching known failure signatures.
+type GameExitError struct {
+	Err       error        // the process exit error
+	Crash     *CrashReport // nil when no crash file was written
+	Diagnoses []Diagnosis  // known failures recognized in the log or crash report
+}
+
+func (e *GameExitError) Error() string {
+	if e.Crash != nil {
+		return "game crashed: " + e.Crash.Summary()
+	}
+	if len(e.Diagnoses) > 0 {
+		return "game exited with error: " + e.Diagnoses[0].Title
+	}
+	return fmt.Sprintf("game exited with error: %v", e.Err)
+}
+
+func (e *GameExitError) Unwrap() error { return e.Err }
+
+// crashFileSnapshot records crash files that existed before the game started, so
+// only reports written during this session are picked up afterwards.
+type crashFileSnapshot map[string]time.Time
+
+// crashCandidates lists crash reports and JVM error logs under gameDir.
+func crashCandidates(gameDir string) []string {
+	reports, _ := filepath.Glob(filepath.Join(gameDir, "crash-reports", "*.txt"))
+	jvm, _ := filepath.Glob(filepath.Join(gameDir, "hs_err_pid*.log"))
+	return append(reports, jvm...)
+}
+
+func snapshotCrashFiles(gameDir string) crashFileSnapshot {
+	snap := crashFileSnapshot{}
+	for _, p := range crashCandidates(gameDir) {
+		if info, err := os.Stat(p); err == nil {
+			snap[p] = info.ModTime()
+		}
+	}
+	return snap
+}
+
+// findCrashReport returns the newest crash file under gameDir that is new or
+// modified since snap, parsed. It returns nil when the session left none.
+func findCrashReport(gameDir string, snap crashFileSnapshot) *CrashReport {
+	var newest string
+	var newestMod time.Time
+	for _, p := range crashCandidates(gameDir) {
+		info, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+		if before, ok := snap[p]; ok && !info.ModTime().After(before) {
+			continue
+		}
+		if newest == "" || info.ModTime().After(newestMod) {
+			newest, newestMod = p, info.ModTime()
+		}
+	}
+	if newest == "" {
+		return nil
+	}
+	report, err := parseCrashFile(newest)
+	if err != nil {
+		return &CrashReport{Path: newest, Kind: crashKindFor(newest)}
+	}
+	return report
+}
+
+func crashKindFor(path string) CrashKind {
+	if strings.HasPrefix(filepath.Base(path), "hs_err_pid") {
+		return CrashKindJVM
+	}
+	return CrashKindGame
+}
+
+// parseCrashFile reads a crash report or JVM error log.
+func parseCrashFile(path string) (*CrashReport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	report := &CrashReport{Path: path, Kind: crashKindFor(path)}
+	if report.Kind == CrashKindJVM {
+		parseJVMErrorLog(report, lines)
+	} else {
+		parseGameCrashReport(report, lines)
+	}
+	return report, nil
+}
+
+// parseGameCrashReport fills report from a Minecraft crash report: the Description
+// line, the exception line that follows it, and the loader's Suspected Mods section.
+func parseGameCrashReport(report *CrashReport, lines []string) {
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case report.Description == "" && strings.HasPrefix(line, "Description:"):
+			report.Description = strings.TrimSpace(strings.TrimPrefix(line, "Description:"))
+			for j := i + 1; j < len(lines); j++ {
+				if t := strings.TrimSpace(lines[j]); t != "" {
+					report.Exception = t
+					i = j
+					break
+				}
+			}
+		case report.SuspectedMods == nil && strings.HasPrefix(strings.ToLower(strings.TrimSpace(line)), "suspected mod"):
+			report.SuspectedMods = []string{}
+			_, rest, _ := strings.Cut(line, ":")
+			if rest = strings.TrimSpace(rest); rest != "" && !strings.EqualFold(rest, "none") && !strings.EqualFold(rest, "unknown") {
+				report.SuspectedMods = append(report.SuspectedMods, rest)
+			}
+			// Entries are indented one tab; their details (issue tracker, stack frames) two.
+			for j := i + 1; j < len(lines); j++ {
+				next := lines[j]
+				if !strings.HasPrefix(next, "\t") {
+					i = j - 1
+					break
+				}
+				if strings.HasPrefix(next, "\t\t") {
+					continue
+				}
+				report.SuspectedMods = append(report.SuspectedMods, strings.TrimSpace(next))
+			}
+		}
+	}
+}
+
+// parseJVMErrorLog fills report from an hs_err_pid log's header comment block.
+func parseJVMErrorLog(report *CrashReport, lines []string) {
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "#") {
+			if report.Description != "" {
+				return // header block ended
+			}
+			continue
+		}
+		text := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+		switch {
+		case report.Description == "" && text != "" && !strings.HasPrefix(text, "A fatal error has been detected"):
+			report.Description = text
+		case text == "Problematic frame:" && i+1 < len(lines):
+			report.Exception = strings.TrimSpace(strings.TrimPrefix(lines[i+1], "#"))
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package launch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const sampleCrashReport = `---- Minecraft Crash Report ----
+// Don't be sad, have a hug! <3
+
+Time: 2025-03-01 12:00:00
+Description: Rendering overlay
+
+java.lang.NullPointerException: Cannot invoke "net.minecraft.class_310.method_1551()" because "this.client" is null
+	at net.minecraft.class_442.method_25394(class_442.java:123)
+	at net.minecraft.class_757.method_3192(class_757.java:456)
+
+A detailed walkthrough of the error, its code path and all known details is as follows:
+---------------------------------------------------------------------------------------
+
+-- Head --
+Thread: Render thread
+Suspected Mods: 
+	Sodium (sodium), Version: 0.5.11
+		Issue tracker URL: https://github.com/CaffeineMC/sodium-fabric/issues
+		at net.caffeinemc.mods.sodium.client.render.SodiumWorldRenderer.render(SodiumWorldRenderer.java:100)
+	Iris (iris), Version: 1.7.0
+Stacktrace:
+	at net.minecraft.class_442.method_25394(class_442.java:123)
+`
+
+const sampleJVMErrorLog = `#
+# A fatal error has been detected by the Java Runtime Environment:
+#
+#  EXCEPTION_ACCESS_VIOLATION (0xc0000005) at pc=0x00007ffb1b2b2a, pid=1234, tid=5678
+#
+# JRE version: OpenJDK Runtime Environment (21.0.2+13) (build 21.0.2+13)
+# Problematic frame:
+# C  [atio6axx.dll+0x1b2b2a]
+#
+
+---------------  S U M M A R Y ------------
+`
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseCrashFile_GameReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crash-reports", "crash-2025-03-01_12.00.00-client.txt")
+	writeFile(t, path, sampleCrashReport)
+
+	r, err := parseCrashFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Kind != CrashKindGame || r.Description != "Rendering overlay" {
+		t.Errorf("kind/description = %q/%q", r.Kind, r.Description)
+	}
+	if want := `java.lang.NullPointerException: Cannot invoke "net.minecraft.class_310.method_1551()" because "this.client" is null`; r.Exception != want {
+		t.Errorf("exception = %q", r.Exception)
+	}
+	if want := []string{"Sodium (sodium), Version: 0.5.11", "Iris (iris), Version: 1.7.0"}; !reflect.DeepEqual(r.SuspectedMods, want) {
+		t.Errorf("suspected mods = %q, want %q", r.SuspectedMods, want)
+	}
+}
+
+func TestParseCrashFile_SuspectedModsNone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crash.txt")
+	writeFile(t, path, "Description: Ticking entity\n\njava.lang.IllegalStateException: boom\n\nSuspected Mods: NONE\n")
+
+	r, err := parseCrashFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.SuspectedMods) != 0 || r.Exception != "java.lang.IllegalStateException: boom" {
+		t.Errorf("report = %+v", r)
+	}
+}
+
+func TestParseCrashFile_JVMErrorLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hs_err_pid1234.log")
+	writeFile(t, path, sampleJVMErrorLog)
+
+	r, err := parseCrashFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Kind != CrashKindJVM {
+		t.Errorf("kind = %q, want jvm", r.Kind)
+	}
+	if want := "EXCEPTION_ACCESS_VIOLATION (0xc0000005) at pc=0x00007ffb1b2b2a, pid=1234, tid=5678"; r.Description != want {
+		t.Errorf("description = %q", r.Description)
+	}
+	if r.Exception != "C  [atio6axx.dll+0x1b2b2a]" {
+		t.Errorf("problematic frame = %q", r.Exception)
+	}
+}
+
+func TestFindCrashReport_OnlyNewFiles(t *testing.T) {
+	gameDir := t.TempDir()
+	old := filepath.Join(gameDir, "crash-reports", "crash-old.txt")
+	writeFile(t, old, "Description: Old crash\n")
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(old, past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	snap := snapshotCrashFiles(gameDir)
+	if r := findCrashReport(gameDir, snap); r != nil {
+		t.Fatalf("pre-existing report should be ignored, got %+v", r)
+	}
+
+	writeFile(t, filepath.Join(gameDir, "hs_err_pid42.log"), sampleJVMErrorLog)
+	r := findCrashReport(gameDir, snap)
+	if r == nil || r.Kind != CrashKindJVM {
+		t.Fatalf("new JVM log should be found, got %+v", r)
+	}
+
+	exitErr := errors.New("exit status 1")
+	err := error(&GameExitError{Err: exitErr, Crash: r})
+	if !errors.Is(err, exitErr) {
+		t.Error("GameExitError should unwrap to the exit error")
+	}
+}
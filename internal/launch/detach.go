@@ -0,0 +1,67 @@
+package launch
+
+import (
+	"os"
+	"os/exec"
+	"time"
+)
+
+// detachedLogPollInterval is how often a detached game's session log is read
+// for new output while mctui is still running.
+const detachedLogPollInterval = 250 * time.Millisecond
+
+// detached reports whether the game should run in its own session and outlive
+// mctui: the instance's override if set, else the global default.
+func (l *Launcher) detached() bool {
+	if inst := l.opts.Instance; inst != nil && inst.Detach != nil {
+		return *inst.Detach
+	}
+	return l.cfg != nil && l.cfg.DetachGames
+}
+
+// findProcess returns the running process pid, provided it is still the one
+// identified by startID (see processStartID). PIDs are reused, so after a reboot
+// or an unobserved exit a bare PID may belong to an unrelated process that must
+// not be shown as the game or signalled.
+func findProcess(pid int, startID string) (*os.Process, bool) {
+	if startID == "" || processStartID(pid) != startID {
+		return nil, false
+	}
+	p, err := os.FindProcess(pid)
+	if err != nil || !processAlive(p) {
+		return nil, false
+	}
+	return p, true
+}
+
+// waitDetached waits for a detached game, following the session log it writes
+// directly so its output still reaches the analyzer and launch view. stdout and
+// stderr share the file, so every line is reported as stdout.
+func (l *Launcher) waitDetached(cmd *exec.Cmd, logPath string) error {
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	var offset int64
+	follow := func() {
+		lines, next, err := ReadSessionLogFrom(logPath, offset)
+		if err != nil {
+			return
+		}
+		offset = next
+		for _, line := range lines {
+			l.handleLogLine(line, "stdout")
+		}
+	}
+
+	ticker := time.NewTicker(detachedLogPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-done:
+			follow()
+			return err
+		case <-ticker.C:
+			follow()
+		}
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !windows
+
+package launch
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// detachProcess starts cmd in a session of its own, so it has no controlling
+// terminal and keeps running after mctui exits.
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
+
+// processAlive reports whether p is still running. Only meaningful for processes
+// that aren't our children, which would otherwise linger as zombies until waited on.
+func processAlive(p *os.Process) bool {
+	return p.Signal(syscall.Signal(0)) == nil
+}
@@ -0,0 +1,32 @@
+package launch
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+const (
+	detachedProcess = 0x00000008 // DETACHED_PROCESS: no console, unaffected by ours closing
+	stillActive     = 259        // STILL_ACTIVE exit code of a running process
+)
+
+// detachProcess starts cmd in a process group of its own without a console, so
+// it keeps running after mctui exits.
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP | detachedProcess}
+}
+
+// processAlive reports whether p is still running.
+func processAlive(p *os.Process) bool {
+	h, err := syscall.OpenProcess(syscall.PROCESS_QUERY_INFORMATION, false, uint32(p.Pid))
+	if err != nil {
+		return false
+	}
+	defer syscall.CloseHandle(h)
+	var code uint32
+	if err := syscall.GetExitCodeProcess(h, &code); err != nil {
+		return false
+	}
+	return code == stillActive
+}
@@ -0,0 +1,245 @@
+package launch
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Diagnosis is a plain-English explanation of a recognized failure and how to fix it.
+type Diagnosis struct {
+	ID         string // rule that matched, e.g. "out-of-memory"
+	Title      string // what went wrong
+	Suggestion string // what to do about it
+}
+
+// diagnosticRule recognizes one known failure signature in a log line.
+//
+// Title and Suggestion are templates expanded with the pattern's named groups
+// (${name}); derive, when set, adds computed values to those groups. A fallback
+// rule is dropped when another rule in the same group also matched, so a generic
+// headline gives way to the specific lines that follow it.
+type diagnosticRule struct {
+	id         string
+	group      string
+	fallback   bool
+	contains   string // cheap substring pre-check before the regexp runs
+	pattern    *regexp.Regexp
+	title      string
+	suggestion string
+	derive     func(vars map[string]string)
+}
+
+// diagnosticRules is the table of known failure signatures, in display order.
+// Add new signatures here.
+var diagnosticRules = []diagnosticRule{
+	{
+		id:         "fabric-missing-dependency",
+		group:      "fabric-deps",
+		contains:   "which is missing",
+		pattern:    regexp.MustCompile(`Mod '(?P<mod>[^']+)' \([^)]*\) \S+ requires .*'(?P<dep>[^']+)' \([^)]*\), which is missing`),
+		title:      "${mod} needs ${dep}, which isn't installed",
+		suggestion: "Install ${dep} from the mods browser, or remove ${mod}.",
+	},
+	{
+		id:         "fabric-wrong-dependency-version",
+		group:      "fabric-deps",
+		contains:   "wrong version is present",
+		pattern:    regexp.MustCompile(`Mod '(?P<mod>[^']+)' \([^)]*\) \S+ requires (?P<want>.*)'(?P<dep>[^']+)' \([^)]*\), but only the wrong version is present: (?P<have>[^!]+)`),
+		title:      "${mod} needs a different version of ${dep} (found ${have})",
+		suggestion: "Update ${mod} and ${dep} to builds for this Minecraft version, or remove ${mod}.",
+	},
+	{
+		id:         "fabric-incompatible-mod",
+		group:      "fabric-deps",
+		contains:   "is incompatible with",
+		pattern:    regexp.MustCompile(`Mod '(?P<mod>[^']+)' \([^)]*\) \S+ is incompatible with .*'(?P<other>[^']+)' \([^)]*\)`),
+		title:      "${mod} can't run alongside ${other}",
+		suggestion: "Remove one of ${mod} or ${other} from the mods folder.",
+	},
+	{
+		id:         "fabric-incompatible-mods",
+		group:      "fabric-deps",
+		fallback:   true,
+		contains:   "Incompatible mod",
+		pattern:    regexp.MustCompile(`Incompatible mods? (?:found|set)`),
+		title:      "Fabric found incompatible or missing mods",
+		suggestion: "Check the log lines after \"Incompatible mods found\" for which mod needs what, then add or remove jars in the mods browser.",
+	},
+	{
+		id:         "out-of-memory",
+		contains:   "OutOfMemoryError",
+		pattern:    regexp.MustCompile(`java\.lang\.OutOfMemoryError`),
+		title:      "The game ran out of memory",
+		suggestion: "Raise the instance's maximum memory in its settings, or drop heavy mods and high-resolution resource packs.",
+	},
+	{
+		id:         "no-opengl-driver",
+		contains:   "65542",
+		pattern:    regexp.MustCompile(`GLFW error 65542`),
+		title:      "No OpenGL driver was found (GLFW error 65542)",
+		suggestion: "Install your GPU vendor's graphics driver. Generic display adapters (e.g. \"Microsoft Basic Display\") can't run Minecraft.",
+	},
+	{
+		id:         "opengl-version-unavailable",
+		contains:   "65543",
+		pattern:    regexp.MustCompile(`GLFW error 65543`),
+		title:      "Your graphics driver doesn't support the OpenGL version Minecraft needs",
+		suggestion: "Update the graphics driver; on laptops with two GPUs, run Java on the dedicated one.",
+	},
+	{
+		id:         "class-version-too-new",
+		contains:   "class file version",
+		pattern:    regexp.MustCompile(`compiled by a more recent version of the Java Runtime \(class file version (?P<major>\d+)(?:\.\d+)?\), this version of the Java Runtime only recognizes class file versions up to (?P<have>\d+)`),
+		title:      "This needs Java ${java}, but Java ${haveJava} is running",
+		suggestion: "Switch the instance to Java ${java} or newer.",
+		derive: func(vars map[string]string) {
+			vars["java"] = javaForClassMajor(vars["major"])
+			vars["haveJava"] = javaForClassMajor(vars["have"])
+		},
+	},
+	{
+		id:         "class-version-unsupported",
+		contains:   "Unsupported class file major version",
+		pattern:    regexp.MustCompile(`Unsupported class file major version (?P<major>\d+)`),
+		title:      "The mod loader can't read Java ${java} class files",
+		suggestion: "Run this instance on an older Java major version (Java 17 for 1.18–1.20.4, Java 8 for 1.16 and older), or update the mod loader.",
+		derive: func(vars map[string]string) {
+			vars["java"] = javaForClassMajor(vars["major"])
+		},
+	},
+	{
+		id:         "mixin-apply-failed",
+		group:      "mixin",
+		contains:   "Mixin apply for mod",
+		pattern:    regexp.MustCompile(`Mixin apply for mod (?P<mod>[\w\-]+) failed (?P<mixin>\S+)`),
+		title:      "Mod ${mod} failed to patch the game",
+		suggestion: "${mod} is likely built for another Minecraft or loader version, or clashes with another mod. Update it or remove its jar from the mods folder.",
+	},
+	{
+		id:         "mixin-apply-failed-config",
+		group:      "mixin",
+		contains:   "FAILED during APPLY",
+		pattern:    regexp.MustCompile(`in config \[(?P<mod>[\w\-]+?)(?:[.\-]mixins)?(?:\.[\w\-]+)*\.json\] FAILED during APPLY`),
+		title:      "Mod ${mod} failed to patch the game",
+		suggestion: "${mod} is likely built for another Minecraft or loader version, or clashes with another mod. Update it or remove its jar from the mods folder.",
+	},
+}
+
+// javaForClassMajor maps a class file major version (e.g. "61") to its Java release ("17").
+func javaForClassMajor(major string) string {
+	n, err := strconv.Atoi(major)
+	if err != nil || n < 45 {
+		return major
+	}
+	return strconv.Itoa(n - 44)
+}
+
+// maxDiagnoses caps how many findings one session reports.
+const maxDiagnoses = 5
+
+// logAnalyzer matches game output against [diagnosticRules]. It is safe for
+// concurrent use, since stdout and stderr are streamed from separate goroutines.
+type logAnalyzer struct {
+	rules []diagnosticRule
+
+	mu    sync.Mutex
+	seen  map[string]bool
+	found []finding
+}
+
+// finding is a matched diagnosis plus the rule metadata needed for fallback pruning.
+type finding struct {
+	Diagnosis
+	group    string
+	fallback bool
+}
+
+func newLogAnalyzer() *logAnalyzer {
+	return &logAnalyzer{
+		rules: diagnosticRules,
+		seen:  map[string]bool{},
+	}
+}
+
+// observe checks one log line against every rule.
+func (a *logAnalyzer) observe(line string) {
+	for i := range a.rules {
+		r := &a.rules[i]
+		if r.contains != "" && !strings.Contains(line, r.contains) {
+			continue
+		}
+		match := r.pattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		vars := map[string]string{}
+		for gi, name := range r.pattern.SubexpNames() {
+			if name != "" {
+				vars[name] = match[gi]
+			}
+		}
+		if r.derive != nil {
+			r.derive(vars)
+		}
+		d := Diagnosis{
+			ID:         r.id,
+			Title:      expandDiagnosis(r.title, vars),
+			Suggestion: expandDiagnosis(r.suggestion, vars),
+		}
+		a.add(r, d)
+	}
+}
+
+// observeFile feeds every line of path (e.g. a crash report) to the analyzer.
+func (a *logAnalyzer) observeFile(path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		a.observe(scanner.Text())
+	}
+}
+
+func (a *logAnalyzer) add(r *diagnosticRule, d Diagnosis) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	key := d.ID + "\x00" + d.Title
+	if a.seen[key] || len(a.found) >= maxDiagnoses {
+		return
+	}
+	a.seen[key] = true
+	a.found = append(a.found, finding{Diagnosis: d, group: r.group, fallback: r.fallback})
+}
+
+// diagnoses returns what matched so far, minus fallbacks superseded by a specific
+// match in the same group.
+func (a *logAnalyzer) diagnoses() []Diagnosis {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	specific := map[string]bool{}
+	for _, f := range a.found {
+		if f.group != "" && !f.fallback {
+			specific[f.group] = true
+		}
+	}
+	var out []Diagnosis
+	for _, f := range a.found {
+		if f.fallback && specific[f.group] {
+			continue
+		}
+		out = append(out, f.Diagnosis)
+	}
+	return out
+}
+
+func expandDiagnosis(tmpl string, vars map[string]string) string {
+	return os.Expand(tmpl, func(k string) string { return vars[k] })
+}
@@ -0,0 +1,111 @@
+package launch
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLogAnalyzer_Rules(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []Diagnosis
+	}{
+		{
+			name: "fabric missing dependency supersedes headline",
+			lines: []string{
+				"[main/ERROR]: Incompatible mods found!",
+				"net.fabricmc.loader.impl.FormattedException: Some of your mods are incompatible with the game or each other!",
+				" - Mod 'Sodium Extra' (sodium-extra) 0.5.1 requires version 0.5.0 or later of mod 'Sodium' (sodium), which is missing!",
+			},
+			want: []Diagnosis{{
+				ID:         "fabric-missing-dependency",
+				Title:      "Sodium Extra needs Sodium, which isn't installed",
+				Suggestion: "Install Sodium from the mods browser, or remove Sodium Extra.",
+			}},
+		},
+		{
+			name:  "fabric headline alone",
+			lines: []string{"[main/ERROR]: Incompatible mods found!"},
+			want: []Diagnosis{{
+				ID:         "fabric-incompatible-mods",
+				Title:      "Fabric found incompatible or missing mods",
+				Suggestion: "Check the log lines after \"Incompatible mods found\" for which mod needs what, then add or remove jars in the mods browser.",
+			}},
+		},
+		{
+			name:  "wrong dependency version",
+			lines: []string{" - Mod 'Fabric API' (fabric-api) 0.92.0+1.20.1 requires version 1.20.1 of 'Minecraft' (minecraft), but only the wrong version is present: 1.20.4!"},
+			want: []Diagnosis{{
+				ID:         "fabric-wrong-dependency-version",
+				Title:      "Fabric API needs a different version of Minecraft (found 1.20.4)",
+				Suggestion: "Update Fabric API and Minecraft to builds for this Minecraft version, or remove Fabric API.",
+			}},
+		},
+		{
+			name:  "class version too new",
+			lines: []string{"java.lang.UnsupportedClassVersionError: net/minecraft/client/main/Main has been compiled by a more recent version of the Java Runtime (class file version 65.0), this version of the Java Runtime only recognizes class file versions up to 61.0"},
+			want: []Diagnosis{{
+				ID:         "class-version-too-new",
+				Title:      "This needs Java 21, but Java 17 is running",
+				Suggestion: "Switch the instance to Java 21 or newer.",
+			}},
+		},
+		{
+			name:  "mixin apply for mod",
+			lines: []string{"Caused by: org.spongepowered.asm.mixin.transformer.throwables.MixinTransformerError: Mixin apply for mod sodium failed sodium.mixins.json:core.MinecraftClientMixin from mod sodium -> net.minecraft.class_310"},
+			want: []Diagnosis{{
+				ID:         "mixin-apply-failed",
+				Title:      "Mod sodium failed to patch the game",
+				Suggestion: "sodium is likely built for another Minecraft or loader version, or clashes with another mod. Update it or remove its jar from the mods folder.",
+			}},
+		},
+		{
+			name:  "mixin config failure",
+			lines: []string{"Mixin [lithium.mixins.json:ai.MobEntityMixin] from phase [DEFAULT] in config [lithium.mixins.json] FAILED during APPLY"},
+			want: []Diagnosis{{
+				ID:         "mixin-apply-failed-config",
+				Title:      "Mod lithium failed to patch the game",
+				Suggestion: "lithium is likely built for another Minecraft or loader version, or clashes with another mod. Update it or remove its jar from the mods folder.",
+			}},
+		},
+		{
+			name:  "duplicates collapse",
+			lines: []string{"java.lang.OutOfMemoryError: Java heap space", "Exception in thread \"Render thread\" java.lang.OutOfMemoryError: Java heap space"},
+			want: []Diagnosis{{
+				ID:         "out-of-memory",
+				Title:      "The game ran out of memory",
+				Suggestion: "Raise the instance's maximum memory in its settings, or drop heavy mods and high-resolution resource packs.",
+			}},
+		},
+		{
+			name:  "no match",
+			lines: []string{"[Render thread/INFO]: Setting user: Steve"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newLogAnalyzer()
+			for _, line := range tt.lines {
+				a.observe(line)
+			}
+			if got := a.diagnoses(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diagnoses = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogAnalyzer_ObserveFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crash.txt")
+	if err := os.WriteFile(path, []byte("Description: Initializing game\n\njava.lang.RuntimeException: GLFW error 65542: WGL: The driver does not appear to support OpenGL\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	a := newLogAnalyzer()
+	a.observeFile(path)
+	if got := a.diagnoses(); len(got) != 1 || got[0].ID != "no-opengl-driver" {
+		t.Fatalf("diagnoses = %+v, want no-opengl-driver", got)
+	}
+}
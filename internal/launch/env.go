@@ -0,0 +1,46 @@
+package launch
+
+import (
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// applyEnv returns base ("KEY=value" entries) with the variables in unset removed
+// and those in set added or replaced. Keys are case-insensitive on Windows, as
+// the OS treats them.
+func applyEnv(base []string, set map[string]string, unset []string) []string {
+	if len(set) == 0 && len(unset) == 0 {
+		return base
+	}
+	drop := map[string]bool{}
+	for _, k := range unset {
+		drop[envKey(k)] = true
+	}
+	for k := range set {
+		drop[envKey(k)] = true
+	}
+	out := make([]string, 0, len(base)+len(set))
+	for _, kv := range base {
+		k, _, _ := strings.Cut(kv, "=")
+		if !drop[envKey(k)] {
+			out = append(out, kv)
+		}
+	}
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		out = append(out, k+"="+set[k])
+	}
+	return out
+}
+
+func envKey(k string) string {
+	if runtime.GOOS == "windows" {
+		return strings.ToUpper(k)
+	}
+	return k
+}
@@ -0,0 +1,31 @@
+package launch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyEnv(t *testing.T) {
+	base := []string{"PATH=/usr/bin", "_JAVA_OPTIONS=-Xmx1G", "DISPLAY=:0"}
+
+	got := applyEnv(base, map[string]string{"DISPLAY": ":1", "__GL_THREADED_OPTIMIZATIONS": "1"}, []string{"_JAVA_OPTIONS"})
+	want := []string{"PATH=/usr/bin", "DISPLAY=:1", "__GL_THREADED_OPTIMIZATIONS=1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("applyEnv = %q, want %q", got, want)
+	}
+
+	if got := applyEnv(base, nil, nil); !reflect.DeepEqual(got, base) {
+		t.Errorf("no overrides should return base unchanged, got %q", got)
+	}
+}
+
+func TestApplyEnv_InstanceOverridesGlobal(t *testing.T) {
+	base := []string{"PATH=/usr/bin"}
+	env := applyEnv(base, map[string]string{"MESA_GL_VERSION_OVERRIDE": "4.5"}, []string{"WAYLAND_DISPLAY"})
+	// The instance re-sets a globally unset variable and unsets a globally set one.
+	env = applyEnv(env, map[string]string{"WAYLAND_DISPLAY": "wayland-1"}, []string{"MESA_GL_VERSION_OVERRIDE"})
+	want := []string{"PATH=/usr/bin", "WAYLAND_DISPLAY=wayland-1"}
+	if !reflect.DeepEqual(env, want) {
+		t.Errorf("env = %q, want %q", env, want)
+	}
+}
@@ -0,0 +1,134 @@
+package launch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Hook names, as shown on the launch screen.
+const (
+	HookPreLaunch = "pre-launch"
+	HookPostExit  = "post-exit"
+)
+
+// HookError is returned by [Launcher.Launch] when an instance's pre-launch command fails.
+type HookError struct {
+	Hook    string // HookPreLaunch or HookPostExit
+	Command string
+	Output  string // combined stdout and stderr
+	Err     error
+}
+
+func (e *HookError) Error() string {
+	return fmt.Sprintf("%s command failed: %v", e.Hook, e.Err)
+}
+
+func (e *HookError) Unwrap() error { return e.Err }
+
+// processEnv is the environment the game and its hook commands run with: mctui's
+// own, then the global and instance overrides, plus INST_* variables describing
+// the instance.
+func (l *Launcher) processEnv(gameDir string) []string {
+	inst := l.opts.Instance
+	env := os.Environ()
+	if l.cfg != nil {
+		env = applyEnv(env, l.cfg.Env, l.cfg.UnsetEnv)
+	}
+	env = applyEnv(env, inst.Env, inst.UnsetEnv)
+	return append(env,
+		"INST_ID="+inst.ID,
+		"INST_NAME="+inst.Name,
+		"INST_DIR="+inst.Path,
+		"INST_MC_DIR="+gameDir,
+		"INST_MC_VERSION="+inst.Version,
+		"INST_JAVA="+l.opts.JavaPath,
+	)
+}
+
+// shellCommand runs command through the platform shell, so hooks can use pipes,
+// redirects, and &&.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// runHook runs a hook command in gameDir and returns its combined output.
+func runHook(ctx context.Context, hook, command, gameDir string, env []string) (string, error) {
+	cmd := shellCommand(ctx, command)
+	cmd.Dir = gameDir
+	cmd.Env = env
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return out.String(), &HookError{Hook: hook, Command: command, Output: strings.TrimSpace(out.String()), Err: err}
+	}
+	return out.String(), nil
+}
+
+// gameCommand builds the game process, prefixed by the instance's wrapper command if set.
+// The wrapper is split like a shell would, so quoted arguments and paths keep their spaces.
+func (l *Launcher) gameCommand(ctx context.Context, args []string) (*exec.Cmd, error) {
+	words, err := SplitWords(l.opts.Instance.WrapperCommand)
+	if err != nil {
+		return nil, fmt.Errorf("wrapper command: %w", err)
+	}
+	if len(words) == 0 {
+		return exec.CommandContext(ctx, l.opts.JavaPath, args...), nil
+	}
+	rest := make([]string, 0, len(words)+len(args))
+	for _, w := range words[1:] {
+		rest = append(rest, w.Text)
+	}
+	rest = append(rest, l.opts.JavaPath)
+	return exec.CommandContext(ctx, words[0].Text, append(rest, args...)...), nil
+}
+
+// runPreLaunchHook runs the instance's pre-launch command, if any. A failure aborts the launch.
+func (l *Launcher) runPreLaunchHook(ctx context.Context, gameDir string, env []string) error {
+	command := strings.TrimSpace(l.opts.Instance.PreLaunchCommand)
+	if command == "" {
+		return nil
+	}
+	l.sendStatus(Status{Step: "Launching", Message: "Running pre-launch command..."})
+	_, err := runHook(ctx, HookPreLaunch, command, gameDir, env)
+	return err
+}
+
+// runPostExitHook runs the instance's post-exit command, if any, with the game's
+// exit code in INST_EXIT_CODE. It runs even when the launch was cancelled, so
+// backup and sync scripts still see every session; failures are only reported.
+func (l *Launcher) runPostExitHook(gameDir string, env []string, exitCode int) {
+	command := strings.TrimSpace(l.opts.Instance.PostExitCommand)
+	if command == "" {
+		return
+	}
+	env = append(env, "INST_EXIT_CODE="+strconv.Itoa(exitCode))
+	out, err := runHook(context.Background(), HookPostExit, command, gameDir, env)
+	if l.sessionLog != nil {
+		for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+			if line != "" {
+				l.sessionLog.writeLine("stdout", "["+HookPostExit+"] "+line)
+			}
+		}
+	}
+	if err != nil {
+		l.sendStatus(Status{Step: "Playing", Message: err.Error()})
+	}
+}
+
+// exitCode is the process's exit code, or -1 when it never ran or was killed by a signal.
+func exitCode(state *os.ProcessState) int {
+	if state == nil {
+		return -1
+	}
+	return state.ExitCode()
+}
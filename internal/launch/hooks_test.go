@@ -0,0 +1,87 @@
+package launch
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/aayushdutt/mctui/internal/core"
+)
+
+func TestGameCommand_Wrapper(t *testing.T) {
+	tests := []struct {
+		wrapper string
+		want    []string
+	}{
+		{"", []string{"/jdk/bin/java", "-Xmx2G", "Main"}},
+		{"gamemoderun", []string{"gamemoderun", "/jdk/bin/java", "-Xmx2G", "Main"}},
+		{"  mangohud  --dlsym ", []string{"mangohud", "--dlsym", "/jdk/bin/java", "-Xmx2G", "Main"}},
+		{`prime-run "my tool" --opt='a b'`, []string{"prime-run", "my tool", "--opt=a b", "/jdk/bin/java", "-Xmx2G", "Main"}},
+		{`"/opt/My Tools/wrap"`, []string{"/opt/My Tools/wrap", "/jdk/bin/java", "-Xmx2G", "Main"}},
+	}
+	for _, tt := range tests {
+		l := NewLauncher(&Options{Instance: &core.Instance{WrapperCommand: tt.wrapper}, JavaPath: "/jdk/bin/java"}, nil)
+		cmd, err := l.gameCommand(context.Background(), []string{"-Xmx2G", "Main"})
+		if err != nil {
+			t.Fatalf("wrapper %q: %v", tt.wrapper, err)
+		}
+		if !reflect.DeepEqual(cmd.Args, tt.want) {
+			t.Errorf("wrapper %q: args = %q, want %q", tt.wrapper, cmd.Args, tt.want)
+		}
+	}
+}
+
+func TestGameCommand_UnterminatedQuote(t *testing.T) {
+	l := NewLauncher(&Options{Instance: &core.Instance{WrapperCommand: `prime-run "my tool`}, JavaPath: "/jdk/bin/java"}, nil)
+	if _, err := l.gameCommand(context.Background(), nil); err == nil {
+		t.Fatal("expected an error for an unterminated quote")
+	}
+}
+
+func TestProcessEnv(t *testing.T) {
+	inst := &core.Instance{ID: "abc", Name: "Survival", Path: "/data/instances/Survival", Version: "1.21.4"}
+	l := NewLauncher(&Options{Instance: inst, JavaPath: "/jdk/bin/java"}, nil)
+	env := l.processEnv("/data/instances/Survival/.minecraft")
+	for _, want := range []string{
+		"INST_NAME=Survival",
+		"INST_DIR=/data/instances/Survival",
+		"INST_MC_DIR=/data/instances/Survival/.minecraft",
+		"INST_JAVA=/jdk/bin/java",
+	} {
+		found := false
+		for _, kv := range env {
+			found = found || kv == want
+		}
+		if !found {
+			t.Errorf("process env missing %s", want)
+		}
+	}
+}
+
+func TestRunHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh syntax")
+	}
+	dir := t.TempDir()
+	env := []string{"INST_NAME=Survival"}
+
+	out, err := runHook(context.Background(), HookPreLaunch, `echo "syncing $INST_NAME in $(pwd)"`, dir, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "syncing Survival in ") {
+		t.Errorf("output = %q", out)
+	}
+
+	_, err = runHook(context.Background(), HookPreLaunch, "echo 'config repo unreachable' >&2; exit 3", dir, env)
+	var hookErr *HookError
+	if !errors.As(err, &hookErr) {
+		t.Fatalf("err = %v, want *HookError", err)
+	}
+	if hookErr.Hook != HookPreLaunch || hookErr.Output != "config repo unreachable" {
+		t.Errorf("hookErr = %+v", hookErr)
+	}
+}
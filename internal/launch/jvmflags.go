@@ -0,0 +1,314 @@
+package launch
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aayushdutt/mctui/internal/java"
+	"github.com/aayushdutt/mctui/internal/memory"
+)
+
+// JVMPreset is a named set of JVM tuning flags an instance can run with.
+type JVMPreset struct {
+	ID          string // stored in core.Instance.JVMPreset
+	Name        string
+	Description string
+	MinJava     int // oldest Java major version the flags run on
+	Flags       []string
+}
+
+// JVMPresets lists the presets in picker order.
+var JVMPresets = []JVMPreset{
+	{
+		ID:          "aikar",
+		Name:        "Aikar's G1",
+		Description: "G1 tuned for short pauses with a large young generation; a safe choice for most instances.",
+		MinJava:     8,
+		Flags: []string{
+			"-XX:+UseG1GC",
+			"-XX:+ParallelRefProcEnabled",
+			"-XX:MaxGCPauseMillis=200",
+			"-XX:+UnlockExperimentalVMOptions",
+			"-XX:+DisableExplicitGC",
+			"-XX:G1NewSizePercent=30",
+			"-XX:G1MaxNewSizePercent=40",
+			"-XX:G1HeapRegionSize=8M",
+			"-XX:G1ReservePercent=20",
+			"-XX:G1HeapWastePercent=5",
+			"-XX:G1MixedGCCountTarget=4",
+			"-XX:InitiatingHeapOccupancyPercent=15",
+			"-XX:G1MixedGCLiveThresholdPercent=90",
+			"-XX:SurvivorRatio=32",
+			"-XX:+PerfDisableSharedMem",
+			"-XX:MaxTenuringThreshold=1",
+		},
+	},
+	{
+		ID:          "zgc",
+		Name:        "Generational ZGC",
+		Description: "Sub-millisecond pauses for large heaps (6 GB and up); uses more memory and CPU than G1.",
+		MinJava:     21,
+		Flags:       []string{"-XX:+UseZGC", "-XX:+ZGenerational"},
+	},
+	{
+		ID:          "shenandoah",
+		Name:        "Shenandoah",
+		Description: "Low pauses on mid-sized heaps. Not in Oracle's Java builds.",
+		MinJava:     15,
+		Flags:       []string{"-XX:+UseShenandoahGC", "-XX:ShenandoahGCHeuristics=compact"},
+	},
+	{
+		ID:          "lowram",
+		Name:        "Low RAM",
+		Description: "Serial collector that hands unused heap back to the system; for machines with 4 GB or less.",
+		MinJava:     8,
+		Flags:       []string{"-XX:+UseSerialGC", "-XX:MinHeapFreeRatio=10", "-XX:MaxHeapFreeRatio=30"},
+	},
+}
+
+// FindJVMPreset returns the preset with id, or nil.
+func FindJVMPreset(id string) *JVMPreset {
+	for i := range JVMPresets {
+		if JVMPresets[i].ID == id {
+			return &JVMPresets[i]
+		}
+	}
+	return nil
+}
+
+// JVMConflict is a set of JVM options the JVM would reject, or that contradict
+// each other.
+type JVMConflict struct {
+	Problem string
+	Flags   []string // the options involved, each with where it came from
+}
+
+// JVMArgsError is returned by [Launcher.Launch] when the JVM options conflict,
+// before the JVM gets to abort on them.
+type JVMArgsError struct {
+	Conflicts []JVMConflict
+}
+
+func (e *JVMArgsError) Error() string {
+	if len(e.Conflicts) == 1 {
+		return "JVM options conflict: " + e.Conflicts[0].Problem
+	}
+	return fmt.Sprintf("%d JVM option conflicts, first: %s", len(e.Conflicts), e.Conflicts[0].Problem)
+}
+
+// jvmFlag is a JVM option and where it came from.
+type jvmFlag struct {
+	arg    string
+	source string // "memory settings", a preset's name, or "JVM arguments"
+}
+
+func (f jvmFlag) String() string {
+	return f.arg + " (" + f.source + ")"
+}
+
+// jvmFlags are the JVM options the user controls, in command-line order: the
+// heap from the memory settings, the instance's preset, then the JVM
+// arguments. A heap size in the preset or JVM arguments replaces the one from
+// the settings, and preset options the instance's Java no longer has are
+// dropped.
+func (l *Launcher) jvmFlags() []jvmFlag {
+	var flags []jvmFlag
+	if p := FindJVMPreset(l.jvmPresetID()); p != nil {
+		javaMajor := l.javaMajorVersion()
+		for _, arg := range p.Flags {
+			// Newer Javas make some preset options the default and then drop
+			// them (ZGC is generational from Java 24 on); leave those out.
+			if support, ok := jvmOptionVersions[jvmOptionName(arg)]; ok && support.max > 0 && javaMajor > support.max {
+				continue
+			}
+			flags = append(flags, jvmFlag{arg, p.Name + " preset"})
+		}
+	}
+	for _, arg := range l.userJVMArgs() {
+		flags = append(flags, jvmFlag{arg, "JVM arguments"})
+	}
+
+	minMB, maxMB := l.heapMB()
+	for _, f := range flags {
+		if strings.HasPrefix(f.arg, "-Xms") {
+			minMB = 0
+		}
+		if strings.HasPrefix(f.arg, "-Xmx") {
+			maxMB = 0
+		}
+	}
+	var heap []jvmFlag
+	for _, arg := range memory.Flags(minMB, maxMB) {
+		heap = append(heap, jvmFlag{arg, "memory settings"})
+	}
+	return append(heap, flags...)
+}
+
+// jvmArgs are the JVM options the user controls; see [Launcher.jvmFlags].
+func (l *Launcher) jvmArgs() []string {
+	flags := l.jvmFlags()
+	args := make([]string, len(flags))
+	for i, f := range flags {
+		args[i] = f.arg
+	}
+	return args
+}
+
+func (l *Launcher) jvmPresetID() string {
+	if inst := l.opts.Instance; inst != nil {
+		return inst.JVMPreset
+	}
+	return ""
+}
+
+// checkJVMArgs reports the conflicts in the JVM options as a [JVMArgsError].
+func (l *Launcher) checkJVMArgs() error {
+	var conflicts []JVMConflict
+	if id := l.jvmPresetID(); id != "" && FindJVMPreset(id) == nil {
+		conflicts = append(conflicts, JVMConflict{Problem: fmt.Sprintf("unknown JVM preset %q", id)})
+	}
+	conflicts = append(conflicts, jvmConflicts(l.jvmFlags(), l.javaMajorVersion())...)
+	if len(conflicts) > 0 {
+		return &JVMArgsError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// javaMajorVersion is the major version of the Java the game will run on, or
+// 0 when it can't be told.
+func (l *Launcher) javaMajorVersion() int {
+	if l.javaMajor == 0 && l.opts.JavaPath != "" {
+		if inst, err := java.NewDetector().Inspect(l.opts.JavaPath); err == nil {
+			l.javaMajor = inst.MajorVersion
+		}
+	}
+	return l.javaMajor
+}
+
+// gcSelectors are the options that pick a garbage collector; the JVM refuses
+// to start with more than one.
+var gcSelectors = []string{
+	"UseSerialGC", "UseParallelGC", "UseParallelOldGC", "UseG1GC", "UseZGC",
+	"UseShenandoahGC", "UseConcMarkSweepGC", "UseEpsilonGC",
+}
+
+// jvmOptionSupport is the range of Java major versions an option exists in;
+// 0 is unbounded.
+type jvmOptionSupport struct {
+	min, max int
+}
+
+// jvmOptionVersions lists options that only some Java versions accept, by -XX
+// name or, for standard options, by their leading dashes and name.
+var jvmOptionVersions = map[string]jvmOptionSupport{
+	"ZGenerational":      {min: 21, max: 23}, // always on from 24
+	"UseZGC":             {min: 15},          // experimental before
+	"UseShenandoahGC":    {min: 15},          // experimental before
+	"UseParallelOldGC":   {max: 15},
+	"UseConcMarkSweepGC": {max: 13},
+	"AggressiveOpts":     {max: 11},
+	"--add-opens":        {min: 9},
+	"--add-exports":      {min: 9},
+	"--add-modules":      {min: 9},
+}
+
+// jvmConflicts finds options in flags that select more than one garbage
+// collector, repeat or contradict a heap size, or that a Java of javaMajor
+// (0 when unknown) doesn't accept.
+func jvmConflicts(flags []jvmFlag, javaMajor int) []JVMConflict {
+	var conflicts []JVMConflict
+
+	gcs := map[string]*jvmFlag{} // by name, nil once turned off again
+	var xmx, xms []jvmFlag
+	for _, f := range flags {
+		name, enabled := xxOption(f.arg)
+		switch {
+		case slices.Contains(gcSelectors, name):
+			if enabled {
+				gcs[name] = &f
+			} else {
+				gcs[name] = nil
+			}
+		case strings.HasPrefix(f.arg, "-Xmx"):
+			xmx = append(xmx, f)
+		case strings.HasPrefix(f.arg, "-Xms"):
+			xms = append(xms, f)
+		}
+	}
+	var selected []jvmFlag
+	for _, name := range gcSelectors {
+		if f := gcs[name]; f != nil {
+			selected = append(selected, *f)
+		}
+	}
+	if len(selected) > 1 {
+		slices.SortFunc(selected, func(a, b jvmFlag) int { return slices.Index(flags, a) - slices.Index(flags, b) })
+		conflicts = append(conflicts, JVMConflict{Problem: "more than one garbage collector is selected", Flags: flagStrings(selected)})
+	}
+	if len(xmx) > 1 {
+		conflicts = append(conflicts, JVMConflict{Problem: "-Xmx is given more than once", Flags: flagStrings(xmx)})
+	}
+	if len(xmx) > 0 && len(xms) > 0 {
+		lastMax, lastMin := xmx[len(xmx)-1], xms[len(xms)-1]
+		if memory.ParseMB(lastMin.arg[4:]) > memory.ParseMB(lastMax.arg[4:]) {
+			conflicts = append(conflicts, JVMConflict{Problem: "the starting heap (-Xms) is larger than the maximum (-Xmx)", Flags: flagStrings([]jvmFlag{lastMin, lastMax})})
+		}
+	}
+
+	if javaMajor > 0 {
+		for _, f := range flags {
+			name := jvmOptionName(f.arg)
+			support, ok := jvmOptionVersions[name]
+			switch {
+			case !ok:
+			case support.min > 0 && javaMajor < support.min:
+				conflicts = append(conflicts, JVMConflict{
+					Problem: fmt.Sprintf("%s needs Java %d or newer, but the instance runs Java %d", name, support.min, javaMajor),
+					Flags:   []string{f.String()},
+				})
+			case support.max > 0 && javaMajor > support.max:
+				conflicts = append(conflicts, JVMConflict{
+					Problem: fmt.Sprintf("%s was removed after Java %d, but the instance runs Java %d", name, support.max, javaMajor),
+					Flags:   []string{f.String()},
+				})
+			}
+		}
+	}
+	return conflicts
+}
+
+// jvmOptionName is the key arg is listed under in [jvmOptionVersions]: its -XX
+// name, or the option itself without a value.
+func jvmOptionName(arg string) string {
+	if name, _ := xxOption(arg); name != "" {
+		return name
+	}
+	name, _, _ := strings.Cut(arg, "=")
+	return name
+}
+
+// xxOption reads a -XX option: its name, and whether it turns a flag on
+// ("-XX:+Name"). name is "" for other options.
+func xxOption(arg string) (name string, enabled bool) {
+	rest, ok := strings.CutPrefix(arg, "-XX:")
+	if !ok {
+		return "", false
+	}
+	switch {
+	case strings.HasPrefix(rest, "+"):
+		return rest[1:], true
+	case strings.HasPrefix(rest, "-"):
+		return rest[1:], false
+	}
+	name, _, _ = strings.Cut(rest, "=")
+	return name, false
+}
+
+func flagStrings(flags []jvmFlag) []string {
+	out := make([]string, len(flags))
+	for i, f := range flags {
+		out[i] = f.String()
+	}
+	return out
+}
@@ -0,0 +1,153 @@
+package launch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aayushdutt/mctui/internal/config"
+	"github.com/aayushdutt/mctui/internal/core"
+)
+
+func TestJVMFlags_MergeOrder(t *testing.T) {
+	inst := &core.Instance{Path: t.TempDir(), MaxMemoryMB: 4096, JVMPreset: "zgc", JVMArgs: []string{"-Dfoo=bar"}}
+	l := NewLauncher(&Options{Instance: inst, Config: &config.Config{}}, nil)
+	want := []string{"-Xms512M", "-Xmx4G", "-XX:+UseZGC", "-XX:+ZGenerational", "-Dfoo=bar"}
+	if got := l.jvmArgs(); !slices.Equal(got, want) {
+		t.Errorf("jvmArgs = %q, want %q", got, want)
+	}
+
+	// A heap size in the JVM arguments replaces the settings' one.
+	inst.JVMArgs = []string{"-Xmx6G"}
+	want = []string{"-Xms512M", "-XX:+UseZGC", "-XX:+ZGenerational", "-Xmx6G"}
+	if got := l.jvmArgs(); !slices.Equal(got, want) {
+		t.Errorf("jvmArgs = %q, want %q", got, want)
+	}
+
+	// Java 24 dropped -XX:+ZGenerational; ZGC is generational there anyway.
+	l.javaMajor = 24
+	want = []string{"-Xms512M", "-XX:+UseZGC", "-Xmx6G"}
+	if got := l.jvmArgs(); !slices.Equal(got, want) {
+		t.Errorf("jvmArgs on Java 24 = %q, want %q", got, want)
+	}
+}
+
+func TestJVMConflicts(t *testing.T) {
+	flags := func(source string, args ...string) []jvmFlag {
+		var out []jvmFlag
+		for _, a := range args {
+			out = append(out, jvmFlag{a, source})
+		}
+		return out
+	}
+	tests := []struct {
+		name      string
+		flags     []jvmFlag
+		javaMajor int
+		want      []string // problems, in order
+	}{
+		{"clean", flags("JVM arguments", "-Xms1G", "-Xmx4G", "-XX:+UseG1GC", "-XX:+UseG1GC"), 21, nil},
+		{"two collectors", append(flags("ZGC preset", "-XX:+UseZGC"), flags("JVM arguments", "-XX:+UseG1GC")...), 21,
+			[]string{"more than one garbage collector is selected"}},
+		{"collector turned off again", append(flags("ZGC preset", "-XX:+UseZGC"), flags("JVM arguments", "-XX:-UseZGC", "-XX:+UseG1GC")...), 21, nil},
+		{"duplicate -Xmx", flags("JVM arguments", "-Xmx4G", "-Xmx2G"), 0, []string{"-Xmx is given more than once"}},
+		{"-Xms above -Xmx", append(flags("memory settings", "-Xms4G"), flags("JVM arguments", "-Xmx2G")...), 0,
+			[]string{"the starting heap (-Xms) is larger than the maximum (-Xmx)"}},
+		{"too old", flags("Generational ZGC preset", "-XX:+UseZGC", "-XX:+ZGenerational"), 17,
+			[]string{"ZGenerational needs Java 21 or newer, but the instance runs Java 17"}},
+		{"experimental", flags("Shenandoah preset", "-XX:+UseShenandoahGC"), 14,
+			[]string{"UseShenandoahGC needs Java 15 or newer, but the instance runs Java 14"}},
+		{"removed", flags("JVM arguments", "-XX:+UseConcMarkSweepGC", "--add-opens=java.base/java.lang=ALL-UNNAMED"), 8,
+			[]string{"--add-opens needs Java 9 or newer, but the instance runs Java 8"}},
+		{"removed later", flags("JVM arguments", "-XX:+UseConcMarkSweepGC"), 17,
+			[]string{"UseConcMarkSweepGC was removed after Java 13, but the instance runs Java 17"}},
+		{"dropped in 24", flags("JVM arguments", "-XX:+UseZGC", "-XX:+ZGenerational"), 24,
+			[]string{"ZGenerational was removed after Java 23, but the instance runs Java 24"}},
+		{"unknown Java", flags("JVM arguments", "-XX:+ZGenerational"), 0, nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, c := range jvmConflicts(tt.flags, tt.javaMajor) {
+			got = append(got, c.Problem)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: conflicts = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// Every preset must pass the check on the oldest Java it claims to support, and
+// be reported on anything older.
+func TestJVMPresets_MinJava(t *testing.T) {
+	for _, p := range JVMPresets {
+		var flags []jvmFlag
+		for _, f := range p.Flags {
+			flags = append(flags, jvmFlag{f, p.Name + " preset"})
+		}
+		if c := jvmConflicts(flags, p.MinJava); len(c) != 0 {
+			t.Errorf("%s on Java %d: conflicts %+v", p.ID, p.MinJava, c)
+		}
+		if p.MinJava > 8 && len(jvmConflicts(flags, p.MinJava-1)) == 0 {
+			t.Errorf("%s on Java %d: no conflict reported below its minimum", p.ID, p.MinJava-1)
+		}
+	}
+}
+
+func TestCheckJVMArgs(t *testing.T) {
+	inst := &core.Instance{Path: t.TempDir(), JVMPreset: "aikar", JVMArgs: []string{"-XX:+UseShenandoahGC"}}
+	l := NewLauncher(&Options{Instance: inst, Config: &config.Config{}}, nil)
+	l.javaMajor = 21
+
+	var jvmErr *JVMArgsError
+	if err := l.checkJVMArgs(); !errors.As(err, &jvmErr) || len(jvmErr.Conflicts) != 1 {
+		t.Fatalf("err = %v, want one conflict", err)
+	}
+	got := strings.Join(jvmErr.Conflicts[0].Flags, ", ")
+	if got != "-XX:+UseG1GC (Aikar's G1 preset), -XX:+UseShenandoahGC (JVM arguments)" {
+		t.Errorf("Flags = %s", got)
+	}
+
+	inst.JVMPreset, inst.JVMArgs = "nonesuch", nil
+	if err := l.checkJVMArgs(); err == nil || !strings.Contains(err.Error(), `unknown JVM preset "nonesuch"`) {
+		t.Errorf("err = %v, want the unknown preset reported", err)
+	}
+}
+
+func TestLaunch_JVMConflictsStopBeforeDownloads(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	inst := &core.Instance{Path: dir, JVMPreset: "aikar", JVMArgs: []string{"-XX:+UseZGC"}}
+	version := &core.VersionDetails{
+		ID: "1.21.4",
+		Libraries: []core.Library{{
+			Name:      "com.example:lib:1.0.0",
+			Downloads: &core.LibraryDownloads{Artifact: &core.Artifact{Path: "lib.jar", URL: srv.URL + "/lib.jar", Size: 1}},
+		}},
+	}
+	l := NewLauncher(&Options{
+		Instance:    inst,
+		VersionInfo: version,
+		JavaPath:    "java",
+		Config:      &config.Config{DataDir: dir, LibrariesDir: dir},
+	}, nil)
+	l.javaMajor = 21
+
+	var jvmErr *JVMArgsError
+	if err := l.Launch(context.Background()); !errors.As(err, &jvmErr) {
+		t.Fatalf("Launch() = %v, want a JVMArgsError", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("%d download request(s) made before the conflict was reported", n)
+	}
+}
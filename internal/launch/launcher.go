@@ -5,13 +5,15 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aayushdutt/mctui/internal/config"
@@ -27,7 +29,9 @@ type Status struct {
 	Message    string  // Human-readable message
 	IsComplete bool
 	Error      error
-	LogLine    *LogLine // Streamed log output
+	LogLine    *LogLine           // Streamed log output
+	LogFile    string             // Full session log being written, sent once the game starts
+	Downloads  *download.Progress // Per-file detail while a download step runs
 }
 
 // Options contains launch configuration
@@ -41,9 +45,18 @@ type Options struct {
 	AccessToken string // Auth Token
 	Config      *config.Config
 
-	// Callbacks
+	// QuickPlay, when set, launches straight into a world, server, or Realm.
+	QuickPlay *core.QuickPlay
+
+	// Supervisor, when set, tracks the game process while it runs.
+	Supervisor *Supervisor
+
+	// Callbacks, called from the launch goroutine. Instance is the launcher's
+	// own copy; changes to it that should outlive the launch are passed to
+	// UpdateInstance as an edit to make to the stored instance.
 	UpdateLastPlayed func(id string) error
-	UpdateInstance   func(inst *core.Instance) error
+	UpdateInstance   func(id string, edit func(*core.Instance)) error
+	RecordSession    func(id string, s core.Session) error
 }
 
 // LogLine represents a line of log output
@@ -57,6 +70,10 @@ type Launcher struct {
 	opts       *Options
 	statusChan chan<- Status
 	cfg        *config.Config
+
+	javaMajor  int               // major version of the chosen Java, once known
+	analyzer   *logAnalyzer      // watches game output for known failures; set per game run
+	sessionLog *sessionLogWriter // full, unfiltered game output; nil if it couldn't be created
 }
 
 // NewLauncher creates a new launcher
@@ -77,7 +94,7 @@ func (l *Launcher) Launch(ctx context.Context) error {
 		name string
 		fn   func(context.Context) error
 	}{
-		{"Checking Java", l.checkJava},
+		{"Checking Java", l.checkRuntime},
 		{"Downloading libraries", l.downloadLibraries},
 		{"Downloading assets", l.downloadAssets},
 		{"Preparing game", l.prepareGame},
@@ -103,11 +120,7 @@ func (l *Launcher) Launch(ctx context.Context) error {
 
 	// Mark instance as fully downloaded for future offline launches
 	if l.opts.Instance != nil && l.opts.UpdateInstance != nil {
-		inst := l.opts.Instance
-		inst.IsFullyDownloaded = true
-		inst.CachedAt = time.Now()
-		inst.DownloadCacheKey = core.LaunchDownloadKey(inst)
-		_ = l.opts.UpdateInstance(inst)
+		l.markDownloaded()
 	}
 
 	l.sendStatus(Status{
@@ -129,53 +142,101 @@ func (l *Launcher) sendStatus(s Status) {
 	}
 }
 
+// checkRuntime picks the Java to launch with, then checks the JVM options
+// against it, so a conflicting preset or argument stops the launch before
+// libraries and assets are downloaded.
+func (l *Launcher) checkRuntime(ctx context.Context) error {
+	if err := l.checkJava(ctx); err != nil {
+		return err
+	}
+	return l.checkJVMArgs()
+}
+
 func (l *Launcher) checkJava(ctx context.Context) error {
-	// 1. Check explicit override or instance setting
+	// 1. Check explicit override
 	if l.opts.JavaPath != "" {
 		return nil
 	}
 
+	req := l.javaRequirement()
+	if !req.Satisfiable() {
+		return fmt.Errorf("no Java fits: %s needs Java %d or newer, but %s needs Java %d or older",
+			req.MinReason, req.Min, req.MaxReason, req.Max)
+	}
+
+	// 2. The instance's own Java must be able to run it; otherwise stop here
+	// and offer a runtime that can, rather than failing inside the JVM.
 	if l.opts.Instance != nil && l.opts.Instance.JavaPath != "" {
 		if _, err := os.Stat(l.opts.Instance.JavaPath); err == nil {
+			if err := l.preflightJava(l.opts.Instance.JavaPath, req); err != nil {
+				return err
+			}
 			l.opts.JavaPath = l.opts.Instance.JavaPath
 			l.sendStatus(Status{Step: "Checking Java", Message: "Using instance Java"})
 			return nil
 		}
 	}
 
-	// Determine required Java version
-	requiredVersion := 8
-	if l.opts.VersionInfo != nil && l.opts.VersionInfo.JavaVersion.MajorVersion > 0 {
-		requiredVersion = l.opts.VersionInfo.JavaVersion.MajorVersion
+	// 3. The global default, when it fits
+	if l.cfg != nil && l.cfg.JavaPath != "" {
+		if _, err := os.Stat(l.cfg.JavaPath); err == nil {
+			var mismatch *JavaMismatchError
+			if err := l.preflightJava(l.cfg.JavaPath, req); errors.As(err, &mismatch) {
+				l.sendStatus(Status{Step: "Checking Java", Message: fmt.Sprintf("Default Java doesn't fit (%s); picking another", mismatch.Problem)})
+			} else {
+				// Not recorded on the instance, so changing the default moves it along.
+				l.opts.JavaPath = l.cfg.JavaPath
+				l.sendStatus(Status{Step: "Checking Java", Message: "Using default Java"})
+				return nil
+			}
+		}
 	}
 
-	// 2. Check managed java directory
-	configDir, _ := os.UserConfigDir()
-	if configDir != "" {
-		managedJavaDir := filepath.Join(configDir, "mctui", "java", fmt.Sprintf("%d", requiredVersion))
-		if exe, err := java.NewDownloader().FindJavaExecutable(managedJavaDir); err == nil {
-			l.commitJavaPath(exe)
-			l.sendStatus(Status{Step: "Checking Java", Message: fmt.Sprintf("Using managed Java %d", requiredVersion)})
-			return nil
+	// Determine the Java version to look for and the Mojang runtime component
+	// that provides it: the version's own, unless a mod needs newer
+	requiredVersion := req.Min
+	component := ""
+	if v := l.opts.VersionInfo; v != nil && v.JavaVersion.MajorVersion == requiredVersion {
+		component = v.JavaVersion.Component
+	}
+	if component == "" {
+		component = java.MojangComponent(requiredVersion)
+	}
+
+	// 4. Check managed java directory: the Mojang runtime, then an Adoptium JRE
+	javaBaseDir := ""
+	if l.cfg != nil && l.cfg.DataDir != "" {
+		javaBaseDir = java.ManagedDir(l.cfg.DataDir)
+		var managed []string
+		if component != "" {
+			managed = append(managed, component)
+		}
+		managed = append(managed, fmt.Sprintf("%d", requiredVersion))
+		for _, name := range managed {
+			if exe, err := java.NewDownloader().FindJavaExecutable(filepath.Join(javaBaseDir, name)); err == nil {
+				l.commitJavaPath(exe)
+				l.sendStatus(Status{Step: "Checking Java", Message: fmt.Sprintf("Using managed Java %s", name)})
+				return nil
+			}
 		}
 	}
 
-	// 3. System-wide detection
-	if inst := java.NewDetector().FindBest(requiredVersion); inst != nil {
+	// 5. System-wide detection
+	if inst := java.NewDetector().FindCompatible(req); inst != nil {
 		l.commitJavaPath(inst.Path)
+		l.javaMajor = inst.MajorVersion
 		l.sendStatus(Status{Step: "Checking Java", Message: fmt.Sprintf("Using %s", java.FormatInstallation(inst))})
 		return nil
 	}
 
-	// 4. Download Java
+	// 6. Download Java
 	l.sendStatus(Status{Step: "Downloading Java", Message: fmt.Sprintf("Downloading Java %d...", requiredVersion)})
 
-	if configDir == "" {
-		return fmt.Errorf("could not determine config directory for java download")
+	if javaBaseDir == "" {
+		return fmt.Errorf("could not determine data directory for java download")
 	}
 
-	javaBaseDir := filepath.Join(configDir, "mctui", "java")
-	exePath, err := java.NewDownloader().DownloadRuntime(ctx, requiredVersion, javaBaseDir, func(msg string) {
+	exePath, err := java.NewDownloader().Install(ctx, component, requiredVersion, javaBaseDir, func(msg string) {
 		l.sendStatus(Status{Step: "Downloading Java", Message: msg})
 	})
 	if err != nil {
@@ -191,11 +252,37 @@ func (l *Launcher) checkJava(ctx context.Context) error {
 func (l *Launcher) commitJavaPath(path string) {
 	l.opts.JavaPath = path
 	if l.opts.Instance != nil && l.opts.UpdateInstance != nil {
-		l.opts.Instance.JavaPath = path
-		_ = l.opts.UpdateInstance(l.opts.Instance)
+		l.updateInstance(func(inst *core.Instance) { inst.JavaPath = path })
 	}
 }
 
+// updateInstance makes edit to the launcher's instance and passes it on to be
+// made to the stored one.
+func (l *Launcher) updateInstance(edit func(*core.Instance)) {
+	edit(l.opts.Instance)
+	_ = l.opts.UpdateInstance(l.opts.Instance.ID, edit)
+}
+
+// markDownloaded records that every file the instance's version and loader
+// need is in place, so launches can skip checking them.
+func (l *Launcher) markDownloaded() {
+	now, key := time.Now(), core.LaunchDownloadKey(l.opts.Instance)
+	l.updateInstance(func(inst *core.Instance) {
+		inst.IsFullyDownloaded = true
+		inst.CachedAt = now
+		inst.DownloadCacheKey = key
+	})
+}
+
+// clearDownloadCache makes the next launch check every file again.
+func (l *Launcher) clearDownloadCache() {
+	l.updateInstance(func(inst *core.Instance) {
+		inst.IsFullyDownloaded = false
+		inst.CachedAt = time.Time{}
+		inst.DownloadCacheKey = ""
+	})
+}
+
 func (l *Launcher) downloadLibraries(ctx context.Context) error {
 	// Check for cancellation
 	select {
@@ -208,11 +295,18 @@ func (l *Launcher) downloadLibraries(ctx context.Context) error {
 		return nil
 	}
 
-	// Optimization: Skip if already fully downloaded
+	// Optimization: Skip if already fully downloaded. Natives classifiers are the
+	// exception: instances cached before they were fetched still need them once.
 	if l.opts.Instance != nil && l.opts.Instance.IsFullyDownloaded {
-		return nil
+		return l.performDownload(ctx, "Downloading libraries", l.missingNativeItems(), 2)
 	}
 
+	return l.performDownload(ctx, "Downloading libraries", l.libraryItems(), 4)
+}
+
+// libraryItems lists every library, natives classifier and the client jar the
+// version needs on this platform.
+func (l *Launcher) libraryItems() []download.Item {
 	var items []download.Item
 	for _, lib := range l.opts.VersionInfo.Libraries {
 		// Check rules
@@ -220,19 +314,22 @@ func (l *Launcher) downloadLibraries(ctx context.Context) error {
 			continue
 		}
 
-		if lib.Downloads == nil || lib.Downloads.Artifact == nil {
+		if lib.Downloads == nil {
 			continue
 		}
 
-		artifact := lib.Downloads.Artifact
-		destPath := filepath.Join(l.cfg.LibrariesDir, artifact.Path)
-
-		items = append(items, download.Item{
-			URL:  artifact.URL,
-			Path: destPath,
-			SHA1: artifact.SHA1,
-			Size: artifact.Size,
-		})
+		// Regular classpath jar plus, for pre-1.19 LWJGL, the platform natives classifier
+		for _, artifact := range []*core.Artifact{lib.Downloads.Artifact, nativeArtifact(&lib)} {
+			if artifact == nil {
+				continue
+			}
+			items = append(items, download.Item{
+				URL:  artifact.URL,
+				Path: filepath.Join(l.cfg.LibrariesDir, artifact.Path),
+				SHA1: artifact.SHA1,
+				Size: artifact.Size,
+			})
+		}
 	}
 
 	// Download client jar
@@ -241,15 +338,16 @@ func (l *Launcher) downloadLibraries(ctx context.Context) error {
 		clientPath := filepath.Join(l.cfg.LibrariesDir, "com", "mojang", "minecraft",
 			l.opts.VersionInfo.ID, fmt.Sprintf("minecraft-%s-client.jar", l.opts.VersionInfo.ID))
 
+		// The client jar dwarfs the libraries; start it first so it isn't the straggler.
 		items = append(items, download.Item{
-			URL:  client.URL,
-			Path: clientPath,
-			SHA1: client.SHA1,
-			Size: client.Size,
+			URL:      client.URL,
+			Path:     clientPath,
+			SHA1:     client.SHA1,
+			Size:     client.Size,
+			Priority: 1,
 		})
 	}
-
-	return l.performDownload(ctx, "Downloading libraries", items, 4)
+	return items
 }
 
 // invalidateStaleDownloadCache clears IsFullyDownloaded when version/loader/LoaderVer changed
@@ -266,10 +364,7 @@ func (l *Launcher) invalidateStaleDownloadCache() {
 	if inst.DownloadCacheKey == want {
 		return
 	}
-	inst.IsFullyDownloaded = false
-	inst.CachedAt = time.Time{}
-	inst.DownloadCacheKey = ""
-	_ = l.opts.UpdateInstance(inst)
+	l.clearDownloadCache()
 }
 
 func (l *Launcher) downloadAssets(ctx context.Context) error {
@@ -289,27 +384,41 @@ func (l *Launcher) downloadAssets(ctx context.Context) error {
 		return nil
 	}
 
-	assetIndex := l.opts.VersionInfo.AssetIndex
-	indexPath := filepath.Join(l.cfg.AssetsDir, "indexes", assetIndex.ID+".json")
+	indexItem := l.assetIndexItem()
 
 	// Download asset index if needed
-	if _, err := os.Stat(indexPath); os.IsNotExist(err) {
+	if _, err := os.Stat(indexItem.Path); os.IsNotExist(err) {
 		mgr := download.NewManager(1)
-		_, err := mgr.Download(ctx, []download.Item{{
-			URL:  assetIndex.URL,
-			Path: indexPath,
-			SHA1: assetIndex.SHA1,
-			Size: assetIndex.Size,
-		}}, nil)
+		_, err := mgr.Download(ctx, []download.Item{indexItem}, nil)
 		if err != nil {
 			return fmt.Errorf("downloading asset index: %w", err)
 		}
 	}
 
+	items, err := l.assetItems(indexItem.Path)
+	if err != nil {
+		return err
+	}
+	return l.performDownload(ctx, "Downloading assets", items, 8)
+}
+
+// assetIndexItem is the version's asset index.
+func (l *Launcher) assetIndexItem() download.Item {
+	assetIndex := l.opts.VersionInfo.AssetIndex
+	return download.Item{
+		URL:  assetIndex.URL,
+		Path: filepath.Join(l.cfg.AssetsDir, "indexes", assetIndex.ID+".json"),
+		SHA1: assetIndex.SHA1,
+		Size: assetIndex.Size,
+	}
+}
+
+// assetItems lists the asset objects named by the index at indexPath.
+func (l *Launcher) assetItems(indexPath string) ([]download.Item, error) {
 	// Parse asset index
 	indexData, err := os.ReadFile(indexPath)
 	if err != nil {
-		return fmt.Errorf("reading asset index: %w", err)
+		return nil, fmt.Errorf("reading asset index: %w", err)
 	}
 
 	var index struct {
@@ -319,7 +428,7 @@ func (l *Launcher) downloadAssets(ctx context.Context) error {
 		} `json:"objects"`
 	}
 	if err := json.Unmarshal(indexData, &index); err != nil {
-		return fmt.Errorf("parsing asset index: %w", err)
+		return nil, fmt.Errorf("parsing asset index: %w", err)
 	}
 
 	// Build download list
@@ -335,8 +444,7 @@ func (l *Launcher) downloadAssets(ctx context.Context) error {
 			Size: obj.Size,
 		})
 	}
-
-	return l.performDownload(ctx, "Downloading assets", items, 8)
+	return items, nil
 }
 
 func (l *Launcher) prepareGame(ctx context.Context) error {
@@ -357,45 +465,136 @@ func (l *Launcher) prepareGame(ctx context.Context) error {
 		}
 	}
 
-	return nil
+	return l.extractNatives()
 }
 
 func (l *Launcher) launchGame(ctx context.Context) error {
 	args := l.buildArguments()
 	inst := l.opts.Instance
 
+	if q := l.quickPlay(); q != nil && q.Kind != core.QuickPlayMultiplayer && !supportsQuickPlay(l.opts.VersionInfo) {
+		l.sendStatus(Status{Step: "Launching", Message: fmt.Sprintf("Minecraft %s can't open a %s directly; starting at the title screen.", inst.Version, q.Kind)})
+	}
+
+	if warning := l.memoryWarning(); warning != "" {
+		l.sendStatus(Status{Step: "Launching", Message: "Memory: " + warning})
+	}
+
 	gameDir := filepath.Join(inst.Path, ".minecraft")
 
-	cmd := exec.CommandContext(ctx, l.opts.JavaPath, args...)
-	cmd.Dir = gameDir
+	detached := l.detached()
+	cmdCtx := ctx
+	if detached {
+		// Not tied to the launch context, so nothing on our side ends the game early.
+		cmdCtx = context.Background()
+	}
+	cmd, err := l.gameCommand(cmdCtx, args)
+	if err != nil {
+		return err
+	}
 
-	// Capture output
-	stdout, _ := cmd.StdoutPipe()
-	stderr, _ := cmd.StderrPipe()
+	env := l.processEnv(gameDir)
+	if err := l.runPreLaunchHook(ctx, gameDir, env); err != nil {
+		return err
+	}
+	cmd.Dir = gameDir
+	cmd.Env = env
+
+	started := time.Now()
+	l.sessionLog = nil
+	sl, slErr := createSessionLog(inst.Path, started, l.sessionLogRetention())
+	if slErr == nil {
+		l.sessionLog = sl
+		started = sl.started // matches the log's name, so the session record can be paired with it
+		defer sl.close()
+	}
+
+	// Capture output. A detached game writes straight to its session log, since
+	// pipes to mctui would break when it exits.
+	var stdout, stderr io.Reader
+	if detached {
+		if slErr != nil {
+			return fmt.Errorf("creating session log for detached game: %w", slErr)
+		}
+		detachProcess(cmd)
+		cmd.Stdout, cmd.Stderr = sl.f, sl.f
+	} else {
+		stdout, _ = cmd.StdoutPipe()
+		stderr, _ = cmd.StderrPipe()
+	}
 
+	crashSnap := snapshotCrashFiles(gameDir)
 	if err := cmd.Start(); err != nil {
+		if sl != nil {
+			_ = os.Remove(sl.path) // nothing was written; don't list an empty session
+		}
 		return err
 	}
 
-	l.sendStatus(Status{
-		Step:    "Playing",
-		Message: "Game running...",
-	})
+	playing := Status{Step: "Playing", Message: "Game running..."}
+	switch {
+	case slErr != nil:
+		playing.Message = fmt.Sprintf("Game running... (session log unavailable: %v)", slErr)
+	case detached:
+		playing.LogFile = sl.path
+		playing.Message = "Game running... (keeps running if you quit mctui)"
+	default:
+		playing.LogFile = sl.path
+	}
+	if sup := l.opts.Supervisor; sup != nil {
+		p := &Process{
+			InstanceID: inst.ID,
+			PID:        cmd.Process.Pid,
+			Started:    started,
+			Account:    l.getPlayerName(),
+			Offline:    l.opts.Offline,
+			LogFile:    playing.LogFile,
+			Detached:   detached,
+			proc:       cmd.Process,
+		}
+		if detached {
+			p.StartID = processStartID(p.PID)
+		}
+		sup.add(p)
+	}
+	l.sendStatus(playing)
 
 	// Update last played struct
 	if l.opts.UpdateLastPlayed != nil {
 		l.opts.UpdateLastPlayed(inst.ID)
 	}
 
-	// Stream logs
-	go l.streamLog(stdout, "stdout")
-	go l.streamLog(stderr, "stderr")
+	l.analyzer = newLogAnalyzer()
+	if detached {
+		err = l.waitDetached(cmd, sl.path)
+	} else {
+		// Stream logs. Both pipes must be drained before Wait, which closes them.
+		var streams sync.WaitGroup
+		streams.Add(2)
+		go func() { defer streams.Done(); l.streamLog(stdout, "stdout") }()
+		go func() { defer streams.Done(); l.streamLog(stderr, "stderr") }()
+		streams.Wait()
+		err = cmd.Wait()
+	}
 
-	// Wait for game to finish
-	err := cmd.Wait()
+	stopped := false
+	if l.opts.Supervisor != nil {
+		stopped = l.opts.Supervisor.remove(inst.ID)
+	}
+	l.recordSession(started, cmd.ProcessState)
+	l.runPostExitHook(gameDir, env, exitCode(cmd.ProcessState))
 
 	// Send final message
-	if err != nil {
+	if err != nil && !stopped {
+		if ctx.Err() == nil {
+			crash := findCrashReport(gameDir, crashSnap)
+			if crash != nil {
+				l.analyzer.observeFile(crash.Path)
+			}
+			if diagnoses := l.analyzer.diagnoses(); crash != nil || len(diagnoses) > 0 {
+				return &GameExitError{Err: err, Crash: crash, Diagnoses: diagnoses}
+			}
+		}
 		return fmt.Errorf("game exited with error: %w", err)
 	}
 
@@ -404,63 +603,103 @@ func (l *Launcher) launchGame(ctx context.Context) error {
 	return nil
 }
 
+// sessionLogRetention is how many session logs to keep per instance.
+func (l *Launcher) sessionLogRetention() int {
+	if l.cfg != nil && l.cfg.SessionLogRetention > 0 {
+		return l.cfg.SessionLogRetention
+	}
+	return DefaultSessionLogRetention
+}
+
+// recordSession logs the finished play session and adds it to the instance's playtime.
+func (l *Launcher) recordSession(started time.Time, state *os.ProcessState) {
+	if l.opts.RecordSession == nil {
+		return
+	}
+	s := core.NewSession(started, time.Now(), exitCode(state), l.getPlayerName(), l.opts.Offline)
+	if err := l.opts.RecordSession(l.opts.Instance.ID, s); err != nil {
+		l.sendStatus(Status{Step: "Playing", Message: fmt.Sprintf("Couldn't record play session: %v", err)})
+	}
+}
+
 func (l *Launcher) streamLog(r io.Reader, apiType string) {
 	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		text := scanner.Text()
-		verb := LogVerbosityError
-		if l.cfg != nil {
-			verb = ParseLaunchLogVerbosity(l.cfg.LaunchLogVerbosity)
+		if l.sessionLog != nil {
+			l.sessionLog.writeLine(apiType, text)
 		}
-		if !shouldEmitGameLogLine(verb, text) {
-			continue
-		}
-		l.sendStatus(Status{
-			Step: "Playing",
-			LogLine: &LogLine{
-				Text: text,
-				Type: apiType,
-			},
-		})
+		l.handleLogLine(text, apiType)
 	}
+	// Keep draining after an oversized line so the game never blocks on a full pipe.
+	_, _ = io.Copy(io.Discard, r)
 }
 
+// handleLogLine feeds one line of game output to the analyzer and, if it passes
+// the verbosity filter, to the launch view.
+func (l *Launcher) handleLogLine(text, apiType string) {
+	if l.analyzer != nil {
+		l.analyzer.observe(text)
+	}
+	verb := LogVerbosityError
+	if l.cfg != nil {
+		verb = ParseLaunchLogVerbosity(l.cfg.LaunchLogVerbosity)
+	}
+	if !shouldEmitGameLogLine(verb, text) {
+		return
+	}
+	l.sendStatus(Status{
+		Step: "Playing",
+		LogLine: &LogLine{
+			Text: text,
+			Type: apiType,
+		},
+	})
+}
+
+// buildArguments assembles the full java command line: the version's JVM arguments
+// (or the legacy equivalents), the user's JVM arguments, the main class, then game arguments.
 func (l *Launcher) buildArguments() []string {
-	var args []string
 	version := l.opts.VersionInfo
-	inst := l.opts.Instance
+	rc := l.ruleContext()
+	vars := l.argumentVars()
 
-	// JVM arguments - use instance settings first, then config defaults
-	if len(inst.JVMArgs) > 0 {
-		args = append(args, inst.JVMArgs...)
-	} else if len(l.cfg.JVMArgs) > 0 {
-		args = append(args, l.cfg.JVMArgs...)
-	} else {
-		// Sensible defaults
-		args = append(args, config.DefaultJVMArgs()...)
+	var jvm []string
+	if version.Arguments != nil {
+		jvm = expandArguments(version.Arguments.JVM, rc)
 	}
-
-	// MacOS specific flags for LWJGL
-	if runtime.GOOS == "darwin" {
-		args = append(args, "-XstartOnFirstThread")
+	// Legacy versions leave the core JVM flags to the launcher. A loader profile merged
+	// onto a legacy parent may still add a few JVM args, so key off the classpath
+	// placeholder rather than whether any JVM args exist at all.
+	if !containsArg(jvm, "${classpath}") {
+		jvm = append(legacyJVMArguments(rc), jvm...)
 	}
 
-	// Native library path
-	nativesDir := filepath.Join(inst.Path, "natives")
-	args = append(args, fmt.Sprintf("-Djava.library.path=%s", nativesDir))
-
-	// Classpath
-	classpath := l.buildClasspath()
-	args = append(args, "-cp", classpath)
-
-	// Main class
+	args := substituteAll(jvm, vars)
+	args = append(args, l.jvmArgs()...)
 	args = append(args, version.MainClass)
+	args = append(args, l.buildGameArguments(rc, vars)...)
+	return args
+}
 
-	// Game arguments
-	gameArgs := l.buildGameArguments()
-	args = append(args, gameArgs...)
+// userJVMArgs returns the instance JVM arguments, or else the config's.
+func (l *Launcher) userJVMArgs() []string {
+	if inst := l.opts.Instance; inst != nil && (len(inst.JVMArgs) > 0 || inst.JVMArgsOverride) {
+		return inst.JVMArgs
+	}
+	if l.cfg != nil {
+		return l.cfg.JVMArgs
+	}
+	return nil
+}
 
-	return args
+func containsArg(args []string, want string) bool {
+	for _, a := range args {
+		if a == want {
+			return true
+		}
+	}
+	return false
 }
 
 func (l *Launcher) buildClasspath() string {
@@ -494,16 +733,90 @@ func (l *Launcher) buildClasspath() string {
 		version.ID, fmt.Sprintf("minecraft-%s-client.jar", version.ID))
 	addPath(clientPath)
 
-	separator := ":"
+	return strings.Join(paths, classpathSeparator())
+}
+
+func classpathSeparator() string {
 	if runtime.GOOS == "windows" {
-		separator = ";"
+		return ";"
+	}
+	return ":"
+}
+
+func (l *Launcher) buildGameArguments(rc ruleContext, vars map[string]string) []string {
+	version := l.opts.VersionInfo
+	var args []string
+	if hasModernGameArgs(version) {
+		args = substituteAll(expandArguments(version.Arguments.Game, rc), vars)
+	} else if version.MinecraftArguments != "" {
+		// Legacy format
+		args = substituteAll(strings.Fields(version.MinecraftArguments), vars)
 	}
+	args = append(args, l.windowArgs()...)
+	return append(args, l.legacyQuickPlayArgs()...)
+}
 
-	return strings.Join(paths, separator)
+// hasModernGameArgs reports whether the version uses the 1.13+ "arguments.game" list
+// rather than the legacy minecraftArguments string.
+func hasModernGameArgs(version *core.VersionDetails) bool {
+	return version.Arguments != nil && len(version.Arguments.Game) > 0
 }
 
-func (l *Launcher) buildGameArguments() []string {
+// ruleContext describes this host and launch for evaluating version JSON rules.
+func (l *Launcher) ruleContext() ruleContext {
+	return ruleContext{
+		OSName:    mojangOSName(runtime.GOOS),
+		OSVersion: hostOSVersion(),
+		Arch:      mojangArch(runtime.GOARCH),
+		Features:  l.features(),
+	}
+}
+
+// features reports which optional argument groups this launch enables.
+func (l *Launcher) features() core.Features {
+	var f core.Features
+	if w, h, _ := l.windowSettings(); w > 0 && h > 0 {
+		f.HasCustomRes = true
+	}
+	l.quickPlayFeatures(&f)
+	return f
+}
+
+// windowSettings resolves the game window size and fullscreen flag: instance overrides
+// first, then the global config. Width/height are both zero when neither sets a size.
+func (l *Launcher) windowSettings() (width, height int, fullscreen bool) {
+	inst := l.opts.Instance
+	if l.cfg != nil {
+		width, height, fullscreen = l.cfg.WindowWidth, l.cfg.WindowHeight, l.cfg.Fullscreen
+	}
+	if inst != nil && inst.WindowWidth > 0 && inst.WindowHeight > 0 {
+		width, height = inst.WindowWidth, inst.WindowHeight
+	}
+	if inst != nil && inst.Fullscreen != nil {
+		fullscreen = *inst.Fullscreen
+	}
+	if width <= 0 || height <= 0 {
+		width, height = 0, 0
+	}
+	return width, height, fullscreen
+}
+
+// windowArgs returns the window flags the version JSON doesn't spell out itself:
+// --width/--height for legacy minecraftArguments, and --fullscreen for every version.
+func (l *Launcher) windowArgs() []string {
 	var args []string
+	width, height, fullscreen := l.windowSettings()
+	if width > 0 && !hasModernGameArgs(l.opts.VersionInfo) {
+		args = append(args, "--width", strconv.Itoa(width), "--height", strconv.Itoa(height))
+	}
+	if fullscreen {
+		args = append(args, "--fullscreen")
+	}
+	return args
+}
+
+// argumentVars is the ${placeholder} table shared by JVM and game arguments.
+func (l *Launcher) argumentVars() map[string]string {
 	version := l.opts.VersionInfo
 	inst := l.opts.Instance
 
@@ -521,46 +834,39 @@ func (l *Launcher) buildGameArguments() []string {
 		userType = "msa"
 	}
 
-	// Replacement map
-	replacements := map[string]string{
-		"${auth_player_name}":  l.getPlayerName(),
-		"${version_name}":      version.ID,
-		"${game_directory}":    filepath.Join(inst.Path, ".minecraft"),
-		"${assets_root}":       l.cfg.AssetsDir,
-		"${assets_index_name}": version.AssetIndex.ID,
-		"${auth_uuid}":         uuid,
-		"${auth_access_token}": token,
-		"${user_type}":         userType,
-		"${version_type}":      string(version.Type),
-		"${user_properties}":   "{}",
-	}
-
-	// Process arguments
-	if version.Arguments != nil && len(version.Arguments.Game) > 0 {
-		for _, arg := range version.Arguments.Game {
-			switch v := arg.(type) {
-			case string:
-				args = append(args, l.replaceVars(v, replacements))
-			case map[string]interface{}:
-				// Complex rules - skip for now
-			}
-		}
-	} else if version.MinecraftArguments != "" {
-		// Legacy format
-		for _, arg := range strings.Split(version.MinecraftArguments, " ") {
-			args = append(args, l.replaceVars(arg, replacements))
-		}
-	}
-
-	return args
-}
-
-func (l *Launcher) replaceVars(s string, replacements map[string]string) string {
-	result := s
-	for k, v := range replacements {
-		result = strings.ReplaceAll(result, k, v)
-	}
-	return result
+	gameAssets := l.cfg.AssetsDir
+	if version.AssetIndex.ID == "legacy" {
+		gameAssets = filepath.Join(l.cfg.AssetsDir, "virtual", "legacy")
+	}
+
+	vars := map[string]string{
+		"auth_player_name":    l.getPlayerName(),
+		"version_name":        version.ID,
+		"game_directory":      filepath.Join(inst.Path, ".minecraft"),
+		"assets_root":         l.cfg.AssetsDir,
+		"game_assets":         gameAssets,
+		"assets_index_name":   version.AssetIndex.ID,
+		"auth_uuid":           uuid,
+		"auth_access_token":   token,
+		"auth_session":        fmt.Sprintf("token:%s:%s", token, uuid),
+		"auth_xuid":           "0",
+		"clientid":            "0",
+		"user_type":           userType,
+		"version_type":        string(version.Type),
+		"user_properties":     "{}",
+		"natives_directory":   l.nativesDir(),
+		"launcher_name":       launcherName,
+		"launcher_version":    LauncherVersion,
+		"classpath":           l.buildClasspath(),
+		"classpath_separator": classpathSeparator(),
+		"library_directory":   l.cfg.LibrariesDir,
+	}
+	if w, h, _ := l.windowSettings(); w > 0 {
+		vars["resolution_width"] = strconv.Itoa(w)
+		vars["resolution_height"] = strconv.Itoa(h)
+	}
+	l.quickPlayVars(vars)
+	return vars
 }
 
 func (l *Launcher) getPlayerName() string {
@@ -571,45 +877,29 @@ func (l *Launcher) getPlayerName() string {
 }
 
 func (l *Launcher) libraryApplies(lib *core.Library) bool {
-	if len(lib.Rules) == 0 {
-		return true
-	}
-
-	allowed := false
-	for _, rule := range lib.Rules {
-		applies := true
-
-		if rule.OS != nil {
-			osName := runtime.GOOS
-			if rule.OS.Name != "" {
-				// Map Go OS names to Mojang names
-				osMap := map[string]string{
-					"darwin":  "osx",
-					"linux":   "linux",
-					"windows": "windows",
-				}
-				if mapped, ok := osMap[osName]; ok {
-					osName = mapped
-				}
-				if rule.OS.Name != osName {
-					applies = false
-				}
-			}
-		}
+	return rulesAllow(lib.Rules, l.ruleContext())
+}
 
-		if applies {
-			allowed = rule.Action == "allow"
-		}
+func (l *Launcher) performDownload(ctx context.Context, stepName string, items []download.Item, workerCount int) error {
+	result, err := l.runDownload(ctx, stepName, items, workerCount, false)
+	if err != nil {
+		return err
 	}
-
-	return allowed
+	// A *download.BatchError, listing which files failed and why.
+	return result.Err()
 }
-func (l *Launcher) performDownload(ctx context.Context, stepName string, items []download.Item, workerCount int) error {
+
+// runDownload downloads items, forwarding progress as stepName's status. With
+// rehash, files already in place are hashed even if the hash index trusts them.
+func (l *Launcher) runDownload(ctx context.Context, stepName string, items []download.Item, workerCount int, rehash bool) (*download.Result, error) {
 	if len(items) == 0 {
-		return nil
+		return &download.Result{}, nil
 	}
 
 	mgr := download.NewManager(workerCount)
+	if rehash {
+		mgr.Rehash()
+	}
 	progressChan := make(chan download.Progress, 10)
 
 	// Forward progress
@@ -621,23 +911,20 @@ func (l *Launcher) performDownload(ctx context.Context, stepName string, items [
 			} else if p.TotalItems > 0 {
 				percent = float64(p.CompletedItems) / float64(p.TotalItems)
 			}
+			msg := fmt.Sprintf("%d/%d files, %s", p.CompletedItems, p.TotalItems, download.FormatSpeed(p.Speed))
+			if p.ETA > 0 {
+				msg += fmt.Sprintf(", %s left", p.ETA.Round(time.Second))
+			}
 			l.sendStatus(Status{
-				Step:     stepName,
-				Progress: percent,
-				Message:  fmt.Sprintf("Downloading %s (%s)", p.CurrentItem, download.FormatSpeed(p.Speed)),
+				Step:      stepName,
+				Progress:  percent,
+				Message:   msg,
+				Downloads: &p,
 			})
 		}
 	}()
 
 	result, err := mgr.Download(ctx, items, progressChan)
 	close(progressChan)
-
-	if err != nil {
-		return err
-	}
-	if result.Failed > 0 {
-		return fmt.Errorf("%d items failed to download", result.Failed)
-	}
-
-	return nil
+	return result, err
 }
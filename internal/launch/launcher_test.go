@@ -3,6 +3,9 @@ package launch
 import (
 	"context"
 	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"testing"
 	"time"
 
@@ -137,3 +140,70 @@ func contains(s, substr string) bool {
 	return len(s) >= len(substr) &&
 		(s[0:len(substr)] == substr || contains(s[1:], substr))
 }
+
+// fakeJava writes dir/bin/java, which reports version as `java -version` would.
+func fakeJava(t *testing.T, dir, version string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake java is a shell script")
+	}
+	bin := filepath.Join(dir, "bin")
+	os.MkdirAll(bin, 0755)
+	exe := filepath.Join(bin, "java")
+	script := "#!/bin/sh\necho 'openjdk version \"" + version + "\"' >&2\necho 'OpenJDK 64-Bit Server VM' >&2\n"
+	if err := os.WriteFile(exe, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return exe
+}
+
+func TestLauncher_CheckJavaOrder(t *testing.T) {
+	dir := t.TempDir()
+	globalJava := fakeJava(t, filepath.Join(dir, "global"), "21.0.7")
+	instJava := fakeJava(t, filepath.Join(dir, "inst"), "21.0.2")
+	cfg := &config.Config{DataDir: dir, JavaPath: globalJava}
+	version := &core.VersionDetails{JavaVersion: core.JavaVersionReq{MajorVersion: 21}}
+
+	// The default applies to an instance without its own, and isn't recorded on it.
+	inst := &core.Instance{ID: "a"}
+	updated := false
+	l := NewLauncher(&Options{Instance: inst, VersionInfo: version, Config: cfg, UpdateInstance: func(string, func(*core.Instance)) error {
+		updated = true
+		return nil
+	}}, nil)
+	if err := l.checkJava(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if l.opts.JavaPath != globalJava || inst.JavaPath != "" || updated {
+		t.Errorf("JavaPath = %q, instance %q (updated %v); want the default, unrecorded", l.opts.JavaPath, inst.JavaPath, updated)
+	}
+
+	// An instance's own Java wins over the default.
+	inst = &core.Instance{ID: "b", JavaPath: instJava}
+	l = NewLauncher(&Options{Instance: inst, VersionInfo: version, Config: cfg}, nil)
+	if err := l.checkJava(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if l.opts.JavaPath != instJava {
+		t.Errorf("JavaPath = %q, want the instance's", l.opts.JavaPath)
+	}
+}
+
+func TestUserJVMArgs_InstanceReplacesGlobal(t *testing.T) {
+	cfg := &config.Config{JVMArgs: []string{"-XX:+UseG1GC"}}
+	tests := []struct {
+		name string
+		inst *core.Instance
+		want []string
+	}{
+		{"inherits", &core.Instance{}, []string{"-XX:+UseG1GC"}},
+		{"own args", &core.Instance{JVMArgs: []string{"-Dfoo=bar"}}, []string{"-Dfoo=bar"}},
+		{"overridden with none", &core.Instance{JVMArgsOverride: true}, nil},
+	}
+	for _, tt := range tests {
+		l := NewLauncher(&Options{Instance: tt.inst, Config: cfg}, nil)
+		if got := l.userJVMArgs(); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: userJVMArgs = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
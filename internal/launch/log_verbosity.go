@@ -117,3 +117,21 @@ func shouldEmitGameLogLine(verb LogVerbosity, line string) bool {
 	sev := classifyJavaLogLine(line)
 	return sev >= verb.minSeverity()
 }
+
+// LineLevel is the lowest verbosity at which line is shown: LogVerbosityError for
+// errors and stack traces, LogVerbosityWarn for warnings, LogVerbosityAll otherwise.
+func LineLevel(line string) LogVerbosity {
+	switch classifyJavaLogLine(line) {
+	case logSeverityError:
+		return LogVerbosityError
+	case logSeverityWarn:
+		return LogVerbosityWarn
+	default:
+		return LogVerbosityAll
+	}
+}
+
+// Allows reports whether line is shown at verbosity v.
+func (v LogVerbosity) Allows(line string) bool {
+	return shouldEmitGameLogLine(v, line)
+}
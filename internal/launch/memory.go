@@ -0,0 +1,47 @@
+package launch
+
+import (
+	"github.com/aayushdutt/mctui/internal/memory"
+	"github.com/aayushdutt/mctui/internal/mods"
+)
+
+// heapMB is the instance's Java heap: its own setting, then the global one,
+// then memory.DefaultMinMB and a recommendation from the machine's RAM and the
+// instance's mods.
+func (l *Launcher) heapMB() (minMB, maxMB int) {
+	inst := l.opts.Instance
+	if inst != nil {
+		minMB, maxMB = inst.MinMemoryMB, inst.MaxMemoryMB
+	}
+	if l.cfg != nil {
+		if minMB == 0 {
+			minMB = l.cfg.MinMemoryMB
+		}
+		if maxMB == 0 {
+			maxMB = l.cfg.MaxMemoryMB
+		}
+	}
+	if minMB == 0 {
+		minMB = memory.DefaultMinMB
+	}
+	if maxMB == 0 {
+		sys, _ := memory.ReadSystem()
+		modCount := 0
+		if inst != nil {
+			jars, _ := mods.ListInstalledJars(inst)
+			modCount = len(jars)
+		}
+		maxMB = memory.Recommend(sys.TotalMB, modCount).MaxMB
+	}
+	return min(minMB, maxMB), maxMB
+}
+
+// memoryWarning says why the heap may not fit in the machine's memory, or ""
+// when it fits or the memory can't be read.
+func (l *Launcher) memoryWarning() string {
+	sys, err := memory.ReadSystem()
+	if err != nil {
+		return ""
+	}
+	return memory.Warning(memory.MaxHeapMB(l.jvmArgs()), sys)
+}
@@ -0,0 +1,173 @@
+package launch
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/download"
+)
+
+// mojangOSName maps a Go GOOS value to the OS name used by version JSON rules and natives maps.
+func mojangOSName(goos string) string {
+	switch goos {
+	case "darwin":
+		return "osx"
+	default:
+		return goos
+	}
+}
+
+// archBits is the value substituted for ${arch} in natives classifiers ("natives-windows-${arch}").
+func archBits(goarch string) string {
+	switch goarch {
+	case "386", "arm", "mips", "mipsle":
+		return "32"
+	default:
+		return "64"
+	}
+}
+
+// nativeArtifact returns the natives classifier artifact for the running platform, or nil
+// when the library ships no natives for it (or isn't a natives library at all).
+func nativeArtifact(lib *core.Library) *core.Artifact {
+	return nativeArtifactFor(lib, runtime.GOOS, runtime.GOARCH)
+}
+
+func nativeArtifactFor(lib *core.Library, goos, goarch string) *core.Artifact {
+	if lib == nil || len(lib.Natives) == 0 || lib.Downloads == nil {
+		return nil
+	}
+	classifier, ok := lib.Natives[mojangOSName(goos)]
+	if !ok || classifier == "" {
+		return nil
+	}
+	classifier = strings.ReplaceAll(classifier, "${arch}", archBits(goarch))
+	return lib.Downloads.Classifiers[classifier]
+}
+
+// missingNativeItems lists natives classifier jars that are not on disk yet.
+func (l *Launcher) missingNativeItems() []download.Item {
+	var items []download.Item
+	for _, lib := range l.opts.VersionInfo.Libraries {
+		if !l.libraryApplies(&lib) {
+			continue
+		}
+		artifact := nativeArtifact(&lib)
+		if artifact == nil {
+			continue
+		}
+		path := filepath.Join(l.cfg.LibrariesDir, artifact.Path)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		items = append(items, download.Item{
+			URL:  artifact.URL,
+			Path: path,
+			SHA1: artifact.SHA1,
+			Size: artifact.Size,
+		})
+	}
+	return items
+}
+
+// nativesDir is where classifier natives are unpacked; it is passed as java.library.path.
+func (l *Launcher) nativesDir() string {
+	return filepath.Join(l.opts.Instance.Path, "natives")
+}
+
+// extractNatives clears the instance natives directory and unpacks every applicable
+// natives classifier jar into it. Re-extracting on each launch keeps the directory in
+// sync when the instance switches Minecraft version (LWJGL2 and LWJGL3 natives clash).
+func (l *Launcher) extractNatives() error {
+	dir := l.nativesDir()
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("clearing natives: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating natives dir: %w", err)
+	}
+	if l.opts.VersionInfo == nil {
+		return nil
+	}
+
+	for _, lib := range l.opts.VersionInfo.Libraries {
+		if !l.libraryApplies(&lib) {
+			continue
+		}
+		artifact := nativeArtifact(&lib)
+		if artifact == nil {
+			continue
+		}
+		var exclude []string
+		if lib.Extract != nil {
+			exclude = lib.Extract.Exclude
+		}
+		jarPath := filepath.Join(l.cfg.LibrariesDir, artifact.Path)
+		if err := extractNativesJar(jarPath, dir, exclude); err != nil {
+			return fmt.Errorf("extracting natives from %s: %w", lib.Name, err)
+		}
+	}
+	return nil
+}
+
+// extractNativesJar unpacks jarPath into destDir, skipping directories and any entry whose
+// name starts with one of the exclude prefixes (e.g. "META-INF/").
+func extractNativesJar(jarPath, destDir string, exclude []string) error {
+	r, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	root := filepath.Clean(destDir) + string(os.PathSeparator)
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() || excludedEntry(f.Name, exclude) {
+			continue
+		}
+		target := filepath.Join(destDir, filepath.FromSlash(f.Name))
+		// Never write outside destDir (zip-slip).
+		if !strings.HasPrefix(target, root) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := extractZipEntry(f, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractZipEntry(f *zip.File, target string) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, rc); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func excludedEntry(name string, exclude []string) bool {
+	for _, prefix := range exclude {
+		if prefix != "" && strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package launch
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aayushdutt/mctui/internal/core"
+)
+
+func TestNativeArtifactFor(t *testing.T) {
+	lib := &core.Library{
+		Name: "org.lwjgl.lwjgl:lwjgl-platform:2.9.4-nightly-20150209",
+		Natives: map[string]string{
+			"linux":   "natives-linux",
+			"osx":     "natives-osx",
+			"windows": "natives-windows-${arch}",
+		},
+		Downloads: &core.LibraryDownloads{
+			Classifiers: map[string]*core.Artifact{
+				"natives-linux":      {Path: "linux.jar"},
+				"natives-osx":        {Path: "osx.jar"},
+				"natives-windows-64": {Path: "win64.jar"},
+				"natives-windows-32": {Path: "win32.jar"},
+			},
+		},
+	}
+
+	tests := []struct {
+		goos, goarch string
+		want         string
+	}{
+		{"linux", "amd64", "linux.jar"},
+		{"darwin", "arm64", "osx.jar"},
+		{"windows", "amd64", "win64.jar"},
+		{"windows", "386", "win32.jar"},
+		{"freebsd", "amd64", ""},
+	}
+	for _, tt := range tests {
+		got := nativeArtifactFor(lib, tt.goos, tt.goarch)
+		gotPath := ""
+		if got != nil {
+			gotPath = got.Path
+		}
+		if gotPath != tt.want {
+			t.Errorf("nativeArtifactFor(%s/%s) = %q, want %q", tt.goos, tt.goarch, gotPath, tt.want)
+		}
+	}
+
+	if nativeArtifactFor(&core.Library{Name: "plain:jar:1.0"}, "linux", "amd64") != nil {
+		t.Error("library without natives should yield nil")
+	}
+}
+
+func TestExtractNativesJar_HonorsExclude(t *testing.T) {
+	tmp := t.TempDir()
+	jarPath := filepath.Join(tmp, "natives.jar")
+	writeTestJar(t, jarPath, map[string]string{
+		"liblwjgl.so":          "lwjgl",
+		"libopenal.so":         "openal",
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0",
+		"../escape.so":         "nope",
+	})
+
+	dest := filepath.Join(tmp, "natives")
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := extractNativesJar(jarPath, dest, []string{"META-INF/"}); err != nil {
+		t.Fatalf("extractNativesJar: %v", err)
+	}
+
+	for _, name := range []string{"liblwjgl.so", "libopenal.so"} {
+		if _, err := os.Stat(filepath.Join(dest, name)); err != nil {
+			t.Errorf("%s not extracted: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dest, "META-INF")); !os.IsNotExist(err) {
+		t.Errorf("META-INF should be excluded, stat err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmp, "escape.so")); !os.IsNotExist(err) {
+		t.Errorf("zip-slip entry escaped dest, stat err = %v", err)
+	}
+}
+
+func writeTestJar(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for name, body := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,91 @@
+package launch
+
+import (
+	"fmt"
+
+	"github.com/aayushdutt/mctui/internal/java"
+	"github.com/aayushdutt/mctui/internal/loader"
+	"github.com/aayushdutt/mctui/internal/memory"
+	"github.com/aayushdutt/mctui/internal/mods"
+)
+
+// JavaMismatchError is returned by [Launcher.Launch] when the Java an instance
+// is set to use can't run it. Alternative, when set, is an installed runtime
+// that can.
+type JavaMismatchError struct {
+	Path        string
+	Found       *java.Installation // nil when the binary didn't run
+	Requirement java.Requirement
+	Problem     string
+	Alternative *java.Runtime
+}
+
+func (e *JavaMismatchError) Error() string {
+	return fmt.Sprintf("instance Java %s can't run it: %s", e.Path, e.Problem)
+}
+
+// max32BitHeapMB is about the largest heap a 32-bit JVM can reserve.
+const max32BitHeapMB = 1536
+
+// javaRequirement is the range of Java the instance can run on: what its
+// version asks for, Java 8 at most for versions old enough to break on newer
+// ones, what its Fabric mods declare, and 64-bit when the heap is too large
+// for a 32-bit JVM.
+func (l *Launcher) javaRequirement() java.Requirement {
+	var req java.Requirement
+	name := "this version"
+	if inst := l.opts.Instance; inst != nil && inst.Version != "" {
+		name = "Minecraft " + inst.Version
+	}
+	req.RequireAtLeast(8, name)
+
+	if v := l.opts.VersionInfo; v != nil {
+		if v.JavaVersion.MajorVersion > 0 {
+			req.RequireAtLeast(v.JavaVersion.MajorVersion, name)
+		}
+		// Versions from before 1.13's argument format (LaunchWrapper, LWJGL 2)
+		// fail on Java 9 and newer.
+		if v.Arguments == nil && v.MinecraftArguments != "" && req.Min <= 8 {
+			req.RequireAtMost(8, name)
+		}
+	}
+
+	if inst := l.opts.Instance; inst != nil && loader.ParseKind(inst.Loader) == loader.KindFabric {
+		for _, dep := range mods.JavaDependencies(inst) {
+			if dep.Min > 0 {
+				req.RequireAtLeast(dep.Min, dep.Mod)
+			}
+			if dep.Max > 0 {
+				req.RequireAtMost(dep.Max, dep.Mod)
+			}
+		}
+	}
+
+	if heap := memory.MaxHeapMB(l.jvmArgs()); heap > max32BitHeapMB {
+		req.Need64Bit = true
+	}
+	return req
+}
+
+// preflightJava runs the java at path and checks it against req, returning a
+// [JavaMismatchError] that offers a compatible installed runtime when it can't
+// run the instance.
+func (l *Launcher) preflightJava(path string, req java.Requirement) error {
+	found, err := java.NewDetector().Inspect(path)
+	problem := ""
+	if err != nil {
+		problem = fmt.Sprintf("it didn't run (%v)", err)
+	} else {
+		problem = req.Problem(found)
+	}
+	if problem == "" {
+		l.javaMajor = found.MajorVersion
+		return nil
+	}
+
+	mismatch := &JavaMismatchError{Path: path, Found: found, Requirement: req, Problem: problem}
+	if l.cfg != nil && l.cfg.DataDir != "" {
+		mismatch.Alternative = java.PickRuntime(java.FindRuntimes(l.cfg.DataDir), req)
+	}
+	return mismatch
+}
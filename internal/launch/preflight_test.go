@@ -0,0 +1,104 @@
+package launch
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aayushdutt/mctui/internal/config"
+	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/java"
+	"github.com/aayushdutt/mctui/internal/memory"
+)
+
+// writeFabricMod writes a mod jar into inst's mods folder with fabric.mod.json.
+func writeFabricMod(t *testing.T, inst *core.Instance, name, modJSON string) {
+	t.Helper()
+	dir := filepath.Join(inst.Path, ".minecraft", "mods")
+	os.MkdirAll(dir, 0755)
+	f, err := os.Create(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	w, _ := zw.Create("fabric.mod.json")
+	w.Write([]byte(modJSON))
+	zw.Close()
+}
+
+func TestJavaRequirement(t *testing.T) {
+	legacy := &core.VersionDetails{MinecraftArguments: "--username ${auth_player_name}", JavaVersion: core.JavaVersionReq{MajorVersion: 8}}
+	modern := &core.VersionDetails{Arguments: &core.Arguments{}, JavaVersion: core.JavaVersionReq{MajorVersion: 17}}
+
+	l := NewLauncher(&Options{Instance: &core.Instance{Version: "1.12.2"}, VersionInfo: legacy}, nil)
+	if req := l.javaRequirement(); req.Min != 8 || req.Max != 8 || req.MaxReason != "Minecraft 1.12.2" {
+		t.Errorf("legacy = %+v, want exactly Java 8", req)
+	}
+
+	inst := &core.Instance{Version: "1.20.1", Loader: "fabric", Path: t.TempDir(), JVMArgs: []string{"-Xmx4G"}}
+	writeFabricMod(t, inst, "sodium.jar", `{"id":"sodium","name":"Sodium","depends":{"java":">=21"}}`)
+	writeFabricMod(t, inst, "plain.jar", `{"id":"plain","depends":{"minecraft":"1.20.1"}}`)
+	l = NewLauncher(&Options{Instance: inst, VersionInfo: modern}, nil)
+	req := l.javaRequirement()
+	if req.Min != 21 || req.MinReason != "Sodium" || req.Max != 0 || !req.Need64Bit {
+		t.Errorf("fabric = %+v, want Java 21+ for Sodium, 64-bit for a 4G heap", req)
+	}
+}
+
+func TestCheckJava_MismatchOffersAlternative(t *testing.T) {
+	dir := t.TempDir()
+	old := fakeJava(t, filepath.Join(dir, "jdk8"), "1.8.0_402")
+	managed := fakeJava(t, filepath.Join(java.ManagedDir(dir), "java-runtime-delta"), "21.0.7")
+	version := &core.VersionDetails{Arguments: &core.Arguments{}, JavaVersion: core.JavaVersionReq{Component: "java-runtime-delta", MajorVersion: 21}}
+
+	inst := &core.Instance{ID: "a", Version: "1.21.4", JavaPath: old}
+	l := NewLauncher(&Options{Instance: inst, VersionInfo: version, Config: &config.Config{DataDir: dir}}, nil)
+	err := l.checkJava(context.Background())
+	var mismatch *JavaMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("err = %v, want a JavaMismatchError", err)
+	}
+	if !strings.Contains(mismatch.Problem, "Java 8 is too old: Minecraft 1.21.4 needs Java 21") {
+		t.Errorf("Problem = %q", mismatch.Problem)
+	}
+	if mismatch.Alternative == nil || mismatch.Alternative.Path != managed {
+		t.Errorf("Alternative = %+v, want the managed Java 21", mismatch.Alternative)
+	}
+
+	// A default that doesn't fit is passed over for one that does.
+	inst = &core.Instance{ID: "b", Version: "1.21.4"}
+	l = NewLauncher(&Options{Instance: inst, VersionInfo: version, Config: &config.Config{DataDir: dir, JavaPath: old}}, nil)
+	if err := l.checkJava(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if l.opts.JavaPath != managed {
+		t.Errorf("JavaPath = %q, want the managed runtime over the Java 8 default", l.opts.JavaPath)
+	}
+}
+
+func TestHeapMB(t *testing.T) {
+	inst := &core.Instance{Path: t.TempDir(), MaxMemoryMB: 6144}
+	l := NewLauncher(&Options{Instance: inst, Config: &config.Config{MinMemoryMB: 1024, MaxMemoryMB: 3072}}, nil)
+	if minMB, maxMB := l.heapMB(); minMB != 1024 || maxMB != 6144 {
+		t.Errorf("heapMB = %d, %d; want the global minimum and the instance maximum", minMB, maxMB)
+	}
+
+	// Unset everywhere: the default minimum and a recommendation.
+	inst.MaxMemoryMB = 0
+	l = NewLauncher(&Options{Instance: inst, Config: &config.Config{}}, nil)
+	minMB, maxMB := l.heapMB()
+	if minMB != memory.DefaultMinMB || maxMB < 1024 {
+		t.Errorf("heapMB = %d, %d; want %d and a recommendation", minMB, maxMB, memory.DefaultMinMB)
+	}
+
+	// A size in the JVM arguments comes after the settings, so it wins.
+	inst.JVMArgs = []string{"-Xmx12G"}
+	if heap := memory.MaxHeapMB(l.jvmArgs()); heap != 12288 {
+		t.Errorf("effective heap = %d, want the -Xmx from the JVM arguments", heap)
+	}
+}
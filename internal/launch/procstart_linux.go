@@ -0,0 +1,39 @@
+package launch
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// processStartID identifies the process pid beyond its PID, which the kernel
+// reuses: the boot ID plus the start time in /proc/<pid>/stat. Empty when the
+// process doesn't exist or /proc can't be read.
+func processStartID(pid int) string {
+	stat, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return ""
+	}
+	start := parseStatStartTime(string(stat))
+	if start == "" {
+		return ""
+	}
+	boot, _ := os.ReadFile("/proc/sys/kernel/random/boot_id")
+	return strings.TrimSpace(string(boot)) + ":" + start
+}
+
+// parseStatStartTime returns field 22 (starttime) of a /proc/<pid>/stat line.
+// The command name in field 2 may contain spaces and parentheses, so fields
+// are counted from its closing parenthesis.
+func parseStatStartTime(stat string) string {
+	i := strings.LastIndexByte(stat, ')')
+	if i < 0 {
+		return ""
+	}
+	fields := strings.Fields(stat[i+1:])
+	const startTimeField = 22 - 3 // fields after the name start at field 3 (state)
+	if len(fields) <= startTimeField {
+		return ""
+	}
+	return fields[startTimeField]
+}
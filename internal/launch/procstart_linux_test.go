@@ -0,0 +1,27 @@
+package launch
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseStatStartTime(t *testing.T) {
+	// The command name may contain spaces and parentheses.
+	stat := "4242 (java (my game)) S 1 4242 4242 0 -1 4194560 1 0 0 0 5 3 0 0 20 0 30 0 987654 1000 100\n"
+	if got := parseStatStartTime(stat); got != "987654" {
+		t.Errorf("parseStatStartTime() = %q, want 987654", got)
+	}
+	if got := parseStatStartTime("4242 (java) S 1"); got != "" {
+		t.Errorf("short stat: got %q, want empty", got)
+	}
+}
+
+func TestProcessStartID_Stable(t *testing.T) {
+	id := processStartID(os.Getpid())
+	if id == "" {
+		t.Fatal("no start ID for the running test process")
+	}
+	if again := processStartID(os.Getpid()); again != id {
+		t.Errorf("start ID changed: %q then %q", id, again)
+	}
+}
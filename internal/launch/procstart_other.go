@@ -0,0 +1,20 @@
+//go:build !linux && !windows
+
+package launch
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// processStartID identifies the process pid beyond its PID, which the OS
+// reuses: its start time as reported by ps. Empty when the process doesn't
+// exist or ps fails.
+func processStartID(pid int) string {
+	out, err := exec.Command("ps", "-o", "lstart=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
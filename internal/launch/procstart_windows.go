@@ -0,0 +1,21 @@
+package launch
+
+import (
+	"strconv"
+	"syscall"
+)
+
+// processStartID identifies the process pid beyond its PID, which Windows
+// reuses: its creation time. Empty when the process can't be opened.
+func processStartID(pid int) string {
+	h, err := syscall.OpenProcess(syscall.PROCESS_QUERY_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return ""
+	}
+	defer syscall.CloseHandle(h)
+	var creation, exit, kernel, user syscall.Filetime
+	if err := syscall.GetProcessTimes(h, &creation, &exit, &kernel, &user); err != nil {
+		return ""
+	}
+	return strconv.FormatInt(creation.Nanoseconds(), 10)
+}
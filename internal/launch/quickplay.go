@@ -0,0 +1,101 @@
+package launch
+
+import (
+	"net"
+	"strings"
+
+	"github.com/aayushdutt/mctui/internal/core"
+)
+
+const defaultServerPort = "25565"
+
+// quickPlay returns the requested Quick Play target, or nil when none (or an invalid one) is set.
+func (l *Launcher) quickPlay() *core.QuickPlay {
+	if q := l.opts.QuickPlay; q.Valid() {
+		return q
+	}
+	return nil
+}
+
+// supportsQuickPlay reports whether the version's game arguments carry the
+// rule-gated --quickPlay* entries (23w14a / 1.20 and later).
+func supportsQuickPlay(version *core.VersionDetails) bool {
+	if version == nil || version.Arguments == nil {
+		return false
+	}
+	for _, arg := range version.Arguments.Game {
+		m, ok := arg.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch v := m["value"].(type) {
+		case string:
+			if strings.HasPrefix(v, "--quickPlay") {
+				return true
+			}
+		case []interface{}:
+			for _, s := range v {
+				if str, ok := s.(string); ok && strings.HasPrefix(str, "--quickPlay") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// quickPlayFeatures enables the feature flag matching the requested target on versions that support it.
+func (l *Launcher) quickPlayFeatures(f *core.Features) {
+	q := l.quickPlay()
+	if q == nil || !supportsQuickPlay(l.opts.VersionInfo) {
+		return
+	}
+	switch q.Kind {
+	case core.QuickPlaySingleplayer:
+		f.IsQuickPlaySingle = true
+	case core.QuickPlayMultiplayer:
+		f.IsQuickPlayMulti = true
+	case core.QuickPlayRealms:
+		f.IsQuickPlayRealms = true
+	}
+}
+
+// quickPlayVars fills the ${quickPlay*} placeholders for the requested target.
+func (l *Launcher) quickPlayVars(vars map[string]string) {
+	q := l.quickPlay()
+	if q == nil {
+		return
+	}
+	switch q.Kind {
+	case core.QuickPlaySingleplayer:
+		vars["quickPlaySingleplayer"] = q.Target
+	case core.QuickPlayMultiplayer:
+		host, port := splitServerAddress(q.Target)
+		vars["quickPlayMultiplayer"] = net.JoinHostPort(host, port)
+	case core.QuickPlayRealms:
+		vars["quickPlayRealms"] = q.Target
+	}
+}
+
+// legacyQuickPlayArgs returns the pre-1.20 fallback: --server/--port for multiplayer
+// targets. Older versions cannot open a world or Realm directly, so those yield nil.
+func (l *Launcher) legacyQuickPlayArgs() []string {
+	q := l.quickPlay()
+	if q == nil || q.Kind != core.QuickPlayMultiplayer || supportsQuickPlay(l.opts.VersionInfo) {
+		return nil
+	}
+	host, port := splitServerAddress(q.Target)
+	return []string{"--server", host, "--port", port}
+}
+
+// splitServerAddress splits "host[:port]" (IPv6 literals in brackets) and defaults the port to 25565.
+func splitServerAddress(addr string) (host, port string) {
+	addr = strings.TrimSpace(addr)
+	if h, p, err := net.SplitHostPort(addr); err == nil {
+		if p == "" {
+			p = defaultServerPort
+		}
+		return h, p
+	}
+	return strings.Trim(addr, "[]"), defaultServerPort
+}
@@ -0,0 +1,91 @@
+package launch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/aayushdutt/mctui/internal/config"
+	"github.com/aayushdutt/mctui/internal/core"
+)
+
+func TestSplitServerAddress(t *testing.T) {
+	tests := []struct {
+		in, host, port string
+	}{
+		{"play.example.net", "play.example.net", "25565"},
+		{"play.example.net:25570", "play.example.net", "25570"},
+		{"[::1]:25566", "::1", "25566"},
+		{"[::1]", "::1", "25565"},
+	}
+	for _, tt := range tests {
+		host, port := splitServerAddress(tt.in)
+		if host != tt.host || port != tt.port {
+			t.Errorf("splitServerAddress(%q) = %q, %q; want %q, %q", tt.in, host, port, tt.host, tt.port)
+		}
+	}
+}
+
+func quickPlayLauncher(t *testing.T, version *core.VersionDetails, q *core.QuickPlay) *Launcher {
+	t.Helper()
+	tmp := t.TempDir()
+	return NewLauncher(&Options{
+		Instance:    &core.Instance{Path: tmp},
+		VersionInfo: version,
+		QuickPlay:   q,
+		Config:      &config.Config{LibrariesDir: tmp, AssetsDir: tmp},
+	}, nil)
+}
+
+func TestQuickPlay_ModernArguments(t *testing.T) {
+	var args core.Arguments
+	err := json.Unmarshal([]byte(`{"game": [
+		"--version", "${version_name}",
+		{"rules": [{"action": "allow", "features": {"is_quick_play_singleplayer": true}}], "value": ["--quickPlaySingleplayer", "${quickPlaySingleplayer}"]},
+		{"rules": [{"action": "allow", "features": {"is_quick_play_multiplayer": true}}], "value": ["--quickPlayMultiplayer", "${quickPlayMultiplayer}"]},
+		{"rules": [{"action": "allow", "features": {"is_quick_play_realms": true}}], "value": ["--quickPlayRealms", "${quickPlayRealms}"]}
+	]}`), &args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	version := &core.VersionDetails{ID: "1.21.4", Arguments: &args}
+
+	l := quickPlayLauncher(t, version, &core.QuickPlay{Kind: core.QuickPlayMultiplayer, Target: "mc.example.net"})
+	got := l.buildGameArguments(l.ruleContext(), l.argumentVars())
+	want := []string{"--version", "1.21.4", "--quickPlayMultiplayer", "mc.example.net:25565"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("multiplayer args = %q, want %q", got, want)
+	}
+
+	l = quickPlayLauncher(t, version, &core.QuickPlay{Kind: core.QuickPlaySingleplayer, Target: "New World"})
+	got = l.buildGameArguments(l.ruleContext(), l.argumentVars())
+	want = []string{"--version", "1.21.4", "--quickPlaySingleplayer", "New World"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("singleplayer args = %q, want %q", got, want)
+	}
+
+	l = quickPlayLauncher(t, version, nil)
+	got = l.buildGameArguments(l.ruleContext(), l.argumentVars())
+	want = []string{"--version", "1.21.4"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("no quick play args = %q, want %q", got, want)
+	}
+}
+
+func TestQuickPlay_LegacyFallsBackToServerPort(t *testing.T) {
+	version := &core.VersionDetails{ID: "1.12.2", MinecraftArguments: "--version ${version_name}"}
+
+	l := quickPlayLauncher(t, version, &core.QuickPlay{Kind: core.QuickPlayMultiplayer, Target: "mc.example.net:25570"})
+	got := l.buildGameArguments(l.ruleContext(), l.argumentVars())
+	want := []string{"--version", "1.12.2", "--server", "mc.example.net", "--port", "25570"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("legacy multiplayer args = %q, want %q", got, want)
+	}
+
+	l = quickPlayLauncher(t, version, &core.QuickPlay{Kind: core.QuickPlayRealms, Target: "12345"})
+	got = l.buildGameArguments(l.ruleContext(), l.argumentVars())
+	want = []string{"--version", "1.12.2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("legacy realms args = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,150 @@
+package launch
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aayushdutt/mctui/internal/download"
+)
+
+// RepairReport is what [Launcher.Repair] found.
+type RepairReport struct {
+	Checked int                // Files verified, including the ones repaired
+	Missing []string           // Files that weren't there, now downloaded
+	Corrupt []string           // Files whose hash was wrong, now replaced
+	Failed  []download.Outcome // Files that couldn't be repaired
+	Profile string             // What checking the loader's cached profile found; empty for vanilla
+}
+
+// Repaired is how many files were downloaded to fix the instance.
+func (r *RepairReport) Repaired() int {
+	return len(r.Missing) + len(r.Corrupt)
+}
+
+// Summary describes the run in one line.
+func (r *RepairReport) Summary() string {
+	s := fmt.Sprintf("Checked %d files", r.Checked)
+	if n := r.Repaired(); n > 0 {
+		s += fmt.Sprintf(", repaired %d (%d missing, %d corrupt)", n, len(r.Missing), len(r.Corrupt))
+	}
+	if len(r.Failed) > 0 {
+		s += fmt.Sprintf(", %d failed", len(r.Failed))
+	} else if r.Repaired() == 0 {
+		s += ", all intact"
+	}
+	return s + "."
+}
+
+// Repair re-verifies every file the instance needs to launch: libraries,
+// natives, the client jar, the asset index and the asset objects. Unlike a
+// launch it ignores the instance's download cache and rehashes files the hash
+// index trusts, downloading whatever is missing or doesn't match. Files that
+// still fail are listed in the report; the error is for a run that couldn't
+// finish.
+func (l *Launcher) Repair(ctx context.Context) (*RepairReport, error) {
+	if l.opts.VersionInfo == nil {
+		return nil, fmt.Errorf("no version info")
+	}
+
+	report := &RepairReport{}
+	steps := []struct {
+		name string
+		fn   func(context.Context, string, *RepairReport) error
+	}{
+		{"Verifying libraries", l.repairLibraries},
+		{"Verifying assets", l.repairAssets},
+	}
+	for i, step := range steps {
+		l.sendStatus(Status{
+			Step:     step.name,
+			Progress: float64(i) / float64(len(steps)),
+			Message:  step.name + "...",
+		})
+		if err := step.fn(ctx, step.name, report); err != nil {
+			if len(report.Failed) > 0 {
+				l.recordRepair(report) // what's broken stays broken; don't let launches skip it
+			}
+			return report, fmt.Errorf("%s: %w", step.name, err)
+		}
+	}
+
+	l.recordRepair(report)
+	return report, nil
+}
+
+func (l *Launcher) repairLibraries(ctx context.Context, step string, report *RepairReport) error {
+	return l.verifyFiles(ctx, step, l.libraryItems(), 4, report)
+}
+
+func (l *Launcher) repairAssets(ctx context.Context, step string, report *RepairReport) error {
+	indexItem := l.assetIndexItem()
+	before := len(report.Failed)
+	if err := l.verifyFiles(ctx, step, []download.Item{indexItem}, 1, report); err != nil {
+		return err
+	}
+	if len(report.Failed) > before {
+		// Without the index there is no list of objects to check.
+		return fmt.Errorf("downloading asset index: %w", report.Failed[before].Err)
+	}
+
+	items, err := l.assetItems(indexItem.Path)
+	if err != nil {
+		return err
+	}
+	return l.verifyFiles(ctx, step, items, 8, report)
+}
+
+// verifyFiles rehashes items, downloads the ones missing or mismatched, and
+// records what it found in report.
+func (l *Launcher) verifyFiles(ctx context.Context, step string, items []download.Item, workerCount int, report *RepairReport) error {
+	missing := make([]bool, len(items))
+	for i, item := range items {
+		_, err := os.Stat(item.Path)
+		missing[i] = err != nil
+	}
+
+	result, err := l.runDownload(ctx, step, items, workerCount, true)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for i, o := range result.Outcomes {
+		report.Checked++
+		switch {
+		case o.Failed():
+			report.Failed = append(report.Failed, o)
+		case o.Attempts == 0:
+			// Already in place with the right hash.
+		case missing[i]:
+			report.Missing = append(report.Missing, o.Item.Path)
+		case hasChecksum(o.Item):
+			report.Corrupt = append(report.Corrupt, o.Item.Path)
+		}
+	}
+	return nil
+}
+
+// hasChecksum reports whether item can be verified at all; one that can't is
+// downloaded again without being counted as corrupt.
+func hasChecksum(item download.Item) bool {
+	return item.SHA1 != "" || item.SHA256 != "" || item.SHA512 != ""
+}
+
+// recordRepair updates the instance's download cache from a finished repair:
+// a clean run lets launches skip verification again, while failures make the
+// next launch check everything.
+func (l *Launcher) recordRepair(report *RepairReport) {
+	inst := l.opts.Instance
+	if inst == nil || l.opts.UpdateInstance == nil {
+		return
+	}
+	if len(report.Failed) == 0 {
+		l.markDownloaded()
+	} else {
+		l.clearDownloadCache()
+	}
+}
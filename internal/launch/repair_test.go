@@ -0,0 +1,132 @@
+package launch
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aayushdutt/mctui/internal/config"
+	"github.com/aayushdutt/mctui/internal/core"
+)
+
+func sha1Hex(b []byte) string {
+	sum := sha1.Sum(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestRepair(t *testing.T) {
+	files := map[string][]byte{
+		"/lib.jar":    []byte("library"),
+		"/client.jar": []byte("client jar"),
+		"/index.json": []byte(`{"objects":{}}`),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(files[r.URL.Path])
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	cfg := &config.Config{LibrariesDir: filepath.Join(dir, "libraries"), AssetsDir: filepath.Join(dir, "assets")}
+	details := &core.VersionDetails{
+		ID: "1.21",
+		Libraries: []core.Library{{
+			Name: "com.example:lib:1",
+			Downloads: &core.LibraryDownloads{Artifact: &core.Artifact{
+				Path: "com/example/lib.jar", URL: srv.URL + "/lib.jar", SHA1: sha1Hex(files["/lib.jar"]),
+			}},
+		}},
+		Downloads: core.Downloads{Client: &core.Artifact{URL: srv.URL + "/client.jar", SHA1: sha1Hex(files["/client.jar"])}},
+		AssetIndex: core.AssetIndexRef{
+			ID: "17", URL: srv.URL + "/index.json", SHA1: sha1Hex(files["/index.json"]),
+		},
+	}
+	inst := &core.Instance{ID: "i", Version: "1.21", IsFullyDownloaded: true}
+	var saved *core.Instance
+	l := NewLauncher(&Options{
+		Instance:    inst,
+		VersionInfo: details,
+		Config:      cfg,
+		UpdateInstance: func(_ string, edit func(*core.Instance)) error {
+			saved = &core.Instance{}
+			edit(saved)
+			return nil
+		},
+	}, nil)
+
+	// The library is missing, the client jar corrupt and the asset index intact.
+	clientPath := filepath.Join(cfg.LibrariesDir, "com", "mojang", "minecraft", "1.21", "minecraft-1.21-client.jar")
+	indexPath := filepath.Join(cfg.AssetsDir, "indexes", "17.json")
+	for path, content := range map[string][]byte{clientPath: []byte("truncated"), indexPath: files["/index.json"]} {
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, content, 0644)
+	}
+
+	report, err := l.Repair(context.Background())
+	if err != nil {
+		t.Fatalf("Repair: %v", err)
+	}
+	if report.Checked != 3 {
+		t.Errorf("Checked = %d, want 3", report.Checked)
+	}
+	if len(report.Missing) != 1 || filepath.Base(report.Missing[0]) != "lib.jar" {
+		t.Errorf("Missing = %v, want the library", report.Missing)
+	}
+	if len(report.Corrupt) != 1 || report.Corrupt[0] != clientPath {
+		t.Errorf("Corrupt = %v, want the client jar", report.Corrupt)
+	}
+	if len(report.Failed) != 0 {
+		t.Errorf("Failed = %v, want none", report.Failed)
+	}
+	if got, _ := os.ReadFile(clientPath); string(got) != "client jar" {
+		t.Errorf("client jar = %q, want it replaced", got)
+	}
+	if saved == nil || !saved.IsFullyDownloaded || saved.DownloadCacheKey != core.LaunchDownloadKey(inst) {
+		t.Errorf("a clean repair should mark the instance downloaded, got %+v", saved)
+	}
+
+	// A second run finds everything intact.
+	report, err = l.Repair(context.Background())
+	if err != nil || report.Repaired() != 0 || len(report.Failed) != 0 {
+		t.Fatalf("second Repair: %v, %+v", err, report)
+	}
+	if got, want := report.Summary(), "Checked 3 files, all intact."; got != want {
+		t.Errorf("Summary = %q, want %q", got, want)
+	}
+}
+
+func TestRepair_FailedFileClearsDownloadCache(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	cfg := &config.Config{LibrariesDir: filepath.Join(dir, "libraries"), AssetsDir: filepath.Join(dir, "assets")}
+	inst := &core.Instance{ID: "i", Version: "1.21", IsFullyDownloaded: true, DownloadCacheKey: "k"}
+	l := NewLauncher(&Options{
+		Instance: inst,
+		VersionInfo: &core.VersionDetails{
+			ID:         "1.21",
+			Downloads:  core.Downloads{Client: &core.Artifact{URL: srv.URL + "/client.jar", SHA1: sha1Hex([]byte("x"))}},
+			AssetIndex: core.AssetIndexRef{ID: "17", URL: srv.URL + "/index.json"},
+		},
+		Config:         cfg,
+		UpdateInstance: func(string, func(*core.Instance)) error { return nil },
+	}, nil)
+
+	report, err := l.Repair(context.Background())
+	if err == nil {
+		t.Fatal("Repair should fail without an asset index")
+	}
+	if len(report.Failed) != 2 {
+		t.Errorf("Failed = %d outcomes, want the client jar and the index", len(report.Failed))
+	}
+	if inst.IsFullyDownloaded {
+		t.Error("files that failed to repair should clear the download cache")
+	}
+}
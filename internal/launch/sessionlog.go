@@ -0,0 +1,176 @@
+package launch
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultSessionLogRetention is how many session logs are kept per instance when
+// config.SessionLogRetention is unset.
+const DefaultSessionLogRetention = 20
+
+// stderrTag prefixes stderr lines in session logs.
+const stderrTag = "[stderr] "
+
+// sessionLogTimeLayout names session log files; it sorts chronologically. The
+// milliseconds keep launches in the same second apart.
+const sessionLogTimeLayout = "2006-01-02_15-04-05.000"
+
+// sessionLogParseLayout reads session log names. Parsing accepts a fraction
+// after the seconds, so names from before milliseconds were added still parse.
+const sessionLogParseLayout = "2006-01-02_15-04-05"
+
+// sessionLogNameAttempts bounds how many later names createSessionLog tries
+// when a session log for the same millisecond already exists.
+const sessionLogNameAttempts = 100
+
+// SessionLogDir is where full, unfiltered game output is kept for an instance.
+func SessionLogDir(instPath string) string {
+	return filepath.Join(instPath, "logs", "mctui")
+}
+
+// SessionLog is one persisted game session's output.
+type SessionLog struct {
+	Path    string
+	Started time.Time
+	Size    int64
+}
+
+// ListSessionLogs returns an instance's session logs, newest first.
+func ListSessionLogs(instPath string) ([]SessionLog, error) {
+	entries, err := os.ReadDir(SessionLogDir(instPath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var logs []SessionLog
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".log") {
+			continue
+		}
+		started, err := time.ParseInLocation(sessionLogParseLayout, strings.TrimSuffix(name, ".log"), time.Local)
+		if err != nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		logs = append(logs, SessionLog{Path: filepath.Join(SessionLogDir(instPath), name), Started: started, Size: info.Size()})
+	}
+	sort.Slice(logs, func(i, j int) bool { return logs[i].Started.After(logs[j].Started) })
+	return logs, nil
+}
+
+// ReadSessionLog returns the lines of a session log.
+func ReadSessionLog(path string) ([]string, error) {
+	lines, _, err := ReadSessionLogFrom(path, 0)
+	return lines, err
+}
+
+// ReadSessionLogFrom returns the complete lines written after byte offset, and the
+// offset to resume from. A trailing partial line is left for the next call, so a
+// viewer can tail a log the game is still writing.
+func ReadSessionLogFrom(path string, offset int64) (lines []string, next int64, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, offset, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, offset, err
+	}
+	end := bytes.LastIndexByte(data, '\n')
+	if end < 0 {
+		return nil, offset, nil
+	}
+	for _, line := range strings.Split(string(data[:end]), "\n") {
+		lines = append(lines, strings.TrimSuffix(line, "\r"))
+	}
+	return lines, offset + int64(end) + 1, nil
+}
+
+// pruneSessionLogs deletes all but the newest keep session logs.
+func pruneSessionLogs(instPath string, keep int) error {
+	logs, err := ListSessionLogs(instPath)
+	if err != nil {
+		return err
+	}
+	for i := keep; i < len(logs); i++ {
+		if err := os.Remove(logs[i].Path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// sessionLogWriter appends game output to a session log. stdout and stderr are
+// streamed from separate goroutines, so writes are serialized.
+type sessionLogWriter struct {
+	path    string
+	started time.Time // the time in the file name
+
+	mu sync.Mutex
+	f  *os.File
+}
+
+// createSessionLog opens a new session log under instPath, first pruning older
+// logs so at most keep remain including the new one. The log is created
+// exclusively, so two sessions never share a file: if one already exists for
+// started, the next free millisecond is used and reported as the writer's start.
+func createSessionLog(instPath string, started time.Time, keep int) (*sessionLogWriter, error) {
+	dir := SessionLogDir(instPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	if keep > 0 {
+		if err := pruneSessionLogs(instPath, keep-1); err != nil {
+			return nil, fmt.Errorf("pruning old session logs: %w", err)
+		}
+	}
+	started = started.Truncate(time.Millisecond)
+	for range sessionLogNameAttempts {
+		path := filepath.Join(dir, started.Format(sessionLogTimeLayout)+".log")
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if os.IsExist(err) {
+			started = started.Add(time.Millisecond)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &sessionLogWriter{path: path, started: started, f: f}, nil
+	}
+	return nil, fmt.Errorf("no free session log name near %s", started.Format(sessionLogTimeLayout))
+}
+
+// writeLine appends one line, unbuffered so the log viewer can tail the file while
+// the game runs. stderr lines keep a stream tag so the viewer can tell them apart.
+func (s *sessionLogWriter) writeLine(stream, text string) {
+	if stream == "stderr" {
+		text = stderrTag + text
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = s.f.WriteString(text + "\n")
+}
+
+func (s *sessionLogWriter) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
@@ -0,0 +1,160 @@
+package launch
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSessionLog_WriteAndRead(t *testing.T) {
+	inst := t.TempDir()
+	started := time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local)
+	w, err := createSessionLog(inst, started, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.writeLine("stdout", "[12:00:00] [Render thread/INFO]: Hello")
+	w.writeLine("stderr", "Exception in thread \"main\"")
+	if err := w.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	logs, err := ListSessionLogs(inst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 || !logs[0].Started.Equal(started) {
+		t.Fatalf("ListSessionLogs = %+v", logs)
+	}
+	lines, err := ReadSessionLog(logs[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"[12:00:00] [Render thread/INFO]: Hello", "[stderr] Exception in thread \"main\""}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+}
+
+func TestCreateSessionLog_SameSecondGetsOwnFile(t *testing.T) {
+	inst := t.TempDir()
+	started := time.Date(2025, 3, 1, 12, 0, 0, 250*int(time.Millisecond), time.Local)
+	var writers []*sessionLogWriter
+	for range 3 {
+		w, err := createSessionLog(inst, started, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer w.close()
+		writers = append(writers, w)
+	}
+	if writers[0].path == writers[1].path || writers[1].path == writers[2].path {
+		t.Fatalf("launches in the same millisecond share a log: %s, %s, %s", writers[0].path, writers[1].path, writers[2].path)
+	}
+
+	logs, err := ListSessionLogs(inst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 3 {
+		t.Fatalf("listed %d sessions, want 3", len(logs))
+	}
+	for _, w := range writers {
+		found := false
+		for _, l := range logs {
+			found = found || (l.Path == w.path && l.Started.Equal(w.started))
+		}
+		if !found {
+			t.Errorf("session %s (started %v) not listed with its start time", w.path, w.started)
+		}
+	}
+}
+
+func TestListSessionLogs_ReadsSecondPrecisionNames(t *testing.T) {
+	inst := t.TempDir()
+	if err := os.MkdirAll(SessionLogDir(inst), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(SessionLogDir(inst)+"/2025-03-01_12-00-00.log", nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	logs, err := ListSessionLogs(inst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 || !logs[0].Started.Equal(time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local)) {
+		t.Errorf("ListSessionLogs = %+v, want the older-style log", logs)
+	}
+}
+
+func TestReadSessionLogFrom_LeavesPartialLine(t *testing.T) {
+	path := t.TempDir() + "/s.log"
+	if err := os.WriteFile(path, []byte("one\ntwo\nthr"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	lines, next, err := ReadSessionLogFrom(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(lines, []string{"one", "two"}) || next != 8 {
+		t.Fatalf("got %q next=%d", lines, next)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("ee\nfour\n")
+	f.Close()
+
+	lines, next, err = ReadSessionLogFrom(path, next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(lines, []string{"three", "four"}) || next != 19 {
+		t.Errorf("got %q next=%d", lines, next)
+	}
+}
+
+func TestCreateSessionLog_PrunesOldest(t *testing.T) {
+	inst := t.TempDir()
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local)
+	for i := 0; i < 4; i++ {
+		w, err := createSessionLog(inst, base.Add(time.Duration(i)*time.Minute), 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.close()
+	}
+	logs, err := ListSessionLogs(inst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 3 {
+		t.Fatalf("kept %d logs, want 3", len(logs))
+	}
+	if !logs[0].Started.Equal(base.Add(3*time.Minute)) || !logs[2].Started.Equal(base.Add(time.Minute)) {
+		t.Errorf("kept %v .. %v, want newest three", logs[0].Started, logs[2].Started)
+	}
+}
+
+func TestLineLevel(t *testing.T) {
+	tests := []struct {
+		line string
+		want LogVerbosity
+	}{
+		{"[12:00:00] [main/ERROR]: boom", LogVerbosityError},
+		{"\tat net.minecraft.Main.main(Main.java:1)", LogVerbosityError},
+		{"[12:00:00] [main/WARN]: careful", LogVerbosityWarn},
+		{"[12:00:00] [main/INFO]: hello", LogVerbosityAll},
+	}
+	for _, tt := range tests {
+		if got := LineLevel(tt.line); got != tt.want {
+			t.Errorf("LineLevel(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+		if !tt.want.Allows(tt.line) {
+			t.Errorf("%v.Allows(%q) = false", tt.want, tt.line)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package launch
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ErrNotRunning is returned when stopping an instance that has no running game.
+var ErrNotRunning = errors.New("instance is not running")
+
+// RunningStateFile records detached games in the data dir, so a later mctui can
+// reattach to them.
+const RunningStateFile = "running.json"
+
+// detachedPollInterval is how often a reattached game, which isn't our child and
+// so can't be waited on, is checked for exit.
+const detachedPollInterval = time.Second
+
+// Process is a running game tracked by a [Supervisor].
+type Process struct {
+	InstanceID string    `json:"instanceId"`
+	PID        int       `json:"pid"`
+	StartID    string    `json:"startId,omitempty"` // tells this process apart from a later one reusing PID
+	Started    time.Time `json:"started"`
+	Account    string    `json:"account,omitempty"` // player name the game was launched as
+	Offline    bool      `json:"offline,omitempty"`
+	LogFile    string    `json:"logFile,omitempty"` // session log being written; empty if unavailable
+	Detached   bool      `json:"-"`                 // runs in its own session and outlives mctui
+	Reattached bool      `json:"-"`                 // started by an earlier mctui; not our child
+
+	proc    *os.Process
+	stopped bool // Stop or Kill was requested, so a non-zero exit isn't a failure
+}
+
+// Supervisor tracks every game process started by a [Launcher], so several
+// instances can run at once and be stopped from anywhere in the UI. Detached
+// games are also recorded in a state file. It is safe for concurrent use.
+type Supervisor struct {
+	statePath string // empty disables the state file
+
+	mu    sync.Mutex
+	procs map[string]*Process // by instance ID
+}
+
+// NewSupervisor returns an empty supervisor that records detached games in
+// statePath (see [RunningStateFile]); an empty path keeps nothing on disk.
+func NewSupervisor(statePath string) *Supervisor {
+	return &Supervisor{statePath: statePath, procs: map[string]*Process{}}
+}
+
+// Running returns a snapshot of the running games, oldest first.
+func (s *Supervisor) Running() []Process {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Process, 0, len(s.procs))
+	for _, p := range s.procs {
+		out = append(out, *p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Started.Before(out[j].Started) })
+	return out
+}
+
+// Get returns the running game for an instance.
+func (s *Supervisor) Get(instanceID string) (Process, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.procs[instanceID]
+	if !ok {
+		return Process{}, false
+	}
+	return *p, true
+}
+
+// Stop asks an instance's game to shut down cleanly (SIGTERM, or a window close
+// request on Windows), letting it save before exiting.
+func (s *Supervisor) Stop(instanceID string) error {
+	p, ok := s.markStopped(instanceID)
+	if !ok {
+		return ErrNotRunning
+	}
+	if runtime.GOOS == "windows" {
+		return exec.Command("taskkill", "/PID", strconv.Itoa(p.PID)).Run()
+	}
+	return p.proc.Signal(syscall.SIGTERM)
+}
+
+// Kill force-kills an instance's game.
+func (s *Supervisor) Kill(instanceID string) error {
+	p, ok := s.markStopped(instanceID)
+	if !ok {
+		return ErrNotRunning
+	}
+	return p.proc.Kill()
+}
+
+// Reattach picks up the detached games recorded in the state file that are
+// still running, returning those newly tracked. Games that exited while no
+// mctui was watching are returned as ended, so their playtime can still be
+// recorded (see [Process.LastActive]), and dropped from the file. Post-exit
+// hooks don't run for either: the exit status of a process that isn't our
+// child can't be observed.
+func (s *Supervisor) Reattach() (running, ended []Process, err error) {
+	recorded, err := s.readState()
+	if err != nil {
+		return nil, nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range recorded {
+		if _, ok := s.procs[p.InstanceID]; ok {
+			continue
+		}
+		proc, ok := findProcess(p.PID, p.StartID)
+		if !ok {
+			ended = append(ended, *p)
+			continue
+		}
+		p.Detached, p.Reattached, p.proc = true, true, proc
+		s.procs[p.InstanceID] = p
+		running = append(running, *p)
+	}
+	return running, ended, s.writeStateLocked()
+}
+
+// LastActive estimates when a game that exited unobserved stopped: the last
+// write to its session log, which a detached game appends to until it exits,
+// or its start time when there is no log.
+func (p Process) LastActive() time.Time {
+	if p.LogFile != "" {
+		if info, err := os.Stat(p.LogFile); err == nil && info.ModTime().After(p.Started) {
+			return info.ModTime()
+		}
+	}
+	return p.Started
+}
+
+// WaitReattached blocks until a reattached game exits, then forgets it. The exit
+// status of a process that isn't our child is unknown, so only the record is
+// returned.
+func (s *Supervisor) WaitReattached(instanceID string) (Process, bool) {
+	p, ok := s.Get(instanceID)
+	if !ok || !p.Reattached {
+		return Process{}, false
+	}
+	for processAlive(p.proc) {
+		time.Sleep(detachedPollInterval)
+	}
+	p.stopped = s.remove(instanceID)
+	return p, true
+}
+
+func (s *Supervisor) add(p *Process) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.procs[p.InstanceID] = p
+	if p.Detached {
+		_ = s.writeStateLocked()
+	}
+}
+
+func (s *Supervisor) markStopped(instanceID string) (Process, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.procs[instanceID]
+	if !ok {
+		return Process{}, false
+	}
+	p.stopped = true
+	return *p, true
+}
+
+// remove forgets an instance's game once it has exited, reporting whether the
+// exit was requested through Stop or Kill.
+func (s *Supervisor) remove(instanceID string) (stopped bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.procs[instanceID]
+	if !ok {
+		return false
+	}
+	delete(s.procs, instanceID)
+	if p.Detached {
+		_ = s.writeStateLocked()
+	}
+	return p.stopped
+}
+
+func (s *Supervisor) readState() ([]*Process, error) {
+	if s.statePath == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(s.statePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var recorded []*Process
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return nil, err
+	}
+	return recorded, nil
+}
+
+// writeStateLocked records the tracked detached games, removing the file when
+// there are none. s.mu must be held.
+func (s *Supervisor) writeStateLocked() error {
+	if s.statePath == "" {
+		return nil
+	}
+	var detached []*Process
+	for _, p := range s.procs {
+		if p.Detached {
+			detached = append(detached, p)
+		}
+	}
+	if len(detached) == 0 {
+		if err := os.Remove(s.statePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	sort.Slice(detached, func(i, j int) bool { return detached[i].Started.Before(detached[j].Started) })
+	data, err := json.MarshalIndent(detached, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.statePath), 0755); err != nil {
+		return err
+	}
+	tmp := s.statePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.statePath)
+}
@@ -0,0 +1,121 @@
+package launch
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSupervisor_TracksAndForgetsProcesses(t *testing.T) {
+	s := NewSupervisor("")
+	now := time.Now()
+	s.add(&Process{InstanceID: "b", PID: 2, Started: now})
+	s.add(&Process{InstanceID: "a", PID: 1, Started: now.Add(-time.Minute)})
+
+	running := s.Running()
+	if len(running) != 2 || running[0].InstanceID != "a" {
+		t.Fatalf("Running() = %+v, want both processes oldest first", running)
+	}
+	if p, ok := s.Get("b"); !ok || p.PID != 2 {
+		t.Fatalf("Get(b) = %+v, %v", p, ok)
+	}
+	if _, ok := s.markStopped("a"); !ok {
+		t.Fatal("markStopped should find a running process")
+	}
+	if !s.remove("a") {
+		t.Error("remove should report a requested stop")
+	}
+	if s.remove("b") {
+		t.Error("remove should not report a stop that was never requested")
+	}
+	if len(s.Running()) != 0 {
+		t.Error("removed processes should be forgotten")
+	}
+	if err := s.Stop("a"); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("Stop on a stopped instance = %v, want ErrNotRunning", err)
+	}
+}
+
+func TestSupervisor_ReattachesRecordedDetachedGames(t *testing.T) {
+	exited := exec.Command(os.Args[0], "-test.run=^$")
+	if err := exited.Run(); err != nil {
+		t.Fatal(err)
+	}
+	statePath := filepath.Join(t.TempDir(), RunningStateFile)
+
+	first := NewSupervisor(statePath)
+	self, _ := os.FindProcess(os.Getpid())
+	first.add(&Process{InstanceID: "alive", PID: os.Getpid(), StartID: processStartID(os.Getpid()), Started: time.Now(), Account: "Steve", Detached: true, proc: self})
+	first.add(&Process{InstanceID: "gone", PID: exited.Process.Pid, Started: time.Now(), Detached: true})
+	first.add(&Process{InstanceID: "attached", PID: os.Getpid(), Started: time.Now()})
+
+	procs, ended, err := NewSupervisor(statePath).Reattach()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(procs) != 1 || procs[0].InstanceID != "alive" || procs[0].Account != "Steve" || !procs[0].Reattached {
+		t.Fatalf("Reattach() = %+v, want only the running detached game", procs)
+	}
+	if len(ended) != 1 || ended[0].InstanceID != "gone" {
+		t.Fatalf("Reattach() ended = %+v, want the game that exited while mctui was closed", ended)
+	}
+
+	first.remove("alive")
+	first.remove("gone")
+	if _, err := os.Stat(statePath); !os.IsNotExist(err) {
+		t.Errorf("state file should be removed once no detached game is tracked, stat err = %v", err)
+	}
+}
+
+func TestSupervisor_ReattachRejectsRecycledPID(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), RunningStateFile)
+	if processStartID(os.Getpid()) == "" {
+		t.Skip("process start time unavailable on this system")
+	}
+
+	// The recorded PID is alive, but it now belongs to a process that started at
+	// a different time than the game did: the PID was handed out again.
+	first := NewSupervisor(statePath)
+	first.add(&Process{InstanceID: "recycled", PID: os.Getpid(), StartID: "an earlier boot", Started: time.Now(), Detached: true})
+	first.add(&Process{InstanceID: "legacy", PID: os.Getpid(), Started: time.Now(), Detached: true})
+
+	sup := NewSupervisor(statePath)
+	procs, ended, err := sup.Reattach()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(procs) != 0 {
+		t.Fatalf("Reattach() = %+v, want no games for a reused PID", procs)
+	}
+	if len(ended) != 2 {
+		t.Errorf("Reattach() ended = %+v, want both records treated as ended games", ended)
+	}
+	if _, ok := sup.Get("recycled"); ok {
+		t.Error("a reused PID must not be tracked as the game")
+	}
+	if err := sup.Stop("recycled"); err != ErrNotRunning {
+		t.Errorf("Stop() = %v, want ErrNotRunning", err)
+	}
+}
+
+func TestProcess_LastActive(t *testing.T) {
+	started := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	logFile := filepath.Join(t.TempDir(), "game.log")
+	if err := os.WriteFile(logFile, []byte("[12:00:00] [main/INFO]: Stopping!\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	lastWrite := started.Add(90 * time.Minute)
+	if err := os.Chtimes(logFile, lastWrite, lastWrite); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := (Process{Started: started, LogFile: logFile}).LastActive(); !got.Equal(lastWrite) {
+		t.Errorf("LastActive() = %v, want the log's last write %v", got, lastWrite)
+	}
+	if got := (Process{Started: started, LogFile: filepath.Join(t.TempDir(), "missing.log")}).LastActive(); !got.Equal(started) {
+		t.Errorf("LastActive() without a log = %v, want the start %v", got, started)
+	}
+}
@@ -0,0 +1,52 @@
+package launch
+
+import (
+	"errors"
+	"strings"
+)
+
+// Word is one shell-style word from [SplitWords].
+type Word struct {
+	Text   string
+	Quoted bool // some part of the word was quoted
+}
+
+// SplitWords splits s on unquoted whitespace, removing the quotes. Single quotes
+// are literal; inside double quotes a backslash escapes the next character.
+func SplitWords(s string) ([]Word, error) {
+	var tokens []Word
+	var cur strings.Builder
+	var quote rune
+	inToken, quoted, escaped := false, false, false
+	for _, r := range s {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			cur.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote, inToken, quoted = r, true, true
+		case r == ' ' || r == '\t':
+			if inToken {
+				tokens = append(tokens, Word{Text: cur.String(), Quoted: quoted})
+				cur.Reset()
+				inToken, quoted = false, false
+			}
+		default:
+			cur.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inToken {
+		tokens = append(tokens, Word{Text: cur.String(), Quoted: quoted})
+	}
+	return tokens, nil
+}
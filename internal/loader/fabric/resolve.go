@@ -1,10 +1,13 @@
 package fabric
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
 	"net/http"
 	"net/url"
@@ -14,6 +17,7 @@ import (
 
 	"github.com/aayushdutt/mctui/internal/api"
 	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/download"
 )
 
 // metaBase is the Fabric meta host. It is a package var (not a const) and
@@ -50,12 +54,8 @@ func ResolveVersion(ctx context.Context, mojang *api.MojangClient, inst *core.In
 	// without touching the network (true airplane mode); otherwise fall through to
 	// the online resolution below, which also handles a never-launched instance.
 	if offline && loaderVer != "" {
-		cacheFile := mergedProfileCacheFile(cacheDir, gameVer, loaderVer)
-		if data, err := os.ReadFile(cacheFile); err == nil {
-			var details core.VersionDetails
-			if json.Unmarshal(data, &details) == nil && details.MainClass != "" {
-				return &details, nil
-			}
+		if details, _ := loadMergedCache(mergedProfileCacheFile(cacheDir, gameVer, loaderVer)); details != nil {
+			return details, nil
 		}
 	}
 
@@ -74,13 +74,99 @@ func ResolveVersion(ctx context.Context, mojang *api.MojangClient, inst *core.In
 	}
 
 	cacheFile := mergedProfileCacheFile(cacheDir, gameVer, loaderVer)
-	if data, err := os.ReadFile(cacheFile); err == nil {
-		var details core.VersionDetails
-		if json.Unmarshal(data, &details) == nil && details.MainClass != "" {
-			return &details, nil
+	if details, _ := loadMergedCache(cacheFile); details != nil {
+		return details, nil
+	}
+
+	merged, err := fetchMerged(ctx, parent, gameVer, loaderVer)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := saveMergedCache(cacheFile, merged); err != nil {
+		log.Printf("fabric: could not save merged profile cache %s: %v", filepath.Base(cacheFile), err)
+	}
+
+	return merged, nil
+}
+
+// ProfileCheck is what [VerifyProfile] found in the merged profile cache.
+type ProfileCheck int
+
+const (
+	ProfileOK      ProfileCheck = iota // The cache matched a fresh merge
+	ProfileMissing                     // There was no cache; it was written
+	ProfileCorrupt                     // The cache didn't parse or had no main class; it was rewritten
+	ProfileStale                       // The cache differed from a fresh merge; it was rewritten
+)
+
+func (c ProfileCheck) String() string {
+	switch c {
+	case ProfileMissing:
+		return "missing, rewritten"
+	case ProfileCorrupt:
+		return "corrupt, rewritten"
+	case ProfileStale:
+		return "out of date, rewritten"
+	default:
+		return "ok"
+	}
+}
+
+// VerifyProfile merges the instance's Fabric profile afresh, ignoring the
+// cache, and rewrites the cache unless it already matched. Like
+// ResolveVersion it may set LoaderVer to the latest stable Fabric.
+func VerifyProfile(ctx context.Context, mojang *api.MojangClient, inst *core.Instance) (*core.VersionDetails, ProfileCheck, error) {
+	if inst == nil {
+		return nil, ProfileOK, fmt.Errorf("instance required")
+	}
+	gameVer := inst.Version
+	if gameVer == "" {
+		return nil, ProfileOK, fmt.Errorf("instance has no Minecraft version")
+	}
+
+	parent, err := mojang.ResolveVersionDetails(ctx, gameVer, false)
+	if err != nil {
+		return nil, ProfileOK, fmt.Errorf("vanilla version %s: %w", gameVer, err)
+	}
+
+	loaderVer := inst.LoaderVer
+	if loaderVer == "" {
+		v, err := pickStableLoaderVersion(ctx, gameVer)
+		if err != nil {
+			return nil, ProfileOK, err
+		}
+		loaderVer = v
+		inst.LoaderVer = loaderVer
+	}
+
+	merged, err := fetchMerged(ctx, parent, gameVer, loaderVer)
+	if err != nil {
+		return nil, ProfileOK, err
+	}
+
+	cacheFile := mergedProfileCacheFile(mojang.VersionCacheDir(), gameVer, loaderVer)
+	cached, err := loadMergedCache(cacheFile)
+	check := ProfileOK
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		check = ProfileMissing
+	case cached == nil:
+		check = ProfileCorrupt
+	case !sameDetails(cached, merged):
+		check = ProfileStale
+	}
+	if check != ProfileOK {
+		if err := saveMergedCache(cacheFile, merged); err != nil {
+			return nil, check, fmt.Errorf("saving fabric profile: %w", err)
 		}
 	}
+	return merged, check, nil
+}
 
+// fetchMerged downloads the Fabric profile for gameVer and loaderVer and
+// merges it onto parent.
+func fetchMerged(ctx context.Context, parent *core.VersionDetails, gameVer, loaderVer string) (*core.VersionDetails, error) {
 	profileURL := fmt.Sprintf("%s/v2/versions/loader/%s/%s/profile/json",
 		metaBase, url.PathEscape(gameVer), url.PathEscape(loaderVer))
 	profileJSON, err := fetchBytes(ctx, profileURL)
@@ -94,12 +180,30 @@ func ResolveVersion(ctx context.Context, mojang *api.MojangClient, inst *core.In
 	}
 
 	merged.ID = parent.ID
+	return merged, nil
+}
 
-	if err := saveMergedCache(cacheFile, merged); err != nil {
-		log.Printf("fabric: could not save merged profile cache %s: %v", filepath.Base(cacheFile), err)
+// loadMergedCache reads a cached merged profile. It returns nil details when
+// the file is unreadable, doesn't parse or has no main class; the error is
+// only set when reading failed.
+func loadMergedCache(path string) (*core.VersionDetails, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
 	}
+	var details core.VersionDetails
+	if json.Unmarshal(data, &details) != nil || details.MainClass == "" {
+		return nil, nil
+	}
+	return &details, nil
+}
 
-	return merged, nil
+// sameDetails reports whether a and b encode to the same JSON, as they would
+// be cached.
+func sameDetails(a, b *core.VersionDetails) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
 }
 
 func pickStableLoaderVersion(ctx context.Context, gameVersion string) (string, error) {
@@ -124,19 +228,11 @@ func pickStableLoaderVersion(ctx context.Context, gameVersion string) (string, e
 }
 
 func fetchBytes(ctx context.Context, rawURL string) ([]byte, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := metaHTTP.Do(req)
+	resp, err := download.Get(ctx, metaHTTP, rawURL)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
-		return nil, fmt.Errorf("%s: status %d: %s", rawURL, resp.StatusCode, string(b))
-	}
 	return io.ReadAll(resp.Body)
 }
 
@@ -148,5 +244,9 @@ func saveMergedCache(path string, merged *core.VersionDetails) error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, data, 0o644)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
 }
@@ -2,6 +2,10 @@ package fabric
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/aayushdutt/mctui/internal/api"
@@ -30,3 +34,60 @@ func TestResolveVersion_OfflineUsesCache(t *testing.T) {
 		t.Errorf("MainClass = %q, want %q", got.MainClass, want.MainClass)
 	}
 }
+
+// fabricServer serves a one-version Mojang manifest, that version's details and
+// a Fabric profile for it, and points the meta resolver at itself.
+func fabricServer(t *testing.T) *api.MojangClient {
+	t.Helper()
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	mux.HandleFunc("/manifest.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(core.VersionManifest{
+			Versions: []core.Version{{ID: "1.21", Type: "release", URL: srv.URL + "/version/1.21.json"}},
+		})
+	})
+	mux.HandleFunc("/version/1.21.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(core.VersionDetails{ID: "1.21", MainClass: "net.minecraft.client.main.Main"})
+	})
+	mux.HandleFunc("/v2/versions/loader/1.21/0.16.0/profile/json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"mainClass":"net.fabricmc.loader.impl.launch.knot.KnotClient","libraries":[]}`))
+	})
+	withMetaBase(t, srv.URL)
+	return api.NewMojangClientWithManifestURL(t.TempDir(), srv.URL+"/manifest.json")
+}
+
+func TestVerifyProfile(t *testing.T) {
+	mojang := fabricServer(t)
+	inst := &core.Instance{Version: "1.21", Loader: "fabric", LoaderVer: "0.16.0"}
+	cacheFile := mergedProfileCacheFile(mojang.VersionCacheDir(), inst.Version, inst.LoaderVer)
+
+	tests := []struct {
+		name  string
+		setup func()
+		want  ProfileCheck
+	}{
+		{"missing", func() { os.Remove(cacheFile) }, ProfileMissing},
+		{"intact", func() {}, ProfileOK},
+		{"corrupt", func() { os.WriteFile(cacheFile, []byte("{trunc"), 0o644) }, ProfileCorrupt},
+		{"stale", func() {
+			_ = saveMergedCache(cacheFile, &core.VersionDetails{ID: "1.21", MainClass: "old.Main"})
+		}, ProfileStale},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setup()
+			details, check, err := VerifyProfile(context.Background(), mojang, inst)
+			if err != nil {
+				t.Fatalf("VerifyProfile: %v", err)
+			}
+			if check != tc.want {
+				t.Errorf("check = %v, want %v", check, tc.want)
+			}
+			cached, _ := loadMergedCache(cacheFile)
+			if cached == nil || !sameDetails(cached, details) {
+				t.Errorf("cache should hold the fresh merge, got %+v", cached)
+			}
+		})
+	}
+}
@@ -25,3 +25,27 @@ func ResolveVersionDetails(ctx context.Context, mojang *api.MojangClient, inst *
 		return nil, fmt.Errorf("mod loader %q is not supported yet (coming soon)", inst.Loader)
 	}
 }
+
+// VerifyVersionDetails resolves an instance's version metadata for a repair,
+// bypassing the loader's cached profile. For Fabric the merged profile is
+// rebuilt and its cache rewritten if it was missing, corrupt or out of date;
+// profile describes what was found, and is empty for vanilla.
+func VerifyVersionDetails(ctx context.Context, mojang *api.MojangClient, inst *core.Instance) (details *core.VersionDetails, profile string, err error) {
+	if mojang == nil || inst == nil {
+		return nil, "", fmt.Errorf("mojang client and instance are required")
+	}
+
+	switch ParseKind(inst.Loader) {
+	case KindFabric:
+		details, check, err := fabric.VerifyProfile(ctx, mojang, inst)
+		if err != nil {
+			return nil, "", err
+		}
+		return details, "Fabric profile " + check.String(), nil
+	case KindVanilla:
+		details, err := mojang.ResolveVersionDetails(ctx, inst.Version, false)
+		return details, "", err
+	default:
+		return nil, "", fmt.Errorf("mod loader %q is not supported yet (coming soon)", inst.Loader)
+	}
+}
@@ -0,0 +1,93 @@
+// Package memory sizes the game's Java heap: reading and writing JVM memory
+// flags, reading how much RAM the machine has, and recommending an allocation.
+package memory
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Defaults used when neither the instance nor the global settings pick a size
+// and nothing better is known.
+const (
+	DefaultMinMB = 512
+	DefaultMaxMB = 2048
+)
+
+// StepMB is the granularity sizes are recommended and adjusted in.
+const StepMB = 512
+
+// ParseMB reads a JVM memory size ("2G", "512m", "1048576k", bytes) in
+// megabytes, or 0 when it can't.
+func ParseMB(s string) int {
+	if s == "" {
+		return 0
+	}
+	unit := strings.ToLower(s[len(s)-1:])
+	n, err := strconv.Atoi(strings.TrimRight(s, "gGmMkK"))
+	if err != nil || n < 0 {
+		return 0
+	}
+	switch unit {
+	case "g":
+		return n * 1024
+	case "m":
+		return n
+	case "k":
+		return n / 1024
+	}
+	return n / (1024 * 1024) // bytes
+}
+
+// Flags returns the -Xms and -Xmx flags for a heap of minMB to maxMB; either
+// is left out when 0.
+func Flags(minMB, maxMB int) []string {
+	var flags []string
+	if minMB > 0 {
+		flags = append(flags, "-Xms"+flagSize(minMB))
+	}
+	if maxMB > 0 {
+		flags = append(flags, "-Xmx"+flagSize(maxMB))
+	}
+	return flags
+}
+
+func flagSize(mb int) string {
+	if mb%1024 == 0 {
+		return strconv.Itoa(mb/1024) + "G"
+	}
+	return strconv.Itoa(mb) + "M"
+}
+
+// SplitArgs takes the -Xms and -Xmx flags out of args, returning the rest and
+// the sizes they set (0 for one that isn't there). The last flag of each kind
+// wins, as in the JVM.
+func SplitArgs(args []string) (rest []string, minMB, maxMB int) {
+	for _, arg := range args {
+		if v, ok := strings.CutPrefix(arg, "-Xms"); ok && ParseMB(v) > 0 {
+			minMB = ParseMB(v)
+			continue
+		}
+		if v, ok := strings.CutPrefix(arg, "-Xmx"); ok && ParseMB(v) > 0 {
+			maxMB = ParseMB(v)
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, minMB, maxMB
+}
+
+// MaxHeapMB is the -Xmx in args in megabytes, or 0 when there is none.
+func MaxHeapMB(args []string) int {
+	_, _, maxMB := SplitArgs(args)
+	return maxMB
+}
+
+// Format renders a size for people: "512 MB", "2 GB", "1.5 GB".
+func Format(mb int) string {
+	if mb < 1024 {
+		return fmt.Sprintf("%d MB", mb)
+	}
+	return strconv.FormatFloat(float64(mb)/1024, 'f', -1, 64) + " GB"
+}
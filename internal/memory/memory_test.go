@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseMB(t *testing.T) {
+	for in, want := range map[string]int{"2G": 2048, "512m": 512, "1048576k": 1024, "3221225472": 3072, "": 0, "lots": 0, "-1G": 0} {
+		if got := ParseMB(in); got != want {
+			t.Errorf("ParseMB(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestFlagsAndSplitArgs(t *testing.T) {
+	if got := Flags(512, 6144); !slices.Equal(got, []string{"-Xms512M", "-Xmx6G"}) {
+		t.Errorf("Flags = %q", got)
+	}
+	if got := Flags(0, 1536); !slices.Equal(got, []string{"-Xmx1536M"}) {
+		t.Errorf("Flags without a minimum = %q", got)
+	}
+
+	rest, minMB, maxMB := SplitArgs([]string{"-Xmx1G", "-XX:+UseG1GC", "-Xms256M", "-Xmx3G", "-Xmxlots"})
+	if minMB != 256 || maxMB != 3072 {
+		t.Errorf("sizes = %d, %d; want 256, 3072 (the last -Xmx)", minMB, maxMB)
+	}
+	if !slices.Equal(rest, []string{"-XX:+UseG1GC", "-Xmxlots"}) {
+		t.Errorf("rest = %q", rest)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	for mb, want := range map[int]string{512: "512 MB", 2048: "2 GB", 1536: "1.5 GB", 15872: "15.5 GB"} {
+		if got := Format(mb); got != want {
+			t.Errorf("Format(%d) = %q, want %q", mb, got, want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package memory
+
+import "fmt"
+
+// Recommendation is a suggested maximum heap and why.
+type Recommendation struct {
+	MaxMB  int
+	Reason string // e.g. "for 40 mods" or "for 40 mods, half of 8 GB RAM"
+}
+
+// Recommend suggests a maximum heap for an instance with mods mods installed,
+// on a machine with totalMB of RAM (0 when unknown). More mods get more, but
+// never more than half the machine's RAM, which the system and everything
+// else running need too.
+func Recommend(totalMB, mods int) Recommendation {
+	rec := Recommendation{MaxMB: DefaultMaxMB, Reason: "for vanilla"}
+	switch {
+	case mods >= 150:
+		rec.MaxMB = 8192
+	case mods >= 75:
+		rec.MaxMB = 6144
+	case mods >= 25:
+		rec.MaxMB = 4096
+	case mods > 0:
+		rec.MaxMB = 3072
+	}
+	if mods > 0 {
+		rec.Reason = fmt.Sprintf("for %d mod%s", mods, plural(mods))
+	}
+
+	if half := totalMB / 2 / StepMB * StepMB; totalMB > 0 && half < rec.MaxMB {
+		rec.MaxMB = max(half, 1024)
+		rec.Reason += ", half of " + Format(totalMB) + " RAM"
+	}
+	return rec
+}
+
+// Warning says why a heap of maxMB may not fit on sys, or "" when it fits or
+// sys is unknown.
+func Warning(maxMB int, sys System) string {
+	switch {
+	case maxMB <= 0:
+		return ""
+	case sys.TotalMB > 0 && maxMB >= sys.TotalMB:
+		return fmt.Sprintf("%s is at least all of this machine's %s of RAM; the game will likely fail to start", Format(maxMB), Format(sys.TotalMB))
+	case sys.FreeMB > 0 && maxMB > sys.FreeMB:
+		return fmt.Sprintf("%s is more than the %s free right now; the game may stutter or fail to start", Format(maxMB), Format(sys.FreeMB))
+	}
+	return ""
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
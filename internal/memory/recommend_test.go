@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecommend(t *testing.T) {
+	tests := []struct {
+		totalMB, mods int
+		want          int
+		reason        string
+	}{
+		{0, 0, 2048, "for vanilla"},
+		{16384, 1, 3072, "for 1 mod"},
+		{16384, 40, 4096, "for 40 mods"},
+		{32768, 200, 8192, "for 200 mods"},
+		{8192, 200, 4096, "for 200 mods, half of 8 GB RAM"},
+		{1024, 0, 1024, "for vanilla, half of 1 GB RAM"}, // never below 1 GB
+	}
+	for _, tt := range tests {
+		got := Recommend(tt.totalMB, tt.mods)
+		if got.MaxMB != tt.want || got.Reason != tt.reason {
+			t.Errorf("Recommend(%d, %d) = %+v, want %d %q", tt.totalMB, tt.mods, got, tt.want, tt.reason)
+		}
+	}
+}
+
+func TestWarning(t *testing.T) {
+	sys := System{TotalMB: 8192, FreeMB: 3072}
+	if w := Warning(2048, sys); w != "" {
+		t.Errorf("2 GB of 3 GB free warned: %q", w)
+	}
+	if w := Warning(4096, sys); !strings.Contains(w, "more than the 3 GB free") {
+		t.Errorf("Warning = %q", w)
+	}
+	if w := Warning(8192, sys); !strings.Contains(w, "all of this machine's 8 GB") {
+		t.Errorf("Warning = %q", w)
+	}
+	if w := Warning(65536, System{}); w != "" {
+		t.Errorf("unknown system warned: %q", w)
+	}
+}
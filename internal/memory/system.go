@@ -0,0 +1,13 @@
+package memory
+
+// System is the machine's physical memory, in megabytes. Either is 0 when it
+// couldn't be read.
+type System struct {
+	TotalMB int
+	FreeMB  int // available without swapping, including reclaimable caches
+}
+
+// ReadSystem reads the machine's total and free physical memory.
+func ReadSystem() (System, error) {
+	return readSystem()
+}
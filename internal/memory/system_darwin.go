@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"encoding/binary"
+	"syscall"
+)
+
+func readSystem() (System, error) {
+	// hw.memsize is a 64-bit value; syscall.Sysctl returns its raw bytes with
+	// a trailing NUL trimmed, so pad them back out.
+	raw, err := syscall.Sysctl("hw.memsize")
+	if err != nil {
+		return System{}, err
+	}
+	buf := make([]byte, 8)
+	copy(buf, raw)
+	sys := System{TotalMB: int(binary.LittleEndian.Uint64(buf) / (1024 * 1024))}
+
+	// Free and purgeable pages can be handed out without swapping.
+	free, err := syscall.SysctlUint32("vm.page_free_count")
+	if err != nil {
+		return sys, nil
+	}
+	purgeable, _ := syscall.SysctlUint32("vm.page_purgeable_count")
+	sys.FreeMB = int((uint64(free) + uint64(purgeable)) * uint64(syscall.Getpagesize()) / (1024 * 1024))
+	return sys, nil
+}
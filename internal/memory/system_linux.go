@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func readSystem() (System, error) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return System{}, err
+	}
+	defer f.Close()
+	return parseMeminfo(f)
+}
+
+// parseMeminfo reads MemTotal and MemAvailable (MemFree on kernels older than
+// 3.14) from /proc/meminfo, whose sizes are in kB.
+func parseMeminfo(r io.Reader) (System, error) {
+	var sys System
+	free := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		kb, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(value), " kB"))
+		if err != nil {
+			continue
+		}
+		switch key {
+		case "MemTotal":
+			sys.TotalMB = kb / 1024
+		case "MemAvailable":
+			sys.FreeMB = kb / 1024
+		case "MemFree":
+			free = kb / 1024
+		}
+	}
+	if sys.FreeMB == 0 {
+		sys.FreeMB = free
+	}
+	if sys.TotalMB == 0 {
+		return sys, errors.New("no MemTotal in /proc/meminfo")
+	}
+	return sys, scanner.Err()
+}
@@ -0,0 +1,28 @@
+package memory
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMeminfo(t *testing.T) {
+	sys, err := parseMeminfo(strings.NewReader(`MemTotal:       16303748 kB
+MemFree:          812344 kB
+MemAvailable:    9216000 kB
+Buffers:          402112 kB
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sys.TotalMB != 15921 || sys.FreeMB != 9000 {
+		t.Errorf("sys = %+v, want MemTotal and MemAvailable in MB", sys)
+	}
+
+	sys, _ = parseMeminfo(strings.NewReader("MemTotal: 2048000 kB\nMemFree: 1024000 kB\n"))
+	if sys.FreeMB != 1000 {
+		t.Errorf("FreeMB = %d, want MemFree without MemAvailable", sys.FreeMB)
+	}
+	if _, err := parseMeminfo(strings.NewReader("")); err == nil {
+		t.Error("want an error without MemTotal")
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !windows
+
+package memory
+
+import "errors"
+
+func readSystem() (System, error) {
+	return System{}, errors.New("reading system memory isn't supported on this platform")
+}
@@ -0,0 +1,172 @@
+package mods
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/aayushdutt/mctui/internal/core"
+)
+
+// JavaDependency is the Java range a Fabric mod declares in fabric.mod.json.
+type JavaDependency struct {
+	Mod      string // mod name, or id when it has none
+	Min, Max int    // major versions; 0 is unbounded
+}
+
+// fabricModJSON is the part of fabric.mod.json that names Java.
+type fabricModJSON struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Depends struct {
+		Java json.RawMessage `json:"java"`
+	} `json:"depends"`
+}
+
+// JavaDependencies reads the Java requirements declared by the Fabric mods in
+// inst's mods folder. Jars that aren't Fabric mods, or don't name Java, are
+// skipped.
+func JavaDependencies(inst *core.Instance) []JavaDependency {
+	jars, err := ListInstalledJars(inst)
+	if err != nil {
+		return nil
+	}
+	var deps []JavaDependency
+	for _, jar := range jars {
+		meta, err := readFabricModJSON(jar.Path)
+		if err != nil || len(meta.Depends.Java) == 0 {
+			continue
+		}
+		lo, hi, ok := parseJavaRanges(meta.Depends.Java)
+		if !ok || (lo == 0 && hi == 0) {
+			continue
+		}
+		name := meta.Name
+		if name == "" {
+			name = meta.ID
+		}
+		deps = append(deps, JavaDependency{Mod: name, Min: lo, Max: hi})
+	}
+	return deps
+}
+
+func readFabricModJSON(jarPath string) (*fabricModJSON, error) {
+	zr, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	f, err := zr.Open("fabric.mod.json")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	var meta fabricModJSON
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// parseJavaRanges reads a Fabric dependency value: one range, or an array of
+// alternatives, which widen to cover all of them.
+func parseJavaRanges(raw json.RawMessage) (lo, hi int, ok bool) {
+	var single string
+	if json.Unmarshal(raw, &single) == nil {
+		return parseJavaRange(single)
+	}
+	var alts []string
+	if json.Unmarshal(raw, &alts) != nil || len(alts) == 0 {
+		return 0, 0, false
+	}
+	for i, s := range alts {
+		altLo, altHi, ok := parseJavaRange(s)
+		if !ok {
+			return 0, 0, false
+		}
+		if i == 0 || altLo < lo {
+			lo = altLo
+		}
+		if i == 0 || altHi == 0 || (hi != 0 && altHi > hi) {
+			hi = altHi
+		}
+	}
+	return lo, hi, true
+}
+
+// parseJavaRange reads space-separated predicates such as ">=17", "<22",
+// "17", "~17" or "*", all of which must hold.
+func parseJavaRange(s string) (lo, hi int, ok bool) {
+	for _, term := range strings.Fields(s) {
+		if term == "*" {
+			continue
+		}
+		op := strings.TrimRight(term, "0123456789.x*")
+		ver := term[len(op):]
+		v, ok := javaMajor(ver)
+		if !ok {
+			return 0, 0, false
+		}
+		// A strict bound below or above a point release (e.g. "<17.0.5")
+		// still admits other releases of that major.
+		within := pastMajor(ver)
+		switch op {
+		case ">=", "^":
+			lo = max(lo, v)
+		case "~":
+			lo = max(lo, v)
+			hi = minBound(hi, v)
+		case ">":
+			if within {
+				lo = max(lo, v)
+			} else {
+				lo = max(lo, v+1)
+			}
+		case "<=":
+			hi = minBound(hi, v)
+		case "<":
+			if within {
+				hi = minBound(hi, v)
+			} else {
+				hi = minBound(hi, v-1)
+			}
+		case "", "=":
+			lo = max(lo, v)
+			hi = minBound(hi, v)
+		default:
+			return 0, 0, false
+		}
+	}
+	return lo, hi, true
+}
+
+// javaMajor reads a Java version ("17", "17.0.2", "1.8", "21.x") as its major
+// version.
+func javaMajor(v string) (int, bool) {
+	v = strings.TrimPrefix(v, "1.")
+	head, _, _ := strings.Cut(v, ".")
+	n, err := strconv.Atoi(head)
+	return n, err == nil
+}
+
+// pastMajor reports whether a version names a release after its major's
+// first one, i.e. has a non-zero minor or patch ("17.0.5", not "17" or "17.0").
+func pastMajor(v string) bool {
+	v = strings.TrimPrefix(v, "1.")
+	_, rest, _ := strings.Cut(v, ".")
+	return strings.Trim(rest, "0.x*") != ""
+}
+
+// minBound is the tighter upper bound, where 0 means unbounded.
+func minBound(a, b int) int {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
+}
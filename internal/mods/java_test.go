@@ -0,0 +1,72 @@
+package mods
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aayushdutt/mctui/internal/core"
+)
+
+func TestParseJavaRanges(t *testing.T) {
+	tests := []struct {
+		in     string
+		lo, hi int
+		ok     bool
+	}{
+		{`">=17"`, 17, 0, true},
+		{`">=1.8 <17"`, 8, 16, true},
+		{`"21"`, 21, 21, true},
+		{`"~17.0.2"`, 17, 17, true},
+		{`"~17"`, 17, 17, true},
+		{`">16"`, 17, 0, true},
+		{`">17.0.1"`, 17, 0, true},
+		{`">=8 <17.0.5"`, 8, 17, true},
+		{`"<17.0"`, 0, 16, true},
+		{`"*"`, 0, 0, true},
+		{`["17", "21"]`, 17, 21, true},
+		{`[">=17", "8"]`, 8, 0, true},
+		{`"!=17"`, 0, 0, false},
+		{`"java"`, 0, 0, false},
+	}
+	for _, tt := range tests {
+		lo, hi, ok := parseJavaRanges([]byte(tt.in))
+		if lo != tt.lo || hi != tt.hi || ok != tt.ok {
+			t.Errorf("parseJavaRanges(%s) = %d, %d, %v; want %d, %d, %v", tt.in, lo, hi, ok, tt.lo, tt.hi, tt.ok)
+		}
+	}
+}
+
+func TestJavaDependencies(t *testing.T) {
+	tmp := t.TempDir()
+	dir := filepath.Join(tmp, ".minecraft", "mods")
+	os.MkdirAll(dir, 0755)
+	writeJar := func(name, modJSON string) {
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		zw := zip.NewWriter(f)
+		if modJSON != "" {
+			w, _ := zw.Create("fabric.mod.json")
+			w.Write([]byte(modJSON))
+		}
+		zw.Close()
+	}
+	writeJar("sodium.jar", `{"id":"sodium","name":"Sodium","depends":{"java":">=21"}}`)
+	writeJar("old.jar", `{"id":"oldmod","depends":{"java":"<=8"}}`)
+	writeJar("any.jar", `{"id":"any","depends":{"java":"*","minecraft":"1.20.1"}}`)
+	writeJar("forge.jar", "")
+	os.WriteFile(filepath.Join(dir, "broken.jar"), []byte("not a zip"), 0644)
+
+	deps := JavaDependencies(&core.Instance{Path: tmp})
+	got := map[string]JavaDependency{}
+	for _, d := range deps {
+		got[d.Mod] = d
+	}
+	if len(deps) != 2 || got["Sodium"].Min != 21 || got["oldmod"].Max != 8 {
+		t.Errorf("deps = %+v, want Sodium >=21 and oldmod <=8", deps)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/aayushdutt/mctui/internal/api"
 	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/download"
 )
 
 // ResolvedMod is one jar selected for installation by the dependency resolver.
@@ -17,6 +18,7 @@ type ResolvedMod struct {
 	FileName  string
 	URL       string
 	SHA1      string
+	SHA512    string
 	Size      int64
 	Root      bool   // true only for the user-selected project
 	DepType   string // "" for root, otherwise "required"
@@ -39,7 +41,13 @@ type ResolvePlan struct {
 type InstallReport struct {
 	Installed []ResolvedMod
 	Skipped   []SkippedDep
-	Failed    []ResolvedMod
+	Failed    []FailedMod
+}
+
+// FailedMod is a mod whose jar didn't download, with why.
+type FailedMod struct {
+	ResolvedMod
+	Download download.Outcome
 }
 
 // resolveNode is an entry in the BFS frontier.
@@ -135,6 +143,7 @@ func (s *Service) ResolveFabricModWithDeps(ctx context.Context, inst *core.Insta
 			FileName:  file.Filename,
 			URL:       file.URL,
 			SHA1:      file.Hashes.SHA1,
+			SHA512:    file.Hashes.SHA512,
 			Size:      file.Size,
 			Root:      node.root,
 			DepType:   node.depType,
@@ -60,7 +60,7 @@ func jarVersion(projectID, versionID, file string, deps ...api.Dependency) api.P
 			Filename: file,
 			Primary:  true,
 			Size:     100,
-			Hashes:   api.FileHashes{SHA1: "sha-" + versionID},
+			Hashes:   api.FileHashes{SHA1: "sha-" + versionID, SHA512: "sha512-" + versionID},
 		}},
 	}
 }
@@ -122,6 +122,9 @@ func TestResolveFabricModWithDeps(t *testing.T) {
 		if plan.Mods[1].DepType != "required" {
 			t.Fatalf("dep DepType = %q, want required", plan.Mods[1].DepType)
 		}
+		if plan.Mods[1].SHA512 != "sha512-dep-v1" {
+			t.Fatalf("dep SHA512 = %q, want the file's SHA-512 for verification", plan.Mods[1].SHA512)
+		}
 	})
 
 	t.Run("cycle terminates", func(t *testing.T) {
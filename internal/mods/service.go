@@ -4,10 +4,7 @@ package mods
 
 import (
 	"context"
-	"crypto/sha1"
-	"encoding/hex"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -98,24 +95,25 @@ func (s *Service) InstallFabricMod(ctx context.Context, inst *core.Instance, pro
 	dest := filepath.Join(dir, file.Filename)
 	mgr := download.NewManager(2)
 	result, err := mgr.Download(ctx, []download.Item{{
-		URL:  file.URL,
-		Path: dest,
-		SHA1: file.Hashes.SHA1,
-		Size: file.Size,
+		URL:    file.URL,
+		Path:   dest,
+		SHA1:   file.Hashes.SHA1,
+		SHA512: file.Hashes.SHA512,
+		Size:   file.Size,
 	}}, nil)
 	if err != nil {
 		return "", err
 	}
-	if result.Failed > 0 {
-		return "", fmt.Errorf("download failed")
+	if err := result.Err(); err != nil {
+		return "", err
 	}
 	return dest, nil
 }
 
 // InstallFabricModWithDeps resolves the root project plus its transitive required
 // dependencies, downloads all jars in one batch, and records each that lands on disk
-// in the catalog. Success/failure is attributed by checking file existence (os.Stat)
-// rather than parsing per-item download errors.
+// in the catalog. Jars that fail are reported with the download outcome saying why;
+// pass them to [Service.RetryFailedMods] to try just those again.
 func (s *Service) InstallFabricModWithDeps(ctx context.Context, inst *core.Instance, projectID string) (*InstallReport, error) {
 	if s == nil || s.Modrinth == nil {
 		return nil, fmt.Errorf("modrinth client required")
@@ -131,79 +129,73 @@ func (s *Service) InstallFabricModWithDeps(ctx context.Context, inst *core.Insta
 		return nil, err
 	}
 
-	dir := ModsDir(inst)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return nil, fmt.Errorf("mods dir: %w", err)
-	}
-
 	report := &InstallReport{Skipped: plan.Skipped}
-
-	items := make([]download.Item, 0, len(plan.Mods))
-	for _, mod := range plan.Mods {
-		items = append(items, download.Item{
-			URL:  mod.URL,
-			Path: filepath.Join(dir, mod.FileName),
-			SHA1: mod.SHA1,
-			Size: mod.Size,
-		})
+	if err := installMods(ctx, inst, plan.Mods, report); err != nil {
+		return report, err
 	}
+	return report, nil
+}
 
-	if len(items) > 0 {
-		workers := len(items)
-		if workers > 4 {
-			workers = 4
-		}
-		mgr := download.NewManager(workers)
-		if _, err := mgr.Download(ctx, items, nil); err != nil {
-			return nil, err
-		}
+// RetryFailedMods downloads again only the jars that failed in an earlier
+// install, recording those that now land in the catalog.
+func (s *Service) RetryFailedMods(ctx context.Context, inst *core.Instance, failed []FailedMod) (*InstallReport, error) {
+	if inst == nil {
+		return nil, fmt.Errorf("instance required")
 	}
-
-	// Attribute outcomes by on-disk presence AND content (download manager error
-	// mapping is loose). A mod counts as installed only when the file exists and,
-	// if we have an expected SHA-1, its content matches — so a stale/colliding
-	// pre-existing jar for a different mod isn't miscounted as a success.
-	for _, mod := range plan.Mods {
-		dest := filepath.Join(dir, mod.FileName)
-		if installedOnDisk(dest, mod.SHA1) {
-			if err := RecordModrinthInstall(inst, mod.ProjectID, mod.Slug, mod.FileName); err != nil {
-				return report, fmt.Errorf("saved %s but catalog: %w", mod.FileName, err)
-			}
-			report.Installed = append(report.Installed, mod)
-		} else {
-			report.Failed = append(report.Failed, mod)
-		}
+	mods := make([]ResolvedMod, len(failed))
+	for i, f := range failed {
+		mods[i] = f.ResolvedMod
+	}
+	report := &InstallReport{}
+	if err := installMods(ctx, inst, mods, report); err != nil {
+		return report, err
 	}
 	return report, nil
 }
 
-// installedOnDisk reports whether dest exists as a regular file and, when
-// wantSHA1 is non-empty, its SHA-1 matches (case-insensitive hex).
-func installedOnDisk(dest, wantSHA1 string) bool {
-	st, err := os.Stat(dest)
-	if err != nil || st.IsDir() {
-		return false
+// installMods downloads the jars of mods into the instance in one batch and
+// sorts them into report.Installed (recorded in the catalog) or report.Failed
+// from each item's download outcome.
+func installMods(ctx context.Context, inst *core.Instance, mods []ResolvedMod, report *InstallReport) error {
+	dir := ModsDir(inst)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("mods dir: %w", err)
 	}
-	if wantSHA1 == "" {
-		return true
+	if len(mods) == 0 {
+		return nil
 	}
-	got, err := sha1OfFile(dest)
-	if err != nil {
-		return false
+
+	items := make([]download.Item, 0, len(mods))
+	for _, mod := range mods {
+		items = append(items, download.Item{
+			URL:    mod.URL,
+			Path:   filepath.Join(dir, mod.FileName),
+			SHA1:   mod.SHA1,
+			SHA512: mod.SHA512,
+			Size:   mod.Size,
+		})
 	}
-	return strings.EqualFold(got, wantSHA1)
-}
 
-// sha1OfFile returns the lowercase hex SHA-1 of the file at path.
-func sha1OfFile(path string) (string, error) {
-	f, err := os.Open(path)
+	workers := len(items)
+	if workers > 4 {
+		workers = 4
+	}
+	result, err := download.NewManager(workers).Download(ctx, items, nil)
 	if err != nil {
-		return "", err
+		return err
 	}
-	defer f.Close()
-	h := sha1.New()
-	if _, err := io.Copy(h, f); err != nil {
-		return "", err
+
+	// Outcomes come back in item order, one per mod. An existing jar only counts
+	// when its hash matched; otherwise the manager replaced it.
+	for i, mod := range mods {
+		if o := result.Outcomes[i]; o.Failed() {
+			report.Failed = append(report.Failed, FailedMod{ResolvedMod: mod, Download: o})
+			continue
+		}
+		if err := RecordModrinthInstall(inst, mod.ProjectID, mod.Slug, mod.FileName); err != nil {
+			return fmt.Errorf("saved %s but catalog: %w", mod.FileName, err)
+		}
+		report.Installed = append(report.Installed, mod)
 	}
-	return hex.EncodeToString(h.Sum(nil)), nil
+	return nil
 }
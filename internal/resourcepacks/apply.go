@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/download"
 )
 
 // optionsEntry is the resourcePacks array entry referencing the merged pack.
@@ -63,6 +64,43 @@ func (s *Service) BuildAndApply(ctx context.Context, inst *core.Instance, sel *S
 		return nil, fmt.Errorf("build resource pack: %w", err)
 	}
 
+	return s.downloadAndApply(ctx, inst, sel, selMap, downloadURL, version)
+}
+
+// RetryDownload finishes an apply whose download failed, fetching the pack
+// already built server-side again instead of rebuilding it. The cart must
+// still hold what the pack was built from.
+func (s *Service) RetryDownload(ctx context.Context, inst *core.Instance, sel *Selection, failed *DownloadError) (*ApplyResult, error) {
+	if s == nil || s.VT == nil {
+		return nil, fmt.Errorf("vanilla tweaks client required")
+	}
+	if inst == nil || sel == nil || failed == nil {
+		return nil, fmt.Errorf("instance, selection and failed download required")
+	}
+	if !selectionsEqual(sel.BuildSelectionMap(), failed.Packs) {
+		return nil, fmt.Errorf("cart changed since the pack was built: build & apply again")
+	}
+	return s.downloadAndApply(ctx, inst, sel, failed.Packs, failed.URL, failed.Version)
+}
+
+// DownloadError is a merged pack that was built but failed to download. Pass it
+// to [Service.RetryDownload] to try the download alone again.
+type DownloadError struct {
+	URL     string              // the built pack
+	Version string              // catalog version it was built against
+	Packs   map[string][]string // the selection it was built from
+	Kind    download.FailureKind
+	Err     error
+}
+
+func (e *DownloadError) Error() string {
+	return fmt.Sprintf("download merged pack (%s): %v", e.Kind, e.Err)
+}
+
+func (e *DownloadError) Unwrap() error { return e.Err }
+
+// downloadAndApply runs the steps after a build: download, enable, record.
+func (s *Service) downloadAndApply(ctx context.Context, inst *core.Instance, sel *Selection, selMap map[string][]string, downloadURL, version string) (*ApplyResult, error) {
 	// 2. Download it to the stable destination (atomic overwrite handled by the
 	//    download layer's tmp+rename).
 	dir := ResourcePacksDir(inst)
@@ -71,7 +109,7 @@ func (s *Service) BuildAndApply(ctx context.Context, inst *core.Instance, sel *S
 	}
 	dest := MergedPackPath(inst)
 	if err := s.VT.DownloadResourcePackZip(ctx, downloadURL, dest); err != nil {
-		return nil, fmt.Errorf("download merged pack: %w", err)
+		return nil, &DownloadError{URL: downloadURL, Version: version, Packs: selMap, Kind: download.Classify(err), Err: err}
 	}
 
 	// 3. Enable in options.txt (idempotent).
@@ -2,6 +2,7 @@ package resourcepacks
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/aayushdutt/mctui/internal/api"
 	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/download"
 )
 
 // readResourcePacks loads options.txt and returns the parsed resourcePacks array.
@@ -281,3 +283,47 @@ func TestBuildAndApplyEmptyCart(t *testing.T) {
 		t.Fatalf("expected error for empty cart")
 	}
 }
+
+func TestRetryDownloadSkipsRebuild(t *testing.T) {
+	dir := t.TempDir()
+	inst := &core.Instance{Path: dir, Version: "1.21.4"}
+	vt := &fakeVT{
+		buildURL:    "https://vanillatweaks.net/download/abc/x.zip",
+		downloadErr: &download.StatusError{URL: "https://vanillatweaks.net/download/abc/x.zip", Code: 503},
+	}
+	svc := NewService(vt)
+
+	sel := NewSelection("1.21")
+	sel.add("Aesthetic", "ClearGlass")
+
+	_, err := svc.BuildAndApply(context.Background(), inst, sel)
+	var dlErr *DownloadError
+	if !errors.As(err, &dlErr) {
+		t.Fatalf("BuildAndApply error = %v, want a *DownloadError", err)
+	}
+	if dlErr.Kind != download.FailHTTPStatus || dlErr.URL != vt.buildURL {
+		t.Errorf("DownloadError = %+v", dlErr)
+	}
+	if !sel.Dirty() {
+		t.Error("cart should stay unapplied after a failed download")
+	}
+
+	vt.downloadErr = nil
+	vt.gotSelMap = nil
+	res, err := svc.RetryDownload(context.Background(), inst, sel, dlErr)
+	if err != nil {
+		t.Fatalf("RetryDownload: %v", err)
+	}
+	if vt.gotSelMap != nil {
+		t.Error("retry should not rebuild the pack")
+	}
+	if res.PackCount != 1 || sel.Dirty() || !IsEnabledInOptionsTxt(inst) {
+		t.Errorf("retry should finish the apply: %+v, dirty=%v", res, sel.Dirty())
+	}
+
+	// A cart edited since the build can't reuse the old pack.
+	sel.add("Aesthetic", "BorderlessGlass")
+	if _, err := svc.RetryDownload(context.Background(), inst, sel, dlErr); err == nil {
+		t.Error("expected an error retrying with a changed cart")
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/aayushdutt/mctui/internal/loader"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -39,8 +40,32 @@ type HomeModel struct {
 	confirmDelete  bool
 	deleteTarget   *core.Instance
 	deleteFocusYes bool // which option arrows / Enter apply to (default Yes so Enter still confirms delete)
+
+	// quickPlay is the open Quick Play target editor, nil when closed
+	quickPlay *quickPlayPicker
+
+	// sortBy is the list order (HomeSortRecent or HomeSortPlayTime)
+	sortBy string
+	// recentPlay is each instance's playtime within RecentPlayWindow, keyed by ID
+	recentPlay map[string]time.Duration
+	// running holds instances with a launch in progress or a game running, keyed by ID
+	running map[string]RunningInstance
+}
+
+// RunningInstance is an instance with a launch in progress or a game running in the background.
+type RunningInstance struct {
+	Started time.Time // when the game process started; zero while the launch is still preparing
 }
 
+// Home list sort orders, persisted as config.HomeSort.
+const (
+	HomeSortRecent   = "recent"
+	HomeSortPlayTime = "playtime"
+)
+
+// RecentPlayWindow is how far back "recent" playtime on the home list looks.
+const RecentPlayWindow = 7 * 24 * time.Hour
+
 type sessionRemoteLine int
 
 const (
@@ -57,9 +82,18 @@ type homeKeyMap struct {
 	Mods        key.Binding
 	ResPacks    key.Binding
 	Settings    key.Binding
+	Edit        key.Binding
 	Delete      key.Binding
 	Auth        key.Binding
 	OpenFolder  key.Binding
+	QuickPlay   key.Binding
+	EditQuick   key.Binding
+	Sort        key.Binding
+	Logs        key.Binding
+	Verify      key.Binding
+	Java        key.Binding
+	Stop        key.Binding
+	Kill        key.Binding
 }
 
 func defaultHomeKeyMap() homeKeyMap {
@@ -88,6 +122,10 @@ func defaultHomeKeyMap() homeKeyMap {
 			key.WithKeys("s"),
 			key.WithHelp("s", "settings"),
 		),
+		Edit: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit instance"),
+		),
 		Delete: key.NewBinding(
 			key.WithKeys("d"),
 			key.WithHelp("d", "delete"),
@@ -100,12 +138,46 @@ func defaultHomeKeyMap() homeKeyMap {
 			key.WithKeys("f"),
 			key.WithHelp("f", "open folder"),
 		),
+		QuickPlay: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "connect to quick play target"),
+		),
+		EditQuick: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "set quick play target"),
+		),
+		Sort: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "sort by recent / playtime"),
+		),
+		Logs: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "session logs"),
+		),
+		Verify: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "verify & repair files"),
+		),
+		Java: key.NewBinding(
+			key.WithKeys("J"),
+			key.WithHelp("J", "java runtimes"),
+		),
+		Stop: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "stop running game"),
+		),
+		Kill: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "force-kill running game"),
+		),
 	}
 }
 
 // instanceItem represents a Minecraft instance in the list
 type instanceItem struct {
 	instance *core.Instance
+	recent   time.Duration    // playtime within RecentPlayWindow
+	running  *RunningInstance // nil unless launching or running
 }
 
 func (i instanceItem) Title() string { return i.instance.Name }
@@ -121,7 +193,17 @@ func (i instanceItem) Description() string {
 		lastPlayed = formatRelativeTime(i.instance.LastPlayed)
 	}
 
-	return fmt.Sprintf("%s • %s • %s", i.instance.Version, loader, lastPlayed)
+	desc := fmt.Sprintf("%s • %s • %s", i.instance.Version, loader, lastPlayed)
+	if i.instance.PlayTime > 0 {
+		desc += " • " + formatPlayTime(time.Duration(i.instance.PlayTime)*time.Second) + " played"
+		if i.recent > 0 {
+			desc += " (" + formatPlayTime(i.recent) + " this week)"
+		}
+	}
+	if q := i.instance.QuickPlay; q.Valid() {
+		desc += " • ▸ " + q.Label()
+	}
+	return desc
 }
 func (i instanceItem) FilterValue() string { return i.instance.Name }
 
@@ -141,6 +223,20 @@ func formatRelativeTime(t time.Time) string {
 	}
 }
 
+// formatPlayTime renders a playtime total compactly: "<1m", "45m", "3h 20m", "120h".
+func formatPlayTime(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 100*time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+}
+
 // NewHomeModel creates a new home view model
 func NewHomeModel() *HomeModel {
 	base := ThemeListDelegate(Active.Primary, Active.Secondary)
@@ -158,6 +254,7 @@ func NewHomeModel() *HomeModel {
 		list:    l,
 		keys:    defaultHomeKeyMap(),
 		loading: true,
+		sortBy:  HomeSortRecent,
 	}
 }
 
@@ -185,14 +282,22 @@ func (m *HomeModel) SetInstances(instances []*core.Instance, selectID string) {
 	m.instances = instances
 	m.loading = false
 
-	// Sort by max(LastPlayed, CreatedAt) — newest first
-	sort.Slice(instances, func(i, j int) bool {
-		return core.RecencyForSort(instances[i]).After(core.RecencyForSort(instances[j]))
+	// Sort by max(LastPlayed, CreatedAt) — newest first; by playtime, recency breaks ties.
+	sort.SliceStable(instances, func(i, j int) bool {
+		a, b := instances[i], instances[j]
+		if m.sortBy == HomeSortPlayTime && a.PlayTime != b.PlayTime {
+			return a.PlayTime > b.PlayTime
+		}
+		return core.RecencyForSort(a).After(core.RecencyForSort(b))
 	})
 
 	items := make([]list.Item, len(instances))
 	for i, inst := range instances {
-		items[i] = instanceItem{instance: inst}
+		item := instanceItem{instance: inst, recent: m.recentPlay[inst.ID]}
+		if r, ok := m.running[inst.ID]; ok {
+			item.running = &r
+		}
+		items[i] = item
 	}
 	m.list.SetItems(items)
 
@@ -207,6 +312,48 @@ func (m *HomeModel) SetInstances(instances []*core.Instance, selectID string) {
 	}
 }
 
+// SetSort sets the list order from a config.HomeSort value; unknown values sort by recency.
+func (m *HomeModel) SetSort(sortBy string) {
+	if sortBy != HomeSortPlayTime {
+		sortBy = HomeSortRecent
+	}
+	m.sortBy = sortBy
+	m.list.Title = "🎮 Minecraft Instances"
+	if sortBy == HomeSortPlayTime {
+		m.list.Title += " · by playtime"
+	}
+	if m.instances != nil {
+		selectID := ""
+		if inst := m.SelectedInstance(); inst != nil {
+			selectID = inst.ID
+		}
+		m.SetInstances(m.instances, selectID)
+	}
+}
+
+// SetRunning marks which instances are launching or running, for the list badge
+// and so launch keys show the running game instead of starting another.
+func (m *HomeModel) SetRunning(running map[string]RunningInstance) {
+	m.running = running
+	if m.instances != nil {
+		selectID := ""
+		if inst := m.SelectedInstance(); inst != nil {
+			selectID = inst.ID
+		}
+		m.SetInstances(m.instances, selectID)
+	}
+}
+
+// selectedRunning returns the selected instance if it is launching or running.
+func (m *HomeModel) selectedRunning() (*core.Instance, bool) {
+	inst := m.SelectedInstance()
+	if inst == nil {
+		return nil, false
+	}
+	_, ok := m.running[inst.ID]
+	return inst, ok
+}
+
 func (m *HomeModel) SetAccountManager(am *core.AccountManager) {
 	m.accounts = am
 }
@@ -306,7 +453,21 @@ func (m *HomeModel) buildFooter() string {
 		{"n", "new"},
 		{"m", modsLabel},
 	}
+	if _, running := m.selectedRunning(); running {
+		primaryItems = []KeyHint{
+			{"↵", "show"},
+			{"x", "stop"},
+			{"X", "kill"},
+			{"n", "new"},
+		}
+	}
 	secondaryItems := []KeyHint{
+		{"c", "connect"},
+		{"e", "edit"},
+		{"t", "sort"},
+		{"L", "logs"},
+		{"v", "verify"},
+		{"J", "java"},
 		{"p", "resource packs"},
 		{"f", "folder"},
 		{"d", "delete"},
@@ -379,6 +540,7 @@ func (m *HomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case InstancesLoaded:
 		if msg.Error == nil {
+			m.recentPlay = msg.RecentPlayTime
 			m.SetInstances(msg.Instances, msg.SelectID)
 		}
 		return m, nil
@@ -389,6 +551,14 @@ func (m *HomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.applyListSize()
 		}
 
+		if m.quickPlay != nil {
+			done, cmd := m.quickPlay.update(msg)
+			if done {
+				m.quickPlay = nil
+			}
+			return m, cmd
+		}
+
 		// Handle delete confirmation mode
 		if m.confirmDelete {
 			if ConfirmKeyToggles(msg.String()) {
@@ -424,7 +594,18 @@ func (m *HomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			break
 		}
 
+		// Launch keys on a launching or running instance show it instead of starting another copy.
+		if inst, ok := m.selectedRunning(); ok &&
+			(key.Matches(msg, m.keys.Launch) || key.Matches(msg, m.keys.PlayOffline) || key.Matches(msg, m.keys.QuickPlay)) {
+			return m, func() tea.Msg { return FocusLaunch{Instance: inst} }
+		}
+
 		switch {
+		case key.Matches(msg, m.keys.Stop), key.Matches(msg, m.keys.Kill):
+			if inst, ok := m.selectedRunning(); ok {
+				force := key.Matches(msg, m.keys.Kill)
+				return m, func() tea.Msg { return StopInstance{Instance: inst, Force: force} }
+			}
 		case key.Matches(msg, m.keys.Launch):
 			if inst := m.SelectedInstance(); inst != nil {
 				// If authenticated, launch online. Else go to auth.
@@ -438,6 +619,30 @@ func (m *HomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if inst := m.SelectedInstance(); inst != nil {
 				return m, func() tea.Msg { return NavigateToLaunch{Instance: inst, Offline: true} }
 			}
+		case key.Matches(msg, m.keys.QuickPlay):
+			if inst := m.SelectedInstance(); inst != nil {
+				if !inst.QuickPlay.Valid() {
+					m.quickPlay = newQuickPlayPicker(inst)
+					return m, textinput.Blink
+				}
+				online := m.accounts != nil && m.accounts.GetActive() != nil
+				if !online {
+					return m, func() tea.Msg { return NavigateToAuth{} }
+				}
+				return m, func() tea.Msg { return NavigateToLaunch{Instance: inst, QuickPlay: true} }
+			}
+		case key.Matches(msg, m.keys.EditQuick):
+			if inst := m.SelectedInstance(); inst != nil {
+				m.quickPlay = newQuickPlayPicker(inst)
+				return m, textinput.Blink
+			}
+		case key.Matches(msg, m.keys.Sort):
+			next := HomeSortPlayTime
+			if m.sortBy == HomeSortPlayTime {
+				next = HomeSortRecent
+			}
+			m.SetSort(next)
+			return m, func() tea.Msg { return PersistHomeSort{Value: next} }
 		case key.Matches(msg, m.keys.NewInst):
 			return m, func() tea.Msg { return NavigateToNewInstance{} }
 		case key.Matches(msg, m.keys.Mods):
@@ -455,6 +660,21 @@ func (m *HomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case key.Matches(msg, m.keys.Settings):
 			return m, func() tea.Msg { return NavigateToSettings{} }
+		case key.Matches(msg, m.keys.Edit):
+			if inst := m.SelectedInstance(); inst != nil {
+				return m, func() tea.Msg { return NavigateToInstanceSettings{Instance: inst} }
+			}
+		case key.Matches(msg, m.keys.Logs):
+			if inst := m.SelectedInstance(); inst != nil {
+				return m, func() tea.Msg { return NavigateToLogs{Instance: inst} }
+			}
+		case key.Matches(msg, m.keys.Verify):
+			if inst := m.SelectedInstance(); inst != nil {
+				return m, func() tea.Msg { return NavigateToRepair{Instance: inst} }
+			}
+		case key.Matches(msg, m.keys.Java):
+			inst := m.SelectedInstance()
+			return m, func() tea.Msg { return NavigateToJava{Instance: inst} }
 		case key.Matches(msg, m.keys.Auth):
 			return m, func() tea.Msg { return NavigateToAuth{} }
 		case key.Matches(msg, m.keys.OpenFolder):
@@ -555,6 +775,10 @@ func (m *HomeModel) View() string {
 
 	baseView := lipgloss.JoinVertical(lipgloss.Left, aboveStatus...)
 
+	if m.quickPlay != nil {
+		return m.quickPlay.view(m.width, m.height)
+	}
+
 	// Show delete confirmation overlay if needed
 	if m.confirmDelete && m.deleteTarget != nil {
 		return ConfirmDialog{
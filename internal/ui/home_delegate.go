@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/lipgloss"
@@ -33,9 +34,18 @@ func newBadgeDimmed() lipgloss.Style {
 		Padding(0, 1)
 }
 
+// runningBadge marks an instance that is launching or running, in every list state.
+func runningBadge() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Background(Active.Success).
+		Foreground(OnColor(Active.Success)).
+		Padding(0, 1)
+}
+
 const titleEllipsis = "…"
 
-// homeInstanceDelegate wraps the default list delegate so never-played instances get a separate "new" badge.
+// homeInstanceDelegate wraps the default list delegate so never-played instances get a separate "new"
+// badge, and launching or running ones a "running" badge.
 type homeInstanceDelegate struct {
 	list.DefaultDelegate
 }
@@ -50,7 +60,16 @@ func (d *homeInstanceDelegate) Render(w io.Writer, m list.Model, index int, item
 	s := &d.Styles
 	name := ii.instance.Name
 	desc := ii.Description()
-	showBadge := ii.instance.LastPlayed.IsZero()
+	badgeText := ""
+	switch {
+	case ii.running != nil && ii.running.Started.IsZero():
+		badgeText = "launching"
+	case ii.running != nil:
+		badgeText = GlyphDot + " running " + formatPlayTime(time.Since(ii.running.Started))
+	case ii.instance.LastPlayed.IsZero():
+		badgeText = "new"
+	}
+	showBadge := badgeText != ""
 
 	if m.Width() <= 0 {
 		return
@@ -59,7 +78,7 @@ func (d *homeInstanceDelegate) Render(w io.Writer, m list.Model, index int, item
 	textwidth := m.Width() - s.NormalTitle.GetPaddingLeft() - s.NormalTitle.GetPaddingRight()
 	badgeReserve := 0
 	if showBadge {
-		badgeReserve = lipgloss.Width(newBadgeNormal().Render("new")) + 1
+		badgeReserve = lipgloss.Width(newBadgeNormal().Render(badgeText)) + 1
 	}
 
 	title := ansi.Truncate(name, textwidth-badgeReserve, titleEllipsis)
@@ -87,8 +106,11 @@ func (d *homeInstanceDelegate) Render(w io.Writer, m list.Model, index int, item
 
 	joinTitle := func(titleLine string, badge lipgloss.Style) string {
 		t := titleLine
+		if ii.running != nil {
+			badge = runningBadge()
+		}
 		if showBadge {
-			return lipgloss.JoinHorizontal(lipgloss.Left, t, " ", badge.Render("new"))
+			return lipgloss.JoinHorizontal(lipgloss.Left, t, " ", badge.Render(badgeText))
 		}
 		return t
 	}
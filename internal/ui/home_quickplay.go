@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// quickPlayPicker edits an instance's Quick Play target from the home screen: a kind
+// selector (world / server / Realm) plus a free-text target. Enter emits
+// [SaveQuickPlay]; an empty target clears the instance's Quick Play.
+type quickPlayPicker struct {
+	instance *core.Instance
+	kindIdx  int
+	input    textinput.Model
+}
+
+func newQuickPlayPicker(inst *core.Instance) *quickPlayPicker {
+	ti := textinput.New()
+	ti.CharLimit = 256
+	ti.Width = 40
+	ThemeTextInput(&ti)
+
+	p := &quickPlayPicker{instance: inst, kindIdx: 1} // default to server, the common case
+	if q := inst.QuickPlay; q != nil {
+		for i, k := range core.QuickPlayKinds {
+			if k == q.Kind {
+				p.kindIdx = i
+			}
+		}
+		ti.SetValue(q.Target)
+	}
+	p.input = ti
+	p.input.Focus()
+	p.syncPlaceholder()
+	return p
+}
+
+func (p *quickPlayPicker) kind() core.QuickPlayKind {
+	return core.QuickPlayKinds[p.kindIdx]
+}
+
+func (p *quickPlayPicker) syncPlaceholder() {
+	switch p.kind() {
+	case core.QuickPlaySingleplayer:
+		p.input.Placeholder = "World folder name, e.g. New World"
+	case core.QuickPlayMultiplayer:
+		p.input.Placeholder = "host or host:port"
+	case core.QuickPlayRealms:
+		p.input.Placeholder = "Realm ID"
+	}
+}
+
+// update handles a key while the picker is open. done is true when the picker should close.
+func (p *quickPlayPicker) update(msg tea.KeyMsg) (done bool, cmd tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		return true, nil
+	case "tab", "shift+tab":
+		n := len(core.QuickPlayKinds)
+		delta := 1
+		if msg.String() == "shift+tab" {
+			delta = -1
+		}
+		p.kindIdx = (p.kindIdx + delta + n) % n
+		p.syncPlaceholder()
+		return false, nil
+	case "enter":
+		inst := p.instance
+		var q *core.QuickPlay
+		if target := strings.TrimSpace(p.input.Value()); target != "" {
+			q = &core.QuickPlay{Kind: p.kind(), Target: target}
+		}
+		return true, func() tea.Msg { return SaveQuickPlay{Instance: inst, QuickPlay: q} }
+	}
+	p.input, cmd = p.input.Update(msg)
+	return false, cmd
+}
+
+func (p *quickPlayPicker) view(w, h int) string {
+	panelW := min(56, max(34, w-8))
+
+	var kinds []string
+	for i, k := range core.QuickPlayKinds {
+		label := map[core.QuickPlayKind]string{
+			core.QuickPlaySingleplayer: "World",
+			core.QuickPlayMultiplayer:  "Server",
+			core.QuickPlayRealms:       "Realm",
+		}[k]
+		if i == p.kindIdx {
+			kinds = append(kinds, lipgloss.NewStyle().Bold(true).Foreground(Active.Primary).Render(GlyphPointer+" "+label))
+		} else {
+			kinds = append(kinds, lipgloss.NewStyle().Foreground(Active.TextMuted).Render("  "+label))
+		}
+	}
+	kindRow := strings.Join(kinds, "    ")
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Active.Success).
+		Padding(0, 1).
+		Render(p.input.View())
+	note := lipgloss.NewStyle().Foreground(Active.TextMuted).
+		Render("Leave empty to clear. Worlds and Realms need Minecraft 1.20+.")
+
+	body := lipgloss.JoinVertical(lipgloss.Left, kindRow, "", box, note)
+	panel := Panel("Quick Play: "+p.instance.Name, body, panelW, Active.Primary)
+	hint := lipgloss.NewStyle().MarginTop(1).Render(KeyHints(panelW,
+		KeyHint{"tab", "kind"},
+		KeyHint{"↵", "save"},
+		KeyHint{"esc", "cancel"},
+	))
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, lipgloss.JoinVertical(lipgloss.Left, panel, hint))
+}
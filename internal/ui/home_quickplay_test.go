@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/aayushdutt/mctui/internal/core"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestQuickPlayPicker_SavesTargetAndKind(t *testing.T) {
+	inst := &core.Instance{ID: "a", Name: "Survival"}
+	p := newQuickPlayPicker(inst)
+	if p.kind() != core.QuickPlayMultiplayer {
+		t.Fatalf("default kind = %q, want multiplayer", p.kind())
+	}
+
+	p.input.SetValue("  mc.example.net:25570 ")
+	done, cmd := p.update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !done || cmd == nil {
+		t.Fatal("enter should close the picker and emit a command")
+	}
+	saved, ok := cmd().(SaveQuickPlay)
+	if !ok {
+		t.Fatalf("expected SaveQuickPlay, got %T", cmd())
+	}
+	want := core.QuickPlay{Kind: core.QuickPlayMultiplayer, Target: "mc.example.net:25570"}
+	if saved.Instance != inst || saved.QuickPlay == nil || *saved.QuickPlay != want {
+		t.Fatalf("saved = %+v, want %+v for %q", saved.QuickPlay, want, inst.Name)
+	}
+}
+
+func TestQuickPlayPicker_TabCyclesKindAndEmptyClears(t *testing.T) {
+	inst := &core.Instance{Name: "Creative", QuickPlay: &core.QuickPlay{Kind: core.QuickPlayRealms, Target: "42"}}
+	p := newQuickPlayPicker(inst)
+	if p.kind() != core.QuickPlayRealms || p.input.Value() != "42" {
+		t.Fatalf("picker should seed from instance, got %q %q", p.kind(), p.input.Value())
+	}
+
+	p.update(tea.KeyMsg{Type: tea.KeyTab})
+	if p.kind() != core.QuickPlaySingleplayer {
+		t.Fatalf("tab should wrap to singleplayer, got %q", p.kind())
+	}
+
+	p.input.SetValue("")
+	_, cmd := p.update(tea.KeyMsg{Type: tea.KeyEnter})
+	if saved := cmd().(SaveQuickPlay); saved.QuickPlay != nil {
+		t.Fatalf("empty target should clear Quick Play, got %+v", saved.QuickPlay)
+	}
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aayushdutt/mctui/internal/core"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFormatPlayTime(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Second, "<1m"},
+		{45 * time.Minute, "45m"},
+		{3*time.Hour + 20*time.Minute, "3h 20m"},
+		{120 * time.Hour, "120h"},
+	}
+	for _, tt := range tests {
+		if got := formatPlayTime(tt.d); got != tt.want {
+			t.Errorf("formatPlayTime(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestHome_SortByPlayTime(t *testing.T) {
+	now := time.Now()
+	m := NewHomeModel()
+	m.SetSize(100, 30)
+	m.Update(InstancesLoaded{
+		Instances: []*core.Instance{
+			{ID: "recent", Name: "Recent", LastPlayed: now, PlayTime: 600},
+			{ID: "grind", Name: "Grind", LastPlayed: now.Add(-48 * time.Hour), PlayTime: 36000},
+		},
+		RecentPlayTime: map[string]time.Duration{"grind": 2 * time.Hour},
+	})
+	if got := m.SelectedInstance(); got == nil || got.ID != "recent" {
+		t.Fatalf("default order should put the most recent first, got %+v", got)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if persisted, ok := cmd().(PersistHomeSort); !ok || persisted.Value != HomeSortPlayTime {
+		t.Fatalf("sort key should persist playtime order, got %+v", cmd())
+	}
+	if first := m.list.Items()[0].(instanceItem); first.instance.ID != "grind" {
+		t.Fatalf("playtime order should put the most played first, got %q", first.instance.ID)
+	}
+	if desc := m.list.Items()[0].(instanceItem).Description(); !strings.Contains(desc, "10h 0m played (2h 0m this week)") {
+		t.Fatalf("description = %q, want total and recent playtime", desc)
+	}
+}
+
+func TestHome_RunningInstanceKeys(t *testing.T) {
+	m := NewHomeModel()
+	m.SetSize(100, 30)
+	m.Update(InstancesLoaded{Instances: []*core.Instance{{ID: "a", Name: "Survival"}}})
+	m.SetRunning(map[string]RunningInstance{"a": {Started: time.Now()}})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if focus, ok := cmd().(FocusLaunch); !ok || focus.Instance.ID != "a" {
+		t.Fatalf("enter on a running instance should focus it, got %+v", cmd())
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	if stop, ok := cmd().(StopInstance); !ok || !stop.Force {
+		t.Fatalf("X should force-stop the running instance, got %+v", cmd())
+	}
+
+	m.SetRunning(nil)
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if cmd != nil {
+		if _, ok := cmd().(StopInstance); ok {
+			t.Fatal("x should do nothing once the instance has stopped")
+		}
+	}
+}
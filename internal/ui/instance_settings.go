@@ -0,0 +1,547 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/aayushdutt/mctui/internal/config"
+	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/launch"
+	"github.com/aayushdutt/mctui/internal/memory"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// instanceSettingsFocus is which control is active on the instance settings form.
+type instanceSettingsFocus int
+
+const (
+	focusInstMaxMemory instanceSettingsFocus = iota
+	focusInstMinMemory
+	focusInstJVMPreset
+	focusInstJVMArgs
+	focusInstWindowSize
+	focusInstFullscreen
+	focusInstDetach
+	focusInstEnv
+	focusInstPreLaunch
+	focusInstWrapper
+	focusInstPostExit
+	focusInstSave
+)
+
+// instanceSettingsFocusOrder is the fixed Tab order of the form.
+var instanceSettingsFocusOrder = []instanceSettingsFocus{
+	focusInstMaxMemory,
+	focusInstMinMemory,
+	focusInstJVMPreset,
+	focusInstJVMArgs,
+	focusInstWindowSize,
+	focusInstFullscreen,
+	focusInstDetach,
+	focusInstEnv,
+	focusInstPreLaunch,
+	focusInstWrapper,
+	focusInstPostExit,
+	focusInstSave,
+}
+
+// overrideChoices are the tri-state values of an on/off override, in ←/→ order.
+// nil inherits the global default.
+var overrideChoices = []*bool{nil, boolPtr(true), boolPtr(false)}
+
+// overrideIndex returns v's index into overrideChoices.
+func overrideIndex(v *bool) int {
+	switch {
+	case v == nil:
+		return 0
+	case *v:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
+
+// InstanceSettingsModel edits per-instance overrides of global settings. Empty
+// values inherit from [config.Config]; submit emits [InstanceSettingsSaved].
+type InstanceSettingsModel struct {
+	width  int
+	height int
+
+	instance *core.Instance
+	cfg      *config.Config
+
+	focus instanceSettingsFocus
+
+	maxMemory memorySlider
+	minMemory memorySlider
+	system    memory.System // the machine's RAM, for the sliders and warnings
+	modCount  int           // installed mods, for the recommendation
+
+	presetIdx int // 0 is no preset, then index+1 into launch.JVMPresets
+	jvmArgs   textinput.Model
+
+	windowSize    textinput.Model
+	fullscreenIdx int // index into overrideChoices
+	detachIdx     int // index into overrideChoices
+	env           textinput.Model
+	preLaunch     textinput.Model
+	wrapper       textinput.Model
+	postExit      textinput.Model
+
+	saveErr string
+}
+
+// NewInstanceSettingsModel seeds the form from inst; cfg supplies the inherited defaults shown as hints.
+func NewInstanceSettingsModel(inst *core.Instance, cfg *config.Config) *InstanceSettingsModel {
+	placeholder := "Global default"
+	if def := formatWindowSize(cfg.WindowWidth, cfg.WindowHeight); def != "" {
+		placeholder = "Global default (" + def + ")"
+	}
+	ti := textinput.New()
+	ti.SetValue(formatWindowSize(inst.WindowWidth, inst.WindowHeight))
+	ti.Placeholder = placeholder
+	ti.CharLimit = 16
+	ti.Width = 48
+	ThemeTextInput(&ti)
+
+	m := &InstanceSettingsModel{
+		instance:   inst,
+		cfg:        cfg,
+		maxMemory:  newMemorySlider(inst.MaxMemoryMB, 0),
+		minMemory:  newMemorySlider(inst.MinMemoryMB, 0),
+		windowSize: ti,
+		presetIdx:  presetIndex(inst.JVMPreset),
+		jvmArgs:    jvmArgsInput(inst, cfg),
+		env:        envInput(inst, cfg),
+		preLaunch:  hookInput(inst.PreLaunchCommand, "e.g. ./sync-configs.sh"),
+		wrapper:    hookInput(inst.WrapperCommand, "e.g. gamemoderun, mangohud, prime-run"),
+		postExit:   hookInput(inst.PostExitCommand, "e.g. ./backup-worlds.sh"),
+
+		fullscreenIdx: overrideIndex(inst.Fullscreen),
+		detachIdx:     overrideIndex(inst.Detach),
+	}
+	m.applyFocus(focusInstMaxMemory)
+	return m
+}
+
+// envInput seeds the environment field; the global overrides show as its placeholder.
+func envInput(inst *core.Instance, cfg *config.Config) textinput.Model {
+	placeholder := "e.g. MESA_GL_VERSION_OVERRIDE=4.5 -_JAVA_OPTIONS"
+	if global := formatEnvVars(cfg.Env, cfg.UnsetEnv); global != "" {
+		placeholder = "Global: " + global
+	}
+	return hookInput(formatEnvVars(inst.Env, inst.UnsetEnv), placeholder)
+}
+
+// jvmArgsInput seeds the JVM arguments field; the global ones show as its
+// placeholder unless the instance overrides them with none.
+func jvmArgsInput(inst *core.Instance, cfg *config.Config) textinput.Model {
+	placeholder := "e.g. -Dsodium.checks.issue2561=false"
+	switch {
+	case inst.JVMArgsOverride && len(cfg.JVMArgs) > 0:
+		placeholder = "None (global arguments not used)"
+	case len(cfg.JVMArgs) > 0:
+		placeholder = "Global: " + strings.Join(cfg.JVMArgs, " ")
+	}
+	return hookInput(strings.Join(inst.JVMArgs, " "), placeholder)
+}
+
+// presetIndex returns id's position in the preset picker, where 0 is none.
+func presetIndex(id string) int {
+	for i, p := range launch.JVMPresets {
+		if p.ID == id {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func hookInput(value, placeholder string) textinput.Model {
+	ti := textinput.New()
+	ti.SetValue(value)
+	ti.Placeholder = placeholder
+	ti.CharLimit = 1024
+	ti.Width = 48
+	ThemeTextInput(&ti)
+	return ti
+}
+
+// SetSize updates dimensions.
+func (m *InstanceSettingsModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	w := min(60, max(24, width-6))
+	m.windowSize.Width = w
+	m.jvmArgs.Width = w
+	m.env.Width = w
+	m.preLaunch.Width = w
+	m.wrapper.Width = w
+	m.postExit.Width = w
+}
+
+// SetMemoryInfo gives the machine's RAM and the instance's installed mod
+// count, which size the memory sliders and drive the recommendation.
+func (m *InstanceSettingsModel) SetMemoryInfo(sys memory.System, modCount int) {
+	m.system = sys
+	m.modCount = modCount
+	m.maxMemory.setTotal(sys.TotalMB)
+	m.minMemory.setTotal(sys.TotalMB)
+}
+
+// Init implements tea.Model.
+func (m *InstanceSettingsModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m *InstanceSettingsModel) focusedInput() *textinput.Model {
+	switch m.focus {
+	case focusInstWindowSize:
+		return &m.windowSize
+	case focusInstJVMArgs:
+		return &m.jvmArgs
+	case focusInstEnv:
+		return &m.env
+	case focusInstPreLaunch:
+		return &m.preLaunch
+	case focusInstWrapper:
+		return &m.wrapper
+	case focusInstPostExit:
+		return &m.postExit
+	}
+	return nil
+}
+
+func (m *InstanceSettingsModel) applyFocus(f instanceSettingsFocus) {
+	m.focus = f
+	m.windowSize.Blur()
+	m.jvmArgs.Blur()
+	m.env.Blur()
+	m.preLaunch.Blur()
+	m.wrapper.Blur()
+	m.postExit.Blur()
+	if in := m.focusedInput(); in != nil {
+		in.Focus()
+	}
+}
+
+func (m *InstanceSettingsModel) cycleFocus(delta int) {
+	idx := 0
+	for i, f := range instanceSettingsFocusOrder {
+		if f == m.focus {
+			idx = i
+			break
+		}
+	}
+	n := len(instanceSettingsFocusOrder)
+	m.applyFocus(instanceSettingsFocusOrder[(idx+delta+n)%n])
+}
+
+// focusedOverride returns the index of the focused on/off override, if any.
+func (m *InstanceSettingsModel) focusedOverride() *int {
+	switch m.focus {
+	case focusInstFullscreen:
+		return &m.fullscreenIdx
+	case focusInstDetach:
+		return &m.detachIdx
+	}
+	return nil
+}
+
+// focusedSlider returns the focused memory slider, if any.
+func (m *InstanceSettingsModel) focusedSlider() *memorySlider {
+	switch m.focus {
+	case focusInstMaxMemory:
+		return &m.maxMemory
+	case focusInstMinMemory:
+		return &m.minMemory
+	}
+	return nil
+}
+
+// effectiveMemory is the heap the instance would launch with: its own
+// settings, then the global ones, then the defaults and recommendation.
+func (m *InstanceSettingsModel) effectiveMemory() (minMB, maxMB int) {
+	minMB = cmp.Or(m.minMemory.mb, m.cfg.MinMemoryMB, memory.DefaultMinMB)
+	maxMB = cmp.Or(m.maxMemory.mb, m.cfg.MaxMemoryMB, m.recommendation().MaxMB)
+	return minMB, maxMB
+}
+
+func (m *InstanceSettingsModel) recommendation() memory.Recommendation {
+	return memory.Recommend(m.system.TotalMB, m.modCount)
+}
+
+func cycleOverride(idx *int, delta int) {
+	n := len(overrideChoices)
+	*idx = (*idx + delta + n) % n
+}
+
+// Update implements tea.Model.
+func (m *InstanceSettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if s := m.focusedSlider(); s != nil && s.handleKey(msg.String()) {
+			return m, nil
+		}
+		if m.focus == focusInstJVMPreset {
+			switch msg.String() {
+			case "left", "h":
+				m.presetIdx = (m.presetIdx + len(launch.JVMPresets)) % (len(launch.JVMPresets) + 1)
+				return m, nil
+			case "right", "l", " ", "space":
+				m.presetIdx = (m.presetIdx + 1) % (len(launch.JVMPresets) + 1)
+				return m, nil
+			}
+		}
+		if idx := m.focusedOverride(); idx != nil {
+			switch msg.String() {
+			case "left", "h":
+				cycleOverride(idx, -1)
+				return m, nil
+			case "right", "l", " ", "space":
+				cycleOverride(idx, 1)
+				return m, nil
+			}
+		}
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return NavigateToHome{} }
+		case "tab", "down":
+			m.cycleFocus(1)
+			return m, textinput.Blink
+		case "shift+tab", "up":
+			m.cycleFocus(-1)
+			return m, textinput.Blink
+		case "enter":
+			return m.submit()
+		}
+	}
+
+	if in := m.focusedInput(); in != nil {
+		var cmd tea.Cmd
+		*in, cmd = in.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m *InstanceSettingsModel) submit() (*InstanceSettingsModel, tea.Cmd) {
+	width, height, err := parseWindowSize(m.windowSize.Value())
+	if err != nil {
+		m.saveErr = "Window size: " + err.Error()
+		m.applyFocus(focusInstWindowSize)
+		return m, textinput.Blink
+	}
+	env, unsetEnv, err := parseEnvVars(m.env.Value())
+	if err != nil {
+		m.saveErr = "Environment variables: " + err.Error()
+		m.applyFocus(focusInstEnv)
+		return m, textinput.Blink
+	}
+	if _, err := launch.SplitWords(m.wrapper.Value()); err != nil {
+		m.saveErr = "Wrapper command: " + err.Error()
+		m.applyFocus(focusInstWrapper)
+		return m, textinput.Blink
+	}
+	if minMB, maxMB := m.effectiveMemory(); m.minMemory.mb > 0 && minMB > maxMB {
+		m.saveErr = fmt.Sprintf("Memory: the minimum is above the maximum (%s)", memory.Format(maxMB))
+		m.applyFocus(focusInstMinMemory)
+		return m, nil
+	}
+	m.saveErr = ""
+
+	override := func(idx int) *bool {
+		if v := overrideChoices[idx]; v != nil {
+			return boolPtr(*v)
+		}
+		return nil
+	}
+	saved := InstanceSettingsSaved{
+		Instance:     m.instance,
+		WindowWidth:  width,
+		WindowHeight: height,
+		Fullscreen:   override(m.fullscreenIdx),
+		Detach:       override(m.detachIdx),
+		MinMemoryMB:  m.minMemory.mb,
+		MaxMemoryMB:  m.maxMemory.mb,
+		JVMPreset:    m.preset().ID,
+		JVMArgs:      strings.Fields(m.jvmArgs.Value()),
+
+		PreLaunchCommand: strings.TrimSpace(m.preLaunch.Value()),
+		WrapperCommand:   strings.TrimSpace(m.wrapper.Value()),
+		PostExitCommand:  strings.TrimSpace(m.postExit.Value()),
+
+		Env:      env,
+		UnsetEnv: unsetEnv,
+	}
+	return m, func() tea.Msg { return saved }
+}
+
+// preset returns the picked JVM preset; the zero preset is none.
+func (m *InstanceSettingsModel) preset() launch.JVMPreset {
+	if m.presetIdx == 0 {
+		return launch.JVMPreset{}
+	}
+	return launch.JVMPresets[m.presetIdx-1]
+}
+
+// presetBlock renders the JVM preset picker with what the picked one does.
+func (m *InstanceSettingsModel) presetBlock() string {
+	p := m.preset()
+	value, about := "None", "Only the JVM arguments below."
+	if p.ID != "" {
+		value = p.Name
+		about = fmt.Sprintf("%s Java %d+, %d flags, added before the JVM arguments.", p.Description, p.MinJava, len(p.Flags))
+	}
+	row := overrideRow("JVM preset", value, m.focus == focusInstJVMPreset)
+	desc := lipgloss.NewStyle().PaddingLeft(2).Foreground(Active.TextMuted).Width(max(24, min(72, m.width-4))).Render(about)
+	return lipgloss.JoinVertical(lipgloss.Left, row, desc)
+}
+
+func (m *InstanceSettingsModel) fullscreenLabel() string {
+	return overrideLabel(m.fullscreenIdx, m.cfg.Fullscreen)
+}
+
+func (m *InstanceSettingsModel) detachLabel() string {
+	return overrideLabel(m.detachIdx, m.cfg.DetachGames)
+}
+
+// overrideLabel names an override choice, showing the inherited value for the default.
+func overrideLabel(idx int, def bool) string {
+	v := overrideChoices[idx]
+	switch {
+	case v == nil && def:
+		return "Default (on)"
+	case v == nil:
+		return "Default (off)"
+	case *v:
+		return "On"
+	default:
+		return "Off"
+	}
+}
+
+// memoryBlocks renders the maximum and minimum memory sliders, showing what
+// "inherit" resolves to and warning when the heap may not fit.
+func (m *InstanceSettingsModel) memoryBlocks() (maxBlock, minBlock string) {
+	minMB, maxMB := m.effectiveMemory()
+	rec := m.recommendation()
+
+	maxValue := memory.Format(maxMB)
+	switch {
+	case m.maxMemory.mb == 0 && m.cfg.MaxMemoryMB > 0:
+		maxValue = "Default (" + maxValue + ", global)"
+	case m.maxMemory.mb == 0:
+		maxValue = "Default (" + maxValue + ", recommended)"
+	}
+	maxHint := "Recommended " + memory.Format(rec.MaxMB) + " " + rec.Reason + " · " + memorySliderKeys
+	maxBlock = memorySliderRow("Maximum memory", maxValue, maxHint,
+		memory.Warning(maxMB, m.system), m.maxMemory, m.focus == focusInstMaxMemory)
+
+	minValue := memory.Format(minMB)
+	if m.minMemory.mb == 0 {
+		minValue = "Default (" + minValue + ")"
+	}
+	minBlock = memorySliderRow("Minimum memory", minValue, "Heap the game starts with · "+memorySliderKeys,
+		"", m.minMemory, m.focus == focusInstMinMemory)
+	return maxBlock, minBlock
+}
+
+// overrideRow renders an on/off override selector, styled to match the Settings theme picker.
+func overrideRow(title, value string, focused bool) string {
+	arrowFg := Active.TextDim
+	if focused {
+		arrowFg = Active.Success
+	}
+	arrowStyle := lipgloss.NewStyle().Foreground(arrowFg)
+	picker := lipgloss.JoinHorizontal(lipgloss.Top,
+		arrowStyle.Render("‹ "),
+		lipgloss.NewStyle().Bold(true).Foreground(Active.Title).Render(value),
+		arrowStyle.Render(" ›"),
+	)
+	label := lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.NewStyle().Foreground(Active.Title).Render(title),
+		lipgloss.NewStyle().Foreground(Active.TextDim).Render("←/→ to change"),
+	)
+	row := lipgloss.JoinHorizontal(lipgloss.Top, picker, "  ", label)
+	rowStyle := lipgloss.NewStyle().PaddingLeft(2)
+	if focused {
+		rowStyle = lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(Active.Success).
+			Background(Active.BorderFaint).
+			PaddingLeft(1).
+			PaddingRight(1)
+	}
+	return rowStyle.Render(row)
+}
+
+// View implements tea.Model.
+func (m *InstanceSettingsModel) View() string {
+	header := ScreenHeader("Instance settings", fmt.Sprintf("%s · empty fields inherit global settings", m.instance.Name))
+
+	field := func(label, hint string, in textinput.Model, focused bool) string {
+		border := Active.BorderSubtle
+		if focused {
+			border = Active.Success
+		}
+		lbl := lipgloss.NewStyle().Foreground(Active.TextDim).Render(label)
+		box := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(border).
+			Padding(0, 1).
+			Render(in.View())
+		hintLine := lipgloss.NewStyle().Foreground(Active.TextMuted).Render(hint)
+		return lipgloss.JoinVertical(lipgloss.Left, lbl, box, hintLine)
+	}
+
+	windowBlock := field("Window size", "WIDTHxHEIGHT, e.g. 1920x1080.", m.windowSize, m.focus == focusInstWindowSize)
+	envBlock := field("Environment variables", "KEY=VALUE sets, -KEY unsets; applied after the global ones.", m.env, m.focus == focusInstEnv)
+	preLaunchBlock := field("Pre-launch command", "Runs in the game folder before launch; a failure stops the launch.", m.preLaunch, m.focus == focusInstPreLaunch)
+	wrapperBlock := field("Wrapper command", "Prefixed to the java command line.", m.wrapper, m.focus == focusInstWrapper)
+	postExitBlock := field("Post-exit command", "Runs after the game exits. Hooks get $INST_NAME, $INST_DIR, $INST_MC_DIR, $INST_JAVA.", m.postExit, m.focus == focusInstPostExit)
+
+	fullscreenBlock := overrideRow("Fullscreen", m.fullscreenLabel(), m.focus == focusInstFullscreen)
+	detachBlock := overrideRow("Keep running after quitting mctui", m.detachLabel(), m.focus == focusInstDetach)
+
+	maxMemoryBlock, minMemoryBlock := m.memoryBlocks()
+	presetBlock := m.presetBlock()
+	jvmBlock := field("JVM arguments", "Space-separated; replace the global ones. Conflicts with the preset are reported before launch.", m.jvmArgs, m.focus == focusInstJVMArgs)
+
+	saveBtn := lipgloss.NewStyle().MarginTop(1).Render(wizardFormButton("Save", m.focus == focusInstSave, true))
+
+	errBlock := ""
+	if m.saveErr != "" {
+		errBlock = lipgloss.NewStyle().Foreground(Active.Error).MarginTop(1).Render(m.saveErr)
+	}
+
+	help := lipgloss.NewStyle().MarginTop(1).Render(KeyHints(max(40, m.width-4),
+		KeyHint{"tab", "move"},
+		KeyHint{"←→", "change"},
+		KeyHint{"enter", "save"},
+		KeyHint{"esc", "cancel"},
+	))
+
+	// Blocks follow instanceSettingsFocusOrder so the focused one can be kept on screen.
+	blocks := []string{maxMemoryBlock, minMemoryBlock, presetBlock, jvmBlock, windowBlock, fullscreenBlock, detachBlock, envBlock, preLaunchBlock, wrapperBlock, postExitBlock, saveBtn}
+	focused := 0
+	for i, f := range instanceSettingsFocusOrder {
+		if f == m.focus {
+			focused = i
+		}
+	}
+	bodyH := m.height - lipgloss.Height(header) - 1 - lipgloss.Height(errBlock) - lipgloss.Height(help)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		"",
+		FormWindow(blocks, focused, bodyH),
+		errBlock,
+		help,
+	)
+}
@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aayushdutt/mctui/internal/config"
+	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/memory"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestInstanceSettings_SeedsAndSubmitsOverrides(t *testing.T) {
+	off := false
+	inst := &core.Instance{Name: "Survival", WindowWidth: 854, WindowHeight: 480, Fullscreen: &off}
+	m := NewInstanceSettingsModel(inst, &config.Config{})
+	if got := m.windowSize.Value(); got != "854x480" {
+		t.Fatalf("windowSize seed = %q, want 854x480", got)
+	}
+	if got := m.fullscreenLabel(); got != "Off" {
+		t.Fatalf("fullscreen seed = %q, want Off", got)
+	}
+
+	m.windowSize.SetValue("1920x1080")
+	m.applyFocus(focusInstFullscreen)
+	m.Update(tea.KeyMsg{Type: tea.KeyLeft}) // Off -> On
+
+	_, cmd := m.Update(keyEnter())
+	saved, ok := cmd().(InstanceSettingsSaved)
+	if !ok {
+		t.Fatalf("expected InstanceSettingsSaved, got %T", cmd())
+	}
+	if saved.Instance != inst || saved.WindowWidth != 1920 || saved.WindowHeight != 1080 {
+		t.Fatalf("saved = %+v, want 1920x1080 for %q", saved, inst.Name)
+	}
+	if saved.Fullscreen == nil || !*saved.Fullscreen {
+		t.Fatalf("Fullscreen = %v, want explicit on", saved.Fullscreen)
+	}
+}
+
+func TestInstanceSettings_DefaultsInherit(t *testing.T) {
+	m := NewInstanceSettingsModel(&core.Instance{Name: "Fresh"}, &config.Config{Fullscreen: true})
+	if got := m.fullscreenLabel(); got != "Default (on)" {
+		t.Fatalf("fullscreen label = %q, want Default (on)", got)
+	}
+
+	_, cmd := m.Update(keyEnter())
+	saved := cmd().(InstanceSettingsSaved)
+	if saved.WindowWidth != 0 || saved.WindowHeight != 0 || saved.Fullscreen != nil {
+		t.Fatalf("empty form should inherit everything, got %+v", saved)
+	}
+}
+
+func TestInstanceSettings_SubmitsHooks(t *testing.T) {
+	inst := &core.Instance{Name: "Modded", WrapperCommand: "gamemoderun"}
+	m := NewInstanceSettingsModel(inst, &config.Config{})
+	if got := m.wrapper.Value(); got != "gamemoderun" {
+		t.Fatalf("wrapper seed = %q, want gamemoderun", got)
+	}
+
+	m.preLaunch.SetValue("  ./sync.sh ")
+	m.wrapper.SetValue("")
+	m.postExit.SetValue("./backup.sh")
+
+	_, cmd := m.Update(keyEnter())
+	saved := cmd().(InstanceSettingsSaved)
+	if saved.PreLaunchCommand != "./sync.sh" || saved.WrapperCommand != "" || saved.PostExitCommand != "./backup.sh" {
+		t.Fatalf("saved hooks = %q / %q / %q", saved.PreLaunchCommand, saved.WrapperCommand, saved.PostExitCommand)
+	}
+}
+
+func TestInstanceSettings_EnvOverrides(t *testing.T) {
+	cfg := &config.Config{Env: map[string]string{"__GL_THREADED_OPTIMIZATIONS": "1"}}
+	m := NewInstanceSettingsModel(&core.Instance{Name: "Shaders"}, cfg)
+	if got := m.env.Placeholder; got != "Global: __GL_THREADED_OPTIMIZATIONS=1" {
+		t.Fatalf("env placeholder = %q", got)
+	}
+
+	m.env.SetValue("MESA_GL_VERSION_OVERRIDE=4.5 -__GL_THREADED_OPTIMIZATIONS")
+	_, cmd := m.Update(keyEnter())
+	saved := cmd().(InstanceSettingsSaved)
+	if saved.Env["MESA_GL_VERSION_OVERRIDE"] != "4.5" || len(saved.UnsetEnv) != 1 || saved.UnsetEnv[0] != "__GL_THREADED_OPTIMIZATIONS" {
+		t.Fatalf("saved env = %v unset = %v", saved.Env, saved.UnsetEnv)
+	}
+
+	m.env.SetValue("BROKEN")
+	_, cmd = m.Update(keyEnter())
+	if cmd == nil || m.focus != focusInstEnv || m.saveErr == "" {
+		t.Fatalf("invalid env should block save and focus the field, saveErr=%q", m.saveErr)
+	}
+}
+
+func TestInstanceSettings_DetachOverride(t *testing.T) {
+	m := NewInstanceSettingsModel(&core.Instance{Name: "Server"}, &config.Config{DetachGames: true})
+	if got := m.detachLabel(); got != "Default (on)" {
+		t.Fatalf("detach label = %q, want Default (on)", got)
+	}
+	m.applyFocus(focusInstDetach)
+	m.Update(tea.KeyMsg{Type: tea.KeyLeft}) // Default -> Off
+
+	_, cmd := m.Update(keyEnter())
+	saved := cmd().(InstanceSettingsSaved)
+	if saved.Detach == nil || *saved.Detach {
+		t.Fatalf("Detach = %v, want explicit off", saved.Detach)
+	}
+	if saved.Fullscreen != nil {
+		t.Fatalf("Fullscreen = %v, want inherited", saved.Fullscreen)
+	}
+}
+
+func TestInstanceSettings_MemorySliders(t *testing.T) {
+	inst := &core.Instance{Name: "Modded"}
+	m := NewInstanceSettingsModel(inst, &config.Config{})
+	m.SetMemoryInfo(memory.System{TotalMB: 16384, FreeMB: 5120}, 40)
+	m.SetSize(80, 60)
+	if view := m.View(); !strings.Contains(view, "Default (4 GB, recommended)") || !strings.Contains(view, "Recommended 4 GB for 40 mods") {
+		t.Fatalf("view should show the recommendation:\n%s", view)
+	}
+
+	// Focus starts on maximum memory; right steps off "inherit" onto sizes.
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if m.maxMemory.mb != memory.StepMB {
+		t.Fatalf("maxMemory = %d, want the smallest size", m.maxMemory.mb)
+	}
+	m.maxMemory.mb = 4096
+	for range 2 {
+		m.Update(tea.KeyMsg{Type: tea.KeyShiftRight})
+	}
+	if m.maxMemory.mb != 8192 {
+		t.Fatalf("maxMemory = %d, want 8192 after two big steps", m.maxMemory.mb)
+	}
+	if !strings.Contains(m.View(), "more than the 5 GB free") {
+		t.Error("a heap above free memory should warn")
+	}
+
+	m.applyFocus(focusInstMinMemory)
+	m.minMemory.mb = 1024
+	_, cmd := m.Update(keyEnter())
+	saved := cmd().(InstanceSettingsSaved)
+	if saved.MaxMemoryMB != 8192 || saved.MinMemoryMB != 1024 {
+		t.Fatalf("saved memory = %d-%d MB, want 1024-8192", saved.MinMemoryMB, saved.MaxMemoryMB)
+	}
+
+	// A minimum above the inherited maximum blocks the save.
+	m.maxMemory.mb = 0
+	m.minMemory.mb = 6144
+	if _, cmd := m.Update(keyEnter()); cmd != nil || !strings.Contains(m.saveErr, "minimum is above the maximum (4 GB)") {
+		t.Fatalf("saveErr = %q, want min above max", m.saveErr)
+	}
+}
+
+func TestMemorySlider_Step(t *testing.T) {
+	s := newMemorySlider(1024, 8192)
+	s.step(-memory.StepMB)
+	s.step(-memory.StepMB)
+	if s.mb != 0 {
+		t.Fatalf("stepping below the smallest size should inherit, got %d", s.mb)
+	}
+	s.mb = 7168
+	s.step(memoryBigStepMB)
+	if s.mb != 8192 {
+		t.Fatalf("step past the end = %d, want the machine's 8 GB", s.mb)
+	}
+	if s := newMemorySlider(12288, 8192); s.topMB != 12288 {
+		t.Fatalf("track should stretch to a larger setting, top = %d", s.topMB)
+	}
+}
+
+func TestInstanceSettings_JVMPresetAndArgs(t *testing.T) {
+	inst := &core.Instance{Name: "Modded", JVMPreset: "zgc", JVMArgs: []string{"-Dfoo=1"}}
+	m := NewInstanceSettingsModel(inst, &config.Config{JVMArgs: []string{"-XX:+UseG1GC"}})
+	m.SetSize(80, 60)
+	if got := m.preset().Name; got != "Generational ZGC" {
+		t.Fatalf("preset seed = %q", got)
+	}
+	if m.jvmArgs.Value() != "-Dfoo=1" || m.jvmArgs.Placeholder != "Global: -XX:+UseG1GC" {
+		t.Fatalf("jvmArgs seed = %q, placeholder %q", m.jvmArgs.Value(), m.jvmArgs.Placeholder)
+	}
+	if !strings.Contains(m.View(), "Java 21+") {
+		t.Error("view should say which Java the preset needs")
+	}
+
+	m.applyFocus(focusInstJVMPreset)
+	m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if got := m.preset().ID; got != "aikar" {
+		t.Fatalf("left = %q, want the previous preset", got)
+	}
+	m.jvmArgs.SetValue("  -Dfoo=2  -Dbar ")
+	_, cmd := m.Update(keyEnter())
+	saved := cmd().(InstanceSettingsSaved)
+	if saved.JVMPreset != "aikar" || strings.Join(saved.JVMArgs, " ") != "-Dfoo=2 -Dbar" {
+		t.Fatalf("saved = %q %q", saved.JVMPreset, saved.JVMArgs)
+	}
+
+	m.presetIdx = 0
+	_, cmd = m.Update(keyEnter())
+	if saved := cmd().(InstanceSettingsSaved); saved.JVMPreset != "" {
+		t.Fatalf("no preset saved as %q", saved.JVMPreset)
+	}
+}
@@ -0,0 +1,444 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/java"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// JavaModel lists the managed and detected Java runtimes, which instances use
+// each, and lets the user install, delete, and pick them.
+type JavaModel struct {
+	width  int
+	height int
+
+	instance    *core.Instance // picking for this instance; nil picks only the default
+	instances   []*core.Instance
+	defaultPath string
+
+	runtimes []java.Runtime
+	usedBy   [][]string // instance names per runtime, parallel to runtimes
+	loading  bool
+	cursor   int
+	offset   int // first runtime shown
+
+	prompting  bool // entering a major version to install
+	version    textinput.Model
+	installing bool
+	status     string
+
+	confirmDelete  bool
+	deleteFocusYes bool
+}
+
+// NewJavaModel creates the Java screen. inst, when set, is the instance Enter
+// picks a runtime for; defaultPath is the global default (config.JavaPath).
+func NewJavaModel(inst *core.Instance, instances []*core.Instance, defaultPath string) *JavaModel {
+	ti := textinput.New()
+	ti.Placeholder = "e.g. 21"
+	ti.CharLimit = 3
+	ti.Width = 8
+	ThemeTextInput(&ti)
+	return &JavaModel{
+		instance:    inst,
+		instances:   instances,
+		defaultPath: defaultPath,
+		loading:     true,
+		version:     ti,
+	}
+}
+
+// SetSize updates dimensions
+func (m *JavaModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// SetUsage refreshes which instances use which runtime after a pick, a
+// deletion, or a change of default.
+func (m *JavaModel) SetUsage(instances []*core.Instance, defaultPath string) {
+	m.instances = instances
+	m.defaultPath = defaultPath
+	m.computeUsage()
+}
+
+// SetStatus shows a one-line notice under the list.
+func (m *JavaModel) SetStatus(msg string) {
+	m.status = msg
+}
+
+// SetInstalling marks an install of Java major as running, e.g. one started
+// before the screen was reopened.
+func (m *JavaModel) SetInstalling(major int) {
+	m.installing = true
+	m.status = fmt.Sprintf("Installing Java %d...", major)
+}
+
+// Init implements tea.Model
+func (m *JavaModel) Init() tea.Cmd {
+	return nil
+}
+
+// computeUsage matches instances to runtimes: an instance uses the runtime it
+// is pinned to, or the default when it has none of its own.
+func (m *JavaModel) computeUsage() {
+	m.usedBy = make([][]string, len(m.runtimes))
+	for _, inst := range m.instances {
+		path := inst.JavaPath
+		if path == "" {
+			path = m.defaultPath
+		}
+		if path == "" {
+			continue
+		}
+		for i, r := range m.runtimes {
+			if sameJava(r.Path, path) {
+				m.usedBy[i] = append(m.usedBy[i], inst.Name)
+				break
+			}
+		}
+	}
+}
+
+// sameJava reports whether two paths name the same java executable.
+func sameJava(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	if filepath.Clean(a) == filepath.Clean(b) {
+		return true
+	}
+	ra, errA := filepath.EvalSymlinks(a)
+	rb, errB := filepath.EvalSymlinks(b)
+	return errA == nil && errB == nil && ra == rb
+}
+
+func (m *JavaModel) selected() (java.Runtime, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.runtimes) {
+		return java.Runtime{}, false
+	}
+	return m.runtimes[m.cursor], true
+}
+
+// Update implements tea.Model
+func (m *JavaModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case JavaRuntimesLoaded:
+		m.loading = false
+		m.runtimes = msg.Runtimes
+		m.cursor = min(m.cursor, max(0, len(m.runtimes)-1))
+		m.computeUsage()
+		return m, nil
+
+	case JavaInstallStatus:
+		m.status = msg.Message
+		return m, nil
+
+	case JavaInstallDone:
+		m.installing = false
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Couldn't install Java %d: %v", msg.Major, msg.Err)
+		} else {
+			m.status = fmt.Sprintf("Installed Java %d.", msg.Major)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.prompting {
+			return m.updatePrompt(msg)
+		}
+		if m.confirmDelete {
+			return m.updateConfirmDelete(msg)
+		}
+		return m.updateList(msg)
+	}
+
+	if m.prompting {
+		var cmd tea.Cmd
+		m.version, cmd = m.version.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m *JavaModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		return m, func() tea.Msg { return NavigateToHome{} }
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.runtimes)-1 {
+			m.cursor++
+		}
+	case "r":
+		inst := m.instance
+		return m, func() tea.Msg { return NavigateToJava{Instance: inst} }
+	case "i":
+		if m.installing {
+			m.status = "An install is already running."
+			return m, nil
+		}
+		m.prompting = true
+		m.version.SetValue("")
+		return m, m.version.Focus()
+	case "enter":
+		r, ok := m.selected()
+		if !ok || m.instance == nil {
+			return m, nil
+		}
+		if r.Path == "" {
+			m.status = "This runtime has no java executable; reinstall or delete it."
+			return m, nil
+		}
+		inst, path := m.instance, r.Path
+		if sameJava(inst.JavaPath, path) {
+			path = ""
+		}
+		return m, func() tea.Msg { return SetInstanceJava{Instance: inst, Path: path} }
+	case "g":
+		r, ok := m.selected()
+		if !ok {
+			return m, nil
+		}
+		if r.Path == "" {
+			m.status = "This runtime has no java executable; reinstall or delete it."
+			return m, nil
+		}
+		path := r.Path
+		if sameJava(m.defaultPath, path) {
+			path = ""
+		}
+		return m, func() tea.Msg { return SetDefaultJava{Path: path} }
+	case "d", "x":
+		r, ok := m.selected()
+		if !ok {
+			return m, nil
+		}
+		if !r.Managed {
+			m.status = "Only runtimes mctui installed can be deleted here."
+			return m, nil
+		}
+		m.confirmDelete = true
+		m.deleteFocusYes = false
+	}
+	m.scrollToCursor()
+	return m, nil
+}
+
+func (m *JavaModel) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.prompting = false
+		m.version.Blur()
+		return m, nil
+	case "enter":
+		major, err := strconv.Atoi(strings.TrimSpace(m.version.Value()))
+		if err != nil || major < 8 {
+			m.status = "Enter a Java major version, e.g. 8, 17 or 21."
+			return m, nil
+		}
+		m.prompting = false
+		m.version.Blur()
+		m.installing = true
+		m.status = fmt.Sprintf("Installing Java %d...", major)
+		return m, func() tea.Msg { return InstallJava{Major: major} }
+	}
+	var cmd tea.Cmd
+	m.version, cmd = m.version.Update(msg)
+	return m, cmd
+}
+
+func (m *JavaModel) updateConfirmDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if ConfirmKeyToggles(msg.String()) {
+		m.deleteFocusYes = !m.deleteFocusYes
+		return m, nil
+	}
+	confirm := false
+	switch msg.String() {
+	case "y", "Y":
+		confirm = true
+	case "enter":
+		confirm = m.deleteFocusYes
+	case "n", "N", "esc", "q":
+	default:
+		return m, nil
+	}
+	m.confirmDelete = false
+	r, ok := m.selected()
+	if !confirm || !ok {
+		return m, nil
+	}
+	return m, func() tea.Msg { return DeleteJava{Runtime: r} }
+}
+
+// rowsPerRuntime is how many lines each runtime takes in the list.
+const rowsPerRuntime = 3
+
+// visibleRuntimes is how many runtimes fit on screen.
+func (m *JavaModel) visibleRuntimes() int {
+	// Header, panel borders, status, prompt and key hints take about 12 lines.
+	return max(1, (m.height-12)/rowsPerRuntime)
+}
+
+func (m *JavaModel) scrollToCursor() {
+	n := m.visibleRuntimes()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+n {
+		m.offset = m.cursor - n + 1
+	}
+}
+
+// View implements tea.Model
+func (m *JavaModel) View() string {
+	panelW := min(max(m.width, 54), 80)
+
+	if m.confirmDelete {
+		if r, ok := m.selected(); ok {
+			warning := "Instances using it will pick Java automatically again."
+			if users := m.usedBy[m.cursor]; len(users) > 0 {
+				warning = fmt.Sprintf("Used by %s; they will pick Java automatically again.", strings.Join(users, ", "))
+			}
+			return ConfirmDialog{
+				Title:    "Delete runtime?",
+				Message:  fmt.Sprintf("Delete %s from %s?", r.Label(), r.Dir),
+				Warning:  warning,
+				Confirm:  "Delete",
+				Cancel:   "Cancel",
+				Kind:     ConfirmDanger,
+				FocusYes: m.deleteFocusYes,
+			}.Render(m.width, m.height)
+		}
+	}
+
+	subtitle := "Default: " + m.describePath(m.defaultPath)
+	if m.instance != nil {
+		subtitle = fmt.Sprintf("%s: %s  %s  %s", m.instance.Name, m.describePath(m.instance.JavaPath), GlyphDot, subtitle)
+	}
+	parts := []string{ScreenHeader("Java runtimes", ansi.Truncate(subtitle, panelW, titleEllipsis)), ""}
+
+	switch {
+	case m.loading:
+		parts = append(parts, lipgloss.NewStyle().Foreground(Active.TextSubtle).Render("Looking for Java installations…"))
+	case len(m.runtimes) == 0:
+		parts = append(parts, Panel("Installed", lipgloss.NewStyle().Foreground(Active.TextSubtle).
+			Render("No Java found. Press [i] to install one."), panelW, Active.Border))
+	default:
+		parts = append(parts, Panel("Installed", m.runtimeRows(panelW-4), panelW, Active.Primary))
+	}
+
+	if m.prompting {
+		label := lipgloss.NewStyle().Foreground(Active.Text).Render("Install Java version: ")
+		parts = append(parts, "", label+m.version.View())
+	}
+	if m.status != "" {
+		parts = append(parts, "", lipgloss.NewStyle().Foreground(Active.TextSubtle).Render(ansi.Truncate(m.status, panelW, titleEllipsis)))
+	}
+
+	var hints []KeyHint
+	if m.prompting {
+		hints = []KeyHint{{"↵", "install"}, {"esc", "cancel"}}
+	} else {
+		if m.instance != nil {
+			label := "use for " + m.instance.Name
+			if r, ok := m.selected(); ok && sameJava(m.instance.JavaPath, r.Path) {
+				label = "automatic for " + m.instance.Name
+			}
+			hints = append(hints, KeyHint{"↵", label})
+		}
+		defaultLabel := "set default"
+		if r, ok := m.selected(); ok && sameJava(m.defaultPath, r.Path) {
+			defaultLabel = "clear default"
+		}
+		hints = append(hints,
+			KeyHint{"g", defaultLabel},
+			KeyHint{"i", "install"},
+			KeyHint{"d", "delete"},
+			KeyHint{"r", "rescan"},
+			KeyHint{"esc", "back"},
+		)
+	}
+	parts = append(parts, "", KeyHints(panelW, hints...))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+// runtimeRows renders the visible runtimes: label, arch and tags, then the
+// path, then the instances using it.
+func (m *JavaModel) runtimeRows(width int) string {
+	dim := lipgloss.NewStyle().Foreground(Active.TextSubtle)
+	tag := lipgloss.NewStyle().Foreground(Active.Secondary)
+
+	end := min(len(m.runtimes), m.offset+m.visibleRuntimes())
+	var rows []string
+	for i := m.offset; i < end; i++ {
+		r := m.runtimes[i]
+		title := lipgloss.NewStyle().Foreground(Active.Text)
+		pointer := "  "
+		if i == m.cursor {
+			title = title.Bold(true).Foreground(Active.Primary)
+			pointer = lipgloss.NewStyle().Foreground(Active.Primary).Render(GlyphPointer + " ")
+		}
+
+		arch := "32-bit"
+		if r.Is64Bit {
+			arch = "64-bit"
+		}
+		var tags []string
+		if r.Managed {
+			tags = append(tags, "managed")
+		}
+		if sameJava(m.defaultPath, r.Path) {
+			tags = append(tags, "default")
+		}
+		if m.instance != nil && sameJava(m.instance.JavaPath, r.Path) {
+			tags = append(tags, m.instance.Name)
+		}
+		line := pointer + title.Render(r.Label()) + "  " + dim.Render(arch)
+		if len(tags) > 0 {
+			line += "  " + tag.Render("["+strings.Join(tags, "] [")+"]")
+		}
+		rows = append(rows, ansi.Truncate(line, width, titleEllipsis))
+
+		path := r.Path
+		if path == "" {
+			path = r.Dir + " (java missing)"
+		}
+		rows = append(rows, dim.Render(ansi.Truncate("  "+path, width, titleEllipsis)))
+
+		users := "  not used"
+		if len(m.usedBy[i]) > 0 {
+			users = "  used by " + strings.Join(m.usedBy[i], ", ")
+		}
+		rows = append(rows, dim.Render(ansi.Truncate(users, width, titleEllipsis)))
+	}
+	if hidden := len(m.runtimes) - (end - m.offset); hidden > 0 {
+		rows = append(rows, dim.Render(fmt.Sprintf("  %d more (↑/↓)", hidden)))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// describePath names the runtime at path for the header: its label when it's
+// listed, the path itself when it isn't, or "automatic".
+func (m *JavaModel) describePath(path string) string {
+	if path == "" {
+		return "automatic"
+	}
+	for _, r := range m.runtimes {
+		if sameJava(r.Path, path) {
+			return r.Label()
+		}
+	}
+	return path
+}
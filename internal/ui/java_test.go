@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/java"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func javaTestModel() (*JavaModel, *core.Instance) {
+	survival := &core.Instance{ID: "s", Name: "Survival", JavaPath: "/rt/delta/bin/java"}
+	creative := &core.Instance{ID: "c", Name: "Creative"}
+	m := NewJavaModel(survival, []*core.Instance{survival, creative}, "/usr/lib/jvm/17/bin/java")
+	m.SetSize(80, 40)
+	m.Update(JavaRuntimesLoaded{Runtimes: []java.Runtime{
+		{Installation: java.Installation{Path: "/rt/delta/bin/java", Version: "21.0.7", MajorVersion: 21, Is64Bit: true, Vendor: "Microsoft"}, Managed: true, Name: "java-runtime-delta", Dir: "/rt/delta"},
+		{Installation: java.Installation{Path: "/usr/lib/jvm/17/bin/java", Version: "17.0.9", MajorVersion: 17, Is64Bit: true, Vendor: "OpenJDK"}},
+	}})
+	return m, survival
+}
+
+func TestJava_ShowsRuntimesAndUsage(t *testing.T) {
+	m, _ := javaTestModel()
+	view := m.View()
+	for _, want := range []string{
+		"Java 21.0.7 (Microsoft)", "[managed] [Survival]", "used by Survival",
+		"Java 17.0.9 (OpenJDK)", "[default]", "used by Creative",
+		"64-bit", "Survival: Java 21.0.7 (Microsoft)",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
+
+func TestJava_PickAndToggle(t *testing.T) {
+	m, survival := javaTestModel()
+
+	// Enter on the instance's own runtime unpins it.
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(SetInstanceJava); !ok || msg.Instance != survival || msg.Path != "" {
+		t.Fatalf("enter on pinned runtime = %#v, want unpin", cmd())
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(SetInstanceJava); !ok || msg.Path != "/usr/lib/jvm/17/bin/java" {
+		t.Fatalf("enter = %#v, want pin to the system Java", cmd())
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	if msg, ok := cmd().(SetDefaultJava); !ok || msg.Path != "" {
+		t.Fatalf("g on the default = %#v, want it cleared", cmd())
+	}
+}
+
+func TestJava_DeleteOnlyManaged(t *testing.T) {
+	m, _ := javaTestModel()
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if m.confirmDelete {
+		t.Fatal("a system Java shouldn't be deletable")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if !strings.Contains(m.View(), "Used by Survival") {
+		t.Error("confirmation should name the instances using the runtime")
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if msg, ok := cmd().(DeleteJava); !ok || msg.Runtime.Name != "java-runtime-delta" {
+		t.Fatalf("y = %#v, want DeleteJava", cmd())
+	}
+}
+
+func TestJava_InstallPrompt(t *testing.T) {
+	m, _ := javaTestModel()
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("21")})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(InstallJava); !ok || msg.Major != 21 {
+		t.Fatalf("enter = %#v, want InstallJava{21}", cmd())
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	if m.prompting {
+		t.Error("a second install shouldn't start while one runs")
+	}
+}
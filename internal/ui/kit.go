@@ -160,3 +160,58 @@ func joinWrapped(items []string, sep string, width int) string {
 	}
 	return strings.Join(lines, "\n")
 }
+
+// FormWindow stacks form blocks (one per focusable control), showing only the
+// blocks around focused when they don't all fit in height rows. Scrolled-off
+// blocks are marked with a faint "more" line at that edge. height <= 0 shows all.
+func FormWindow(blocks []string, focused, height int) string {
+	heights := make([]int, len(blocks))
+	total := 0
+	for i, b := range blocks {
+		heights[i] = lipgloss.Height(b)
+		total += heights[i]
+	}
+	if height <= 0 || total <= height || len(blocks) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, blocks...)
+	}
+	focused = max(0, min(focused, len(blocks)-1))
+
+	// Grow the window from the focused block, preferring blocks below so the
+	// next Tab target stays visible, and reserving a row for each marker.
+	lo, hi := focused, focused+1
+	used := heights[focused]
+	fits := func(extra, newLo, newHi int) bool {
+		markers := 0
+		if newLo > 0 {
+			markers++
+		}
+		if newHi < len(blocks) {
+			markers++
+		}
+		return used+extra+markers <= height
+	}
+	for grew := true; grew; {
+		grew = false
+		if hi < len(blocks) && fits(heights[hi], lo, hi+1) {
+			used += heights[hi]
+			hi++
+			grew = true
+		}
+		if lo > 0 && fits(heights[lo-1], lo-1, hi) {
+			lo--
+			used += heights[lo]
+			grew = true
+		}
+	}
+
+	more := lipgloss.NewStyle().Foreground(Active.TextFaint)
+	var parts []string
+	if lo > 0 {
+		parts = append(parts, more.Render("  ↑ more"))
+	}
+	parts = append(parts, blocks[lo:hi]...)
+	if hi < len(blocks) {
+		parts = append(parts, more.Render("  ↓ more"))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
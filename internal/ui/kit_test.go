@@ -66,3 +66,22 @@ func TestKeyHintsWrap(t *testing.T) {
 		}
 	}
 }
+
+func TestFormWindowKeepsFocusVisible(t *testing.T) {
+	blocks := []string{"a1\na2\na3", "b1\nb2\nb3", "c1\nc2\nc3", "d1\nd2\nd3"}
+
+	if got := FormWindow(blocks, 0, 20); lipgloss.Height(got) != 12 {
+		t.Fatalf("everything fits; height = %d, want 12", lipgloss.Height(got))
+	}
+
+	got := FormWindow(blocks, 3, 8)
+	if !strings.Contains(got, "d1") || strings.Contains(got, "a1") {
+		t.Errorf("focused last block should be shown without the first:\n%s", got)
+	}
+	if !strings.Contains(got, "↑ more") || strings.Contains(got, "↓ more") {
+		t.Errorf("expected only an upper marker:\n%s", got)
+	}
+	if h := lipgloss.Height(got); h > 8 {
+		t.Errorf("height = %d, want <= 8", h)
+	}
+}
@@ -2,15 +2,20 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/aayushdutt/mctui/internal/config"
 	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/download"
 	"github.com/aayushdutt/mctui/internal/launch"
 	"github.com/charmbracelet/bubbles/progress"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/dustin/go-humanize"
 )
 
 // LaunchModel shows launch progress
@@ -19,12 +24,19 @@ type LaunchModel struct {
 	width    int
 	height   int
 
-	progress progress.Model
-	status   launch.Status
-	steps    []stepInfo
-	done     bool
-	err      error
-	logs     []string
+	progress  progress.Model
+	status    launch.Status
+	steps     []stepInfo
+	done      bool
+	err       error
+	logs      []string
+	crash     *launch.CrashReport       // set when the game crashed and left a report behind
+	diagnoses []launch.Diagnosis        // known failures recognized after an abnormal exit
+	logFile   string                    // this session's full log, once the game has started
+	hookErr   *launch.HookError         // set when the pre-launch command aborted the launch
+	dlErr     *download.BatchError      // set when files failed to download
+	javaErr   *launch.JavaMismatchError // set when the instance's Java can't run it
+	jvmErr    *launch.JVMArgsError      // set when the JVM options conflict
 
 	cfg *config.Config // optional; used for log verbosity while playing
 }
@@ -64,6 +76,21 @@ func (m *LaunchModel) GetInstance() *core.Instance {
 	return m.instance
 }
 
+// playing reports whether the game process is running.
+func (m *LaunchModel) playing() bool {
+	return !m.done && m.status.Step == "Playing"
+}
+
+// Done reports whether the launch has finished, successfully or not.
+func (m *LaunchModel) Done() bool {
+	return m.done
+}
+
+// Closed reports whether the game ran and exited cleanly, so there is nothing left to show.
+func (m *LaunchModel) Closed() bool {
+	return m.done && m.err == nil
+}
+
 // Init implements tea.Model
 func (m *LaunchModel) Init() tea.Cmd {
 	return nil
@@ -75,6 +102,9 @@ func (m *LaunchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case LaunchStatusUpdate:
 		m.status = msg.Status
 		m.updateSteps()
+		if msg.Status.LogFile != "" {
+			m.logFile = msg.Status.LogFile
+		}
 
 		if msg.Status.LogLine != nil {
 			line := fmt.Sprintf("[%s] %s", msg.Status.LogLine.Type, msg.Status.LogLine.Text)
@@ -91,6 +121,15 @@ func (m *LaunchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case LaunchComplete:
 		m.done = true
 		m.err = msg.Error
+		var exitErr *launch.GameExitError
+		if errors.As(msg.Error, &exitErr) {
+			m.crash = exitErr.Crash
+			m.diagnoses = exitErr.Diagnoses
+		}
+		errors.As(msg.Error, &m.hookErr)
+		errors.As(msg.Error, &m.dlErr)
+		errors.As(msg.Error, &m.javaErr)
+		errors.As(msg.Error, &m.jvmErr)
 		if msg.Error != nil {
 			m.updateStepStatus(m.status.Step, "error")
 		} else {
@@ -110,13 +149,16 @@ func (m *LaunchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "ctrl+c":
 			return m, tea.Quit
 		case "esc", "q":
-			if m.done {
+			if m.done || m.playing() {
+				// A running game keeps going in the background.
 				return m, func() tea.Msg { return NavigateToHome{} }
 			}
-			// Cancel launch if still in progress
+			m.status.Message = "Cancelling..."
+			id := m.instance.ID
+			return m, func() tea.Msg { return CancelLaunch{InstanceID: id} }
+		case "b":
 			if !m.done {
-				m.status.Message = "Cancelling..."
-				return m, func() tea.Msg { return CancelLaunch{} }
+				return m, func() tea.Msg { return NavigateToHome{} }
 			}
 		case "enter":
 			if m.done {
@@ -124,11 +166,46 @@ func (m *LaunchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "r":
 			if m.done && m.err != nil {
-				return m, func() tea.Msg { return RetryLaunch{Offline: false} }
+				id := m.instance.ID
+				return m, func() tea.Msg { return RetryLaunch{InstanceID: id, Offline: false} }
 			}
 		case "o":
 			if m.done && m.err != nil {
-				return m, func() tea.Msg { return RetryLaunch{Offline: true} }
+				id := m.instance.ID
+				return m, func() tea.Msg { return RetryLaunch{InstanceID: id, Offline: true} }
+			}
+		case "j":
+			if m.done && m.javaErr != nil {
+				id, path := m.instance.ID, ""
+				if alt := m.javaErr.Alternative; alt != nil {
+					path = alt.Path
+				}
+				return m, func() tea.Msg { return SwitchJava{InstanceID: id, Path: path} }
+			}
+		case "e":
+			if m.done && m.jvmErr != nil {
+				inst := m.instance
+				return m, func() tea.Msg { return NavigateToInstanceSettings{Instance: inst} }
+			}
+		case "c":
+			if m.done && m.crash != nil {
+				if err := openURL(m.crash.Path); err != nil {
+					m.status.Message = fmt.Sprintf("Couldn't open %s: %v", m.crash.Path, err)
+				}
+			}
+		case "x", "X":
+			if m.playing() {
+				force := msg.String() == "X"
+				m.status.Message = "Stopping..."
+				if force {
+					m.status.Message = "Killing..."
+				}
+				inst := m.instance
+				return m, func() tea.Msg { return StopInstance{Instance: inst, Force: force} }
+			}
+		case "L":
+			if m.logFile != "" {
+				return m, func() tea.Msg { return NavigateToLogs{Instance: m.instance, Path: m.logFile} }
 			}
 		case "v":
 			if m.cfg != nil && m.status.Step == "Playing" && !m.done {
@@ -227,35 +304,9 @@ func (m *LaunchModel) View() string {
 		Foreground(Active.TextSubtle).
 		Render(fmt.Sprintf("Minecraft %s  %s  %s", m.instance.Version, GlyphDot, m.instance.Loader))
 
-	// Progress panel: the bar, then the step rows. The currently-running step is
-	// emphasized bold; done steps are success, pending steps dim.
-	var steps strings.Builder
-	for i, step := range m.steps {
-		var icon string
-		var style lipgloss.Style
-		switch step.status {
-		case "done":
-			icon = GlyphDone
-			style = lipgloss.NewStyle().Foreground(Active.Success)
-		case "running":
-			icon = GlyphRunning
-			style = lipgloss.NewStyle().Bold(true).Foreground(Active.WarningStrong)
-		case "error":
-			icon = GlyphFail
-			style = lipgloss.NewStyle().Foreground(Active.Error)
-		default:
-			icon = GlyphPending
-			style = lipgloss.NewStyle().Foreground(Active.TextFaint)
-		}
-		if i > 0 {
-			steps.WriteString("\n")
-		}
-		steps.WriteString(style.Render(icon + " " + step.name))
-	}
-
 	// The progress bar renders at its own fixed width, so keep it above the
 	// panel rather than inside (where the bar + percentage would wrap).
-	stepsPanel := Panel("Progress", steps.String(), panelW, Active.Primary)
+	progressPanel := stepsPanel(m.steps, panelW)
 
 	// Status message under the progress panel.
 	statusMsg := lipgloss.NewStyle().Foreground(Active.TextSubtle).Render(m.status.Message)
@@ -268,12 +319,28 @@ func (m *LaunchModel) View() string {
 				Bold(true).
 				Foreground(Active.Error).
 				Render(fmt.Sprintf("%s Failed: %v", GlyphFail, m.err))
-			hints := KeyHints(panelW,
-				KeyHint{"r", "retry"},
-				KeyHint{"o", "offline mode"},
-				KeyHint{"enter", "home"},
-			)
-			footer = lipgloss.JoinVertical(lipgloss.Left, fail, "", hints)
+			retry := "retry"
+			if m.dlErr != nil {
+				retry = "retry failed downloads"
+			}
+			hintItems := []KeyHint{
+				{"r", retry},
+				{"o", "offline mode"},
+				{"enter", "home"},
+			}
+			if m.logFile != "" {
+				hintItems = append([]KeyHint{{"L", "full log"}}, hintItems...)
+			}
+			if m.crash != nil {
+				hintItems = append([]KeyHint{{"c", "open crash report"}}, hintItems...)
+			}
+			if m.javaErr != nil {
+				hintItems = append([]KeyHint{{"j", javaSwitchHint(m.javaErr)}}, hintItems...)
+			}
+			if m.jvmErr != nil {
+				hintItems = append([]KeyHint{{"e", "instance settings"}}, hintItems...)
+			}
+			footer = lipgloss.JoinVertical(lipgloss.Left, fail, "", KeyHints(panelW, hintItems...))
 		} else {
 			footer = lipgloss.NewStyle().
 				Bold(true).
@@ -283,16 +350,27 @@ func (m *LaunchModel) View() string {
 	} else if m.status.Step == "Playing" {
 		if m.cfg != nil {
 			v := launch.ParseLaunchLogVerbosity(m.cfg.LaunchLogVerbosity)
+			hintItems := []KeyHint{
+				{"esc", "home (keeps running)"},
+				{"x", "stop"},
+				{"X", "kill"},
+				{"v", "logs: " + v.ShortLabel()},
+			}
+			if m.logFile != "" {
+				hintItems = append(hintItems, KeyHint{"L", "full log"})
+			}
+			footer = KeyHints(panelW, hintItems...)
+		} else {
 			footer = KeyHints(panelW,
-				KeyHint{"ctrl+c", "force quit"},
-				KeyHint{"v", "logs: " + v.ShortLabel()},
+				KeyHint{"esc", "home (keeps running)"},
+				KeyHint{"x", "stop"},
+				KeyHint{"X", "kill"},
 			)
-		} else {
-			footer = KeyHints(panelW, KeyHint{"ctrl+c", "force quit"})
 		}
 	} else {
 		footer = KeyHints(panelW,
 			KeyHint{"esc", "cancel"},
+			KeyHint{"b", "background"},
 			KeyHint{"ctrl+c", "quit"},
 		)
 	}
@@ -304,11 +382,14 @@ func (m *LaunchModel) View() string {
 		"",
 		m.progress.View(),
 		"",
-		stepsPanel,
+		progressPanel,
 	}
 	if m.status.Message != "" {
 		parts = append(parts, "", statusMsg)
 	}
+	if d := m.status.Downloads; d != nil && !m.done && (len(d.Active) > 0 || len(d.Failed) > 0) {
+		parts = append(parts, "", transfersPanel(d, panelW))
+	}
 
 	// Logs panel (only when there are logs).
 	if len(m.logs) > 0 {
@@ -321,7 +402,249 @@ func (m *LaunchModel) View() string {
 		parts = append(parts, "", logsPanel)
 	}
 
+	if m.hookErr != nil {
+		parts = append(parts, "", hookPanel(m.hookErr, panelW))
+	}
+	if m.dlErr != nil {
+		parts = append(parts, "", failedDownloadsPanel(m.dlErr, panelW))
+	}
+	if m.javaErr != nil {
+		parts = append(parts, "", javaMismatchPanel(m.javaErr, panelW))
+	}
+	if m.jvmErr != nil {
+		parts = append(parts, "", jvmConflictsPanel(m.jvmErr, panelW))
+	}
+	if len(m.diagnoses) > 0 {
+		parts = append(parts, "", diagnosisPanel(m.diagnoses, panelW))
+	}
+	if m.crash != nil {
+		parts = append(parts, "", crashPanel(m.crash, panelW))
+	}
+
 	parts = append(parts, "", footer)
 
 	return lipgloss.JoinVertical(lipgloss.Left, parts...)
 }
+
+// stepsPanel renders the step rows of a progress screen. The currently-running
+// step is emphasized bold; done steps are success, pending steps dim.
+func stepsPanel(steps []stepInfo, width int) string {
+	var b strings.Builder
+	for i, step := range steps {
+		var icon string
+		var style lipgloss.Style
+		switch step.status {
+		case "done":
+			icon = GlyphDone
+			style = lipgloss.NewStyle().Foreground(Active.Success)
+		case "running":
+			icon = GlyphRunning
+			style = lipgloss.NewStyle().Bold(true).Foreground(Active.WarningStrong)
+		case "error":
+			icon = GlyphFail
+			style = lipgloss.NewStyle().Foreground(Active.Error)
+		default:
+			icon = GlyphPending
+			style = lipgloss.NewStyle().Foreground(Active.TextFaint)
+		}
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(style.Render(icon + " " + step.name))
+	}
+	return Panel("Progress", b.String(), width, Active.Primary)
+}
+
+// hookOutputLines caps how much of a failed hook's output the launch screen shows.
+const hookOutputLines = 12
+
+// hookPanel shows the failed hook command and the tail of its output.
+func hookPanel(e *launch.HookError, width int) string {
+	label := lipgloss.NewStyle().Foreground(Active.TextDim)
+	value := lipgloss.NewStyle().Foreground(Active.Text)
+
+	rows := []string{label.Render("$ " + e.Command)}
+	output := strings.Split(e.Output, "\n")
+	if e.Output == "" {
+		output = []string{"(no output)"}
+	}
+	if len(output) > hookOutputLines {
+		rows = append(rows, label.Render(fmt.Sprintf("… %d earlier lines", len(output)-hookOutputLines)))
+		output = output[len(output)-hookOutputLines:]
+	}
+	for _, line := range output {
+		rows = append(rows, value.Render(line))
+	}
+	title := strings.ToUpper(e.Hook[:1]) + e.Hook[1:] + " command"
+	return Panel(title, strings.Join(rows, "\n"), width, Active.Error)
+}
+
+// javaMismatchPanel says why the instance's Java can't run it and what would.
+func javaMismatchPanel(e *launch.JavaMismatchError, width int) string {
+	label := lipgloss.NewStyle().Foreground(Active.TextDim)
+	value := lipgloss.NewStyle().Foreground(Active.Text)
+	contentW := width - 4
+
+	found := "unknown"
+	if e.Found != nil {
+		found = fmt.Sprintf("Java %s", e.Found.Version)
+		if e.Found.Is64Bit {
+			found += ", 64-bit"
+		} else {
+			found += ", 32-bit"
+		}
+	}
+	need := e.Requirement.String()
+	if e.Requirement.Need64Bit {
+		need += ", 64-bit"
+	}
+	rows := []string{
+		value.Render(ansi.Truncate(e.Problem, contentW, titleEllipsis)),
+		"",
+		label.Render("Set to   ") + value.Render(ansi.Truncate(e.Path, max(1, contentW-9), titleEllipsis)),
+		label.Render("Found    ") + value.Render(found),
+		label.Render("Needs    ") + value.Render(need),
+	}
+	if alt := e.Alternative; alt != nil {
+		rows = append(rows, label.Render("Fits     ")+value.Render(ansi.Truncate(alt.Label()+"  "+alt.Path, max(1, contentW-9), titleEllipsis)))
+	}
+	return Panel("Java mismatch", strings.Join(rows, "\n"), width, Active.Error)
+}
+
+// jvmConflictsPanel lists the conflicting JVM options, each with where it came from.
+func jvmConflictsPanel(e *launch.JVMArgsError, width int) string {
+	problem := lipgloss.NewStyle().Foreground(Active.Text)
+	flag := lipgloss.NewStyle().Foreground(Active.TextSubtle)
+	contentW := width - 4
+
+	var rows []string
+	for i, c := range e.Conflicts {
+		if i > 0 {
+			rows = append(rows, "")
+		}
+		rows = append(rows, problem.Render(ansi.Truncate(c.Problem, contentW, titleEllipsis)))
+		for _, f := range c.Flags {
+			rows = append(rows, flag.Render(ansi.Truncate("  "+f, contentW, titleEllipsis)))
+		}
+	}
+	title := fmt.Sprintf("%d JVM option conflict%s", len(e.Conflicts), plural(len(e.Conflicts)))
+	return Panel(title, strings.Join(rows, "\n"), width, Active.Error)
+}
+
+// javaSwitchHint names what [j] switches the instance to.
+func javaSwitchHint(e *launch.JavaMismatchError) string {
+	if e.Alternative != nil {
+		return "use " + e.Alternative.Label()
+	}
+	return fmt.Sprintf("download Java %d", e.Requirement.Min)
+}
+
+// transferRows caps how many in-flight downloads the launch screen lists.
+const transferRows = 6
+
+// transfersPanel lists the files being downloaded, each with its own progress
+// and speed, and how many have failed so far.
+func transfersPanel(p *download.Progress, width int) string {
+	name := lipgloss.NewStyle().Foreground(Active.Text)
+	detail := lipgloss.NewStyle().Foreground(Active.TextSubtle)
+	dim := lipgloss.NewStyle().Foreground(Active.TextFaint)
+	contentW := width - 4
+
+	var rows []string
+	for i, t := range p.Active {
+		if i == transferRows {
+			rows = append(rows, dim.Render(fmt.Sprintf("… %d more", len(p.Active)-transferRows)))
+			break
+		}
+		right := humanize.Bytes(uint64(t.Bytes))
+		if t.Size > 0 {
+			right += " / " + humanize.Bytes(uint64(t.Size))
+		}
+		right += "  " + download.FormatSpeed(t.Speed)
+		left := ansi.Truncate(t.Name, max(1, contentW-lipgloss.Width(right)-2), titleEllipsis)
+		gap := max(2, contentW-lipgloss.Width(left)-lipgloss.Width(right))
+		rows = append(rows, name.Render(left)+strings.Repeat(" ", gap)+detail.Render(right))
+	}
+	if p.QueuedItems > 0 {
+		rows = append(rows, dim.Render(fmt.Sprintf("%d queued", p.QueuedItems)))
+	}
+	if n := len(p.Failed); n > 0 {
+		last := p.Failed[n-1]
+		msg := fmt.Sprintf("%s %d failed, last: %s: %v", GlyphFail, n, last.Name, last.Err)
+		rows = append(rows, lipgloss.NewStyle().Foreground(Active.Error).Render(ansi.Truncate(msg, contentW, titleEllipsis)))
+	}
+	return Panel("Downloads", strings.Join(rows, "\n"), width, Active.BorderSubtle)
+}
+
+// failedDownloadsPanel lists the files that didn't download, each with why.
+// Files that were verified stay on disk, so a retry only fetches these.
+func failedDownloadsPanel(e *download.BatchError, width int) string {
+	name := lipgloss.NewStyle().Foreground(Active.Text)
+	kind := lipgloss.NewStyle().Foreground(Active.Error)
+	reason := lipgloss.NewStyle().Foreground(Active.TextSubtle)
+	contentW := width - 4
+
+	var rows []string
+	for i, o := range e.Failures {
+		if i == transferRows {
+			rows = append(rows, reason.Render(fmt.Sprintf("… %d more", len(e.Failures)-transferRows)))
+			break
+		}
+		label := o.Kind.String()
+		if o.Attempts > 1 {
+			label += fmt.Sprintf(", %d attempts", o.Attempts)
+		}
+		left := ansi.Truncate(filepath.Base(o.Item.Path), max(1, contentW-lipgloss.Width(label)-2), titleEllipsis)
+		gap := max(2, contentW-lipgloss.Width(left)-lipgloss.Width(label))
+		rows = append(rows,
+			name.Render(left)+strings.Repeat(" ", gap)+kind.Render(label),
+			reason.Render(ansi.Truncate("  "+o.Err.Error(), contentW, titleEllipsis)))
+	}
+	title := fmt.Sprintf("%d failed download%s", len(e.Failures), plural(len(e.Failures)))
+	return Panel(title, strings.Join(rows, "\n"), width, Active.Error)
+}
+
+// diagnosisPanel lists recognized failures, each with its suggested fix.
+func diagnosisPanel(diagnoses []launch.Diagnosis, width int) string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(Active.Text)
+	fix := lipgloss.NewStyle().Foreground(Active.TextSubtle)
+
+	var rows []string
+	for i, d := range diagnoses {
+		if i > 0 {
+			rows = append(rows, "")
+		}
+		rows = append(rows, title.Render(d.Title), fix.Render(GlyphPointer+" "+d.Suggestion))
+	}
+	return Panel("What went wrong", strings.Join(rows, "\n"), width, Active.WarningStrong)
+}
+
+// crashPanel summarizes a crash report: what failed, the exception, and any suspected mods.
+func crashPanel(r *launch.CrashReport, width int) string {
+	label := lipgloss.NewStyle().Foreground(Active.TextDim)
+	value := lipgloss.NewStyle().Foreground(Active.Text)
+
+	var rows []string
+	if r.Description != "" {
+		rows = append(rows, value.Bold(true).Render(r.Description))
+	}
+	if r.Exception != "" {
+		rows = append(rows, value.Render(r.Exception))
+	}
+	if len(r.SuspectedMods) > 0 {
+		rows = append(rows, "", label.Render("Suspected mods:"))
+		for _, mod := range r.SuspectedMods {
+			rows = append(rows, value.Render("  • "+mod))
+		}
+	}
+	if len(rows) > 0 {
+		rows = append(rows, "")
+	}
+	rows = append(rows, label.Render(filepath.Base(r.Path)))
+
+	title := "Crash report"
+	if r.Kind == launch.CrashKindJVM {
+		title = "JVM crash log"
+	}
+	return Panel(title, strings.Join(rows, "\n"), width, Active.Error)
+}
@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/download"
+	"github.com/aayushdutt/mctui/internal/java"
+	"github.com/aayushdutt/mctui/internal/launch"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLaunch_GameExitErrorShowsCrashAndDiagnoses(t *testing.T) {
+	m := NewLaunchModel(&core.Instance{Name: "Survival", Version: "1.21.4"}, nil)
+	m.SetSize(80, 40)
+
+	report := &launch.CrashReport{
+		Path:          "/tmp/crash-reports/crash-1.txt",
+		Kind:          launch.CrashKindGame,
+		Description:   "Rendering overlay",
+		Exception:     "java.lang.NullPointerException",
+		SuspectedMods: []string{"Sodium (sodium)"},
+	}
+	err := fmt.Errorf("Launching: %w", &launch.GameExitError{
+		Crash:     report,
+		Diagnoses: []launch.Diagnosis{{ID: "out-of-memory", Title: "The game ran out of memory", Suggestion: "Raise -Xmx"}},
+	})
+	m.Update(LaunchComplete{Error: err})
+
+	if m.crash != report || len(m.diagnoses) != 1 {
+		t.Fatal("crash report and diagnoses should be extracted from the wrapped error")
+	}
+	view := m.View()
+	for _, want := range []string{"Crash report", "Rendering overlay", "Sodium (sodium)", "crash-1.txt", "open crash report", "What went wrong", "ran out of memory", "Raise -Xmx"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
+
+func TestLaunch_PreLaunchHookFailureShowsOutput(t *testing.T) {
+	m := NewLaunchModel(&core.Instance{Name: "Survival", Version: "1.21.4"}, nil)
+	m.SetSize(80, 40)
+
+	err := fmt.Errorf("Launching: %w", &launch.HookError{
+		Hook:    launch.HookPreLaunch,
+		Command: "./sync-configs.sh",
+		Output:  "fatal: could not read from remote repository",
+		Err:     fmt.Errorf("exit status 128"),
+	})
+	m.Update(LaunchComplete{Error: err})
+
+	view := m.View()
+	for _, want := range []string{"Pre-launch command", "./sync-configs.sh", "could not read from remote"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
+
+func TestLaunch_ListsActiveTransfers(t *testing.T) {
+	m := NewLaunchModel(&core.Instance{Name: "Survival", Version: "1.21.4"}, nil)
+	m.SetSize(80, 40)
+
+	m.Update(LaunchStatusUpdate{Status: launch.Status{
+		Step:     "Downloading libraries",
+		Progress: 0.4,
+		Message:  "3/40 files, 2.0 MB/s, 5s left",
+		Downloads: &download.Progress{
+			TotalItems:     40,
+			CompletedItems: 3,
+			QueuedItems:    34,
+			Active: []download.ItemProgress{
+				{Name: "minecraft-1.21.4-client.jar", State: download.ItemActive, Bytes: 4_000_000, Size: 26_000_000, Speed: 1_500_000},
+				{Name: "lwjgl-3.3.3.jar", State: download.ItemActive, Bytes: 200_000, Size: 800_000, Speed: 500_000},
+			},
+			Failed: []download.ItemProgress{
+				{Name: "jtracy.jar", State: download.ItemFailed, Err: fmt.Errorf("unexpected status: 404")},
+			},
+		},
+	}})
+
+	view := m.View()
+	for _, want := range []string{"Downloads", "minecraft-1.21.4-client.jar", "4.0 MB / 26 MB", "1.5 MB/s", "lwjgl-3.3.3.jar", "34 queued", "1 failed", "jtracy.jar"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	m.Update(LaunchStatusUpdate{Status: launch.Status{Step: "Preparing game"}})
+	if strings.Contains(m.View(), "lwjgl-3.3.3.jar") {
+		t.Error("transfers should disappear once the download step ends")
+	}
+}
+
+func TestLaunch_FailedDownloadsListed(t *testing.T) {
+	m := NewLaunchModel(&core.Instance{Name: "Survival", Version: "1.21.4"}, nil)
+	m.SetSize(80, 40)
+
+	err := fmt.Errorf("Downloading libraries: %w", &download.BatchError{Failures: []download.Outcome{
+		{Item: download.Item{Path: "/libs/jtracy-1.0.jar"}, Kind: download.FailHTTPStatus, Attempts: 1, Err: &download.StatusError{URL: "https://libraries.minecraft.net/jtracy-1.0.jar", Code: 404}},
+		{Item: download.Item{Path: "/libs/lwjgl-3.3.3.jar"}, Kind: download.FailNetwork, Attempts: 4, Err: fmt.Errorf("connection reset by peer")},
+	}})
+	m.Update(LaunchComplete{Error: err})
+
+	view := m.View()
+	for _, want := range []string{"2 failed downloads", "jtracy-1.0.jar", "HTTP status", "status 404", "lwjgl-3.3.3.jar", "network, 4 attempts", "retry failed downloads"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
+
+func TestLaunch_JavaMismatchOffersSwitch(t *testing.T) {
+	m := NewLaunchModel(&core.Instance{ID: "a", Name: "Survival", Version: "1.21.4"}, nil)
+	m.SetSize(80, 40)
+
+	mismatch := &launch.JavaMismatchError{
+		Path:        "/usr/lib/jvm/java-8/bin/java",
+		Found:       &java.Installation{Version: "1.8.0_402", MajorVersion: 8, Is64Bit: true},
+		Requirement: java.Requirement{Min: 21, MinReason: "Minecraft 1.21.4"},
+		Problem:     "Java 8 is too old: Minecraft 1.21.4 needs Java 21 or newer",
+		Alternative: &java.Runtime{Installation: java.Installation{Path: "/data/java/delta/bin/java", Version: "21.0.7", MajorVersion: 21, Vendor: "Microsoft"}, Managed: true},
+	}
+	m.Update(LaunchComplete{Error: fmt.Errorf("Checking Java: %w", mismatch)})
+
+	view := m.View()
+	for _, want := range []string{"Java mismatch", "too old", "Java 21 or newer", "use Java 21.0.7 (Microsoft)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if msg, ok := cmd().(SwitchJava); !ok || msg.InstanceID != "a" || msg.Path != "/data/java/delta/bin/java" {
+		t.Fatalf("j = %#v, want a switch to the managed Java", cmd())
+	}
+
+	// With nothing installed that fits, j clears the pin so one is downloaded.
+	mismatch.Alternative = nil
+	m.Update(LaunchComplete{Error: mismatch})
+	if !strings.Contains(m.View(), "download Java 21") {
+		t.Error("hint should offer the download")
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if msg, ok := cmd().(SwitchJava); !ok || msg.Path != "" {
+		t.Fatalf("j = %#v, want the pin cleared", cmd())
+	}
+}
+
+func TestLaunch_JVMConflictsListed(t *testing.T) {
+	inst := &core.Instance{ID: "a", Name: "Survival", Version: "1.21.4"}
+	m := NewLaunchModel(inst, nil)
+	m.SetSize(100, 50)
+
+	m.Update(LaunchComplete{Error: fmt.Errorf("Preparing game: %w", &launch.JVMArgsError{Conflicts: []launch.JVMConflict{{
+		Problem: "more than one garbage collector is selected",
+		Flags:   []string{"-XX:+UseZGC (Generational ZGC preset)", "-XX:+UseG1GC (JVM arguments)"},
+	}}})})
+
+	view := m.View()
+	for _, want := range []string{"1 JVM option conflict", "more than one garbage collector", "-XX:+UseG1GC (JVM arguments)", "instance settings"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if msg, ok := cmd().(NavigateToInstanceSettings); !ok || msg.Instance != inst {
+		t.Fatalf("e = %#v, want the instance settings", cmd())
+	}
+}
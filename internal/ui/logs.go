@@ -0,0 +1,518 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/launch"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// logsTailInterval is how often the newest session log is re-read while open.
+const logsTailInterval = time.Second
+
+type logsListedMsg struct {
+	sessions []launch.SessionLog
+	records  []core.Session
+	err      error
+}
+
+type logsReadMsg struct {
+	path   string
+	lines  []string
+	offset int64
+	reset  bool // lines replace the buffer rather than append to it
+	err    error
+}
+
+type logsTickMsg struct{ path string }
+
+// LogsModel browses an instance's persisted session logs: scrollback, incremental
+// search, and a severity filter that re-applies to every captured line. The
+// newest session is tailed so a running game's output keeps streaming in.
+type LogsModel struct {
+	width  int
+	height int
+
+	instance *core.Instance
+	initial  string // session to open first; empty opens the newest
+
+	sessions   []launch.SessionLog // newest first
+	sessionIdx int
+	records    []core.Session // play history, for how each session ended
+
+	lines     []string // every line of the open session
+	offset    int64    // bytes of the session log read so far
+	filter    launch.LogVerbosity
+	visible   []int // indices into lines that pass filter
+	top       int   // first visible row shown
+	follow    bool  // keep the view pinned to the newest line
+	searching bool
+	search    textinput.Model
+	query     string
+	matches   []int // rows of visible whose line contains query
+	matchIdx  int
+
+	notice string
+}
+
+// NewLogsModel opens inst's session logs at path (a session log file), or at the
+// newest session when path is empty.
+func NewLogsModel(inst *core.Instance, path string) *LogsModel {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "search"
+	ti.CharLimit = 128
+	ThemeTextInput(&ti)
+	return &LogsModel{
+		instance: inst,
+		initial:  path,
+		filter:   launch.LogVerbosityAll,
+		follow:   true,
+		search:   ti,
+	}
+}
+
+// SetSize updates dimensions.
+func (m *LogsModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.search.Width = max(10, width-4)
+	m.clampTop()
+}
+
+// Init implements tea.Model.
+func (m *LogsModel) Init() tea.Cmd {
+	inst := m.instance
+	return func() tea.Msg {
+		sessions, err := launch.ListSessionLogs(inst.Path)
+		records, _ := core.LoadSessions(inst.Path) // exit statuses are optional detail
+		return logsListedMsg{sessions: sessions, records: records, err: err}
+	}
+}
+
+func (m *LogsModel) currentPath() string {
+	if m.sessionIdx < 0 || m.sessionIdx >= len(m.sessions) {
+		return ""
+	}
+	return m.sessions[m.sessionIdx].Path
+}
+
+// exitStatus describes how the open session ended, or "" while it's running or
+// when it has no play history entry. Both are keyed by the game's start time,
+// which older log names only kept to the second.
+func (m *LogsModel) exitStatus() string {
+	if m.sessionIdx < 0 || m.sessionIdx >= len(m.sessions) {
+		return ""
+	}
+	started := m.sessions[m.sessionIdx].Started
+	for _, r := range m.records {
+		if r.Start.Truncate(time.Millisecond).Equal(started) || r.Start.Truncate(time.Second).Equal(started) {
+			return r.ExitStatus()
+		}
+	}
+	return ""
+}
+
+// tailing reports whether the open session is the newest, which may still be growing.
+func (m *LogsModel) tailing() bool {
+	return len(m.sessions) > 0 && m.sessionIdx == 0
+}
+
+func readLogsCmd(path string, offset int64) tea.Cmd {
+	return func() tea.Msg {
+		lines, next, err := launch.ReadSessionLogFrom(path, offset)
+		return logsReadMsg{path: path, lines: lines, offset: next, reset: offset == 0, err: err}
+	}
+}
+
+func logsTickCmd(path string) tea.Cmd {
+	return tea.Tick(logsTailInterval, func(time.Time) tea.Msg { return logsTickMsg{path: path} })
+}
+
+// openSession switches to sessions[idx] and starts reading it from the beginning.
+func (m *LogsModel) openSession(idx int) tea.Cmd {
+	m.sessionIdx = idx
+	m.lines = nil
+	m.visible = nil
+	m.offset = 0
+	m.top = 0
+	m.follow = true
+	m.matches = nil
+	path := m.currentPath()
+	if path == "" {
+		return nil
+	}
+	cmds := []tea.Cmd{readLogsCmd(path, 0)}
+	if m.tailing() {
+		cmds = append(cmds, logsTickCmd(path))
+	}
+	return tea.Batch(cmds...)
+}
+
+// bodyHeight is the number of log rows that fit between the header and footer.
+func (m *LogsModel) bodyHeight() int {
+	return max(3, m.height-6)
+}
+
+func (m *LogsModel) maxTop() int {
+	return max(0, len(m.visible)-m.bodyHeight())
+}
+
+func (m *LogsModel) clampTop() {
+	if m.follow {
+		m.top = m.maxTop()
+	}
+	m.top = max(0, min(m.top, m.maxTop()))
+}
+
+func (m *LogsModel) scroll(delta int) {
+	m.top += delta
+	m.follow = m.top >= m.maxTop()
+	m.clampTop()
+}
+
+// refilter recomputes visible rows after the filter or buffer changes, keeping the
+// top line in place where possible.
+func (m *LogsModel) refilter() {
+	anchor := -1
+	if m.top < len(m.visible) {
+		anchor = m.visible[m.top]
+	}
+	m.visible = m.visible[:0]
+	m.top = 0
+	for i, line := range m.lines {
+		if !m.filter.Allows(line) {
+			continue
+		}
+		if anchor >= 0 && i <= anchor {
+			m.top = len(m.visible)
+		}
+		m.visible = append(m.visible, i)
+	}
+	m.rematch()
+	m.clampTop()
+}
+
+// appendLines adds newly tailed lines without rescanning the whole buffer.
+func (m *LogsModel) appendLines(lines []string) {
+	for _, line := range lines {
+		m.lines = append(m.lines, line)
+		if !m.filter.Allows(line) {
+			continue
+		}
+		m.visible = append(m.visible, len(m.lines)-1)
+		if m.query != "" && containsFold(line, m.query) {
+			m.matches = append(m.matches, len(m.visible)-1)
+		}
+	}
+	m.clampTop()
+}
+
+// rematch recomputes search matches over visible rows.
+func (m *LogsModel) rematch() {
+	m.matches = m.matches[:0]
+	if m.query == "" {
+		return
+	}
+	for row, i := range m.visible {
+		if containsFold(m.lines[i], m.query) {
+			m.matches = append(m.matches, row)
+		}
+	}
+	m.matchIdx = min(m.matchIdx, max(0, len(m.matches)-1))
+}
+
+// jumpToMatch moves to the first match at or after row (wrapping), or before it when back is set.
+func (m *LogsModel) jumpToMatch(row int, back bool) {
+	if len(m.matches) == 0 {
+		return
+	}
+	idx := -1
+	if back {
+		for i := len(m.matches) - 1; i >= 0; i-- {
+			if m.matches[i] <= row {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			idx = len(m.matches) - 1
+		}
+	} else {
+		for i, r := range m.matches {
+			if r >= row {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			idx = 0
+		}
+	}
+	m.matchIdx = idx
+	// Center the match in the body.
+	m.top = m.matches[idx] - m.bodyHeight()/2
+	m.follow = false
+	m.clampTop()
+}
+
+func containsFold(s, substr string) bool {
+	start, _ := indexFold(s, substr)
+	return start >= 0
+}
+
+// indexFold returns the byte span in s of the first case-insensitive match of
+// substr, or -1, -1. It compares rune windows of s itself rather than a
+// lowercased copy, whose byte offsets differ from s wherever lowercasing changes
+// a character's encoded length ("İ", the Kelvin sign).
+func indexFold(s, substr string) (start, end int) {
+	n := utf8.RuneCountInString(substr)
+	if n == 0 {
+		return -1, -1
+	}
+	for start = 0; start < len(s); {
+		end = start
+		for k := 0; k < n; k++ {
+			if end >= len(s) {
+				return -1, -1
+			}
+			_, size := utf8.DecodeRuneInString(s[end:])
+			end += size
+		}
+		if strings.EqualFold(s[start:end], substr) {
+			return start, end
+		}
+		_, size := utf8.DecodeRuneInString(s[start:])
+		start += size
+	}
+	return -1, -1
+}
+
+// Update implements tea.Model.
+func (m *LogsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case logsListedMsg:
+		if msg.err != nil {
+			m.notice = fmt.Sprintf("Couldn't list session logs: %v", msg.err)
+			return m, nil
+		}
+		m.sessions = msg.sessions
+		m.records = msg.records
+		idx := 0
+		for i, s := range m.sessions {
+			if s.Path == m.initial {
+				idx = i
+			}
+		}
+		return m, m.openSession(idx)
+
+	case logsReadMsg:
+		if msg.path != m.currentPath() {
+			return m, nil // a read for a session we've since left
+		}
+		if msg.err != nil {
+			m.notice = fmt.Sprintf("Couldn't read log: %v", msg.err)
+			return m, nil
+		}
+		m.offset = msg.offset
+		if msg.reset {
+			m.lines = msg.lines
+			m.refilter()
+		} else {
+			m.appendLines(msg.lines)
+		}
+		return m, nil
+
+	case logsTickMsg:
+		if msg.path != m.currentPath() || !m.tailing() {
+			return m, nil
+		}
+		return m, tea.Batch(readLogsCmd(msg.path, m.offset), logsTickCmd(msg.path))
+
+	case tea.KeyMsg:
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		m.notice = ""
+		switch msg.String() {
+		case "esc", "q":
+			if m.query != "" && msg.String() == "esc" {
+				m.query = ""
+				m.search.SetValue("")
+				m.rematch()
+				return m, nil
+			}
+			return m, func() tea.Msg { return CloseLogs{} }
+		case "up", "k":
+			m.scroll(-1)
+		case "down", "j":
+			m.scroll(1)
+		case "pgup", "ctrl+u":
+			m.scroll(-m.bodyHeight() / 2)
+		case "pgdown", "ctrl+d", " ":
+			m.scroll(m.bodyHeight() / 2)
+		case "home", "g":
+			m.top = 0
+			m.follow = false
+			m.clampTop()
+		case "end", "G":
+			m.follow = true
+			m.clampTop()
+		case "/":
+			m.searching = true
+			m.search.SetValue(m.query)
+			m.search.CursorEnd()
+			return m, m.search.Focus()
+		case "n":
+			if len(m.matches) > 0 {
+				m.jumpToMatch(m.matches[m.matchIdx]+1, false)
+			}
+		case "N":
+			if len(m.matches) > 0 {
+				m.jumpToMatch(m.matches[m.matchIdx]-1, true)
+			}
+		case "v":
+			m.filter = launch.ParseLaunchLogVerbosity(launch.CycleLaunchLogVerbosity(m.filter.ConfigString()))
+			m.refilter()
+		case "[":
+			if m.sessionIdx+1 < len(m.sessions) {
+				return m, m.openSession(m.sessionIdx + 1)
+			}
+		case "]":
+			if m.sessionIdx > 0 {
+				return m, m.openSession(m.sessionIdx - 1)
+			}
+		case "o":
+			if path := m.currentPath(); path != "" {
+				if err := openURL(path); err != nil {
+					m.notice = fmt.Sprintf("Couldn't open %s: %v", path, err)
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// updateSearch handles keys while the search prompt is open; matches update as you type.
+func (m *LogsModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.searching = false
+		m.search.Blur()
+		m.query = ""
+		m.rematch()
+		return m, nil
+	case "enter":
+		m.searching = false
+		m.search.Blur()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	if q := m.search.Value(); q != m.query {
+		m.query = q
+		m.rematch()
+		m.jumpToMatch(m.top, false)
+	}
+	return m, cmd
+}
+
+// View implements tea.Model.
+func (m *LogsModel) View() string {
+	subtitle := "No session logs yet. Launch the game to record one."
+	if path := m.currentPath(); path != "" {
+		s := m.sessions[m.sessionIdx]
+		subtitle = fmt.Sprintf("Session %d/%d %s %s", m.sessionIdx+1, len(m.sessions), GlyphDot, s.Started.Format("Jan 2 15:04:05"))
+		if exit := m.exitStatus(); exit != "" {
+			subtitle += " " + GlyphDot + " " + exit
+		}
+		if m.tailing() {
+			subtitle += " " + GlyphDot + " latest"
+		}
+		subtitle += fmt.Sprintf(" %s filter: %s", GlyphDot, m.filter.ShortLabel())
+	}
+	header := ScreenHeader("Logs: "+m.instance.Name, subtitle)
+
+	bodyH := m.bodyHeight()
+	width := max(20, m.width)
+	current := -1
+	if len(m.matches) > 0 {
+		current = m.matches[m.matchIdx]
+	}
+	rows := make([]string, 0, bodyH)
+	for row := m.top; row < len(m.visible) && len(rows) < bodyH; row++ {
+		gutter := "  "
+		if row == current {
+			gutter = lipgloss.NewStyle().Foreground(Active.Primary).Render(GlyphPointer + " ")
+		}
+		rows = append(rows, gutter+m.renderLine(m.lines[m.visible[row]], width-2))
+	}
+	for len(rows) < bodyH {
+		rows = append(rows, "")
+	}
+
+	var status string
+	switch {
+	case m.notice != "":
+		status = lipgloss.NewStyle().Foreground(Active.Error).Render(m.notice)
+	case m.searching:
+		status = m.search.View()
+	case m.query != "":
+		pos := 0
+		if len(m.matches) > 0 {
+			pos = m.matchIdx + 1
+		}
+		status = lipgloss.NewStyle().Foreground(Active.TextSubtle).
+			Render(fmt.Sprintf("/%s  %d/%d matches", m.query, pos, len(m.matches)))
+	default:
+		status = lipgloss.NewStyle().Foreground(Active.TextMuted).
+			Render(fmt.Sprintf("%d/%d lines", len(m.visible), len(m.lines)))
+	}
+
+	help := KeyHints(width,
+		KeyHint{"↑↓", "scroll"},
+		KeyHint{"/", "search"},
+		KeyHint{"n/N", "next/prev"},
+		KeyHint{"v", "filter"},
+		KeyHint{"[ ]", "older/newer"},
+		KeyHint{"o", "open file"},
+		KeyHint{"esc", "back"},
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, "", strings.Join(rows, "\n"), status, help)
+}
+
+// renderLine truncates line to width, colors it by severity, and highlights the search query.
+func (m *LogsModel) renderLine(line string, width int) string {
+	line = ansi.Truncate(strings.ReplaceAll(line, "\t", "    "), width, titleEllipsis)
+	style := lipgloss.NewStyle().Foreground(Active.TextSubtle)
+	switch launch.LineLevel(line) {
+	case launch.LogVerbosityError:
+		style = lipgloss.NewStyle().Foreground(Active.Error)
+	case launch.LogVerbosityWarn:
+		style = lipgloss.NewStyle().Foreground(Active.Warning)
+	}
+	if m.query == "" {
+		return style.Render(line)
+	}
+	hl := lipgloss.NewStyle().Foreground(OnColor(Active.Primary)).Background(Active.Primary)
+	var b strings.Builder
+	for {
+		start, end := indexFold(line, m.query)
+		if start < 0 {
+			b.WriteString(style.Render(line))
+			break
+		}
+		b.WriteString(style.Render(line[:start]))
+		b.WriteString(hl.Render(line[start:end]))
+		line = line[end:]
+	}
+	return b.String()
+}
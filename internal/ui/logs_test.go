@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/launch"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// writeSessionLog writes a session log for inst named after started.
+func writeSessionLog(t *testing.T, inst *core.Instance, started time.Time, body string) string {
+	t.Helper()
+	dir := launch.SessionLogDir(inst.Path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, started.Format("2006-01-02_15-04-05")+".log")
+	if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// runLogsCmd runs cmd and feeds every non-tick message it produces back into m.
+func runLogsCmd(m *LogsModel, cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			runLogsCmd(m, c)
+		}
+	case logsTickMsg:
+	default:
+		_, next := m.Update(msg)
+		runLogsCmd(m, next)
+	}
+}
+
+func newTestLogsModel(t *testing.T, path string) (*LogsModel, *core.Instance) {
+	t.Helper()
+	inst := &core.Instance{Name: "Test", Path: t.TempDir()}
+	return NewLogsModel(inst, path), inst
+}
+
+const sampleSessionLog = "[12:00:00] [main/INFO]: Loading\n" +
+	"[12:00:01] [main/WARN]: Missing sound\n" +
+	"[12:00:02] [main/ERROR]: Failed to load texture\n" +
+	"[12:00:03] [main/INFO]: Texture loaded\n"
+
+func TestLogs_FilterReappliesToAllLines(t *testing.T) {
+	m, inst := newTestLogsModel(t, "")
+	writeSessionLog(t, inst, time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local), sampleSessionLog)
+	m.SetSize(80, 30)
+	runLogsCmd(m, m.Init())
+
+	if len(m.lines) != 4 || len(m.visible) != 4 {
+		t.Fatalf("lines=%d visible=%d, want 4/4", len(m.lines), len(m.visible))
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")}) // all → errors
+	if m.filter != launch.LogVerbosityError || len(m.visible) != 1 {
+		t.Fatalf("filter=%v visible=%d, want errors/1", m.filter, len(m.visible))
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")}) // errors → warnings
+	if len(m.visible) != 2 {
+		t.Errorf("visible=%d with warnings, want 2", len(m.visible))
+	}
+}
+
+func TestLogs_SearchMatches(t *testing.T) {
+	m, inst := newTestLogsModel(t, "")
+	writeSessionLog(t, inst, time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local), sampleSessionLog)
+	m.SetSize(80, 30)
+	runLogsCmd(m, m.Init())
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	for _, r := range "texture" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.searching || m.query != "texture" {
+		t.Fatalf("searching=%v query=%q", m.searching, m.query)
+	}
+	if len(m.matches) != 2 || m.matches[m.matchIdx] != 2 {
+		t.Fatalf("matches=%v idx=%d, want [2 3] at 0", m.matches, m.matchIdx)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.matches[m.matchIdx] != 3 {
+		t.Errorf("after n, match row = %d, want 3", m.matches[m.matchIdx])
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.matches[m.matchIdx] != 2 {
+		t.Errorf("n should wrap to row 2, got %d", m.matches[m.matchIdx])
+	}
+}
+
+func TestLogs_SwitchSessions(t *testing.T) {
+	m, inst := newTestLogsModel(t, "")
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local)
+	older := writeSessionLog(t, inst, base, "old\n")
+	writeSessionLog(t, inst, base.Add(time.Hour), "new\n")
+	m.SetSize(80, 30)
+	runLogsCmd(m, m.Init())
+
+	if len(m.lines) != 1 || m.lines[0] != "new" {
+		t.Fatalf("opened %q, want newest session", m.lines)
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("[")})
+	runLogsCmd(m, cmd)
+	if m.currentPath() != older || len(m.lines) != 1 || m.lines[0] != "old" {
+		t.Errorf("after [, path=%s lines=%q", m.currentPath(), m.lines)
+	}
+}
+
+func TestLogs_OpensRequestedSession(t *testing.T) {
+	inst := &core.Instance{Name: "Test", Path: t.TempDir()}
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local)
+	older := writeSessionLog(t, inst, base, "old\n")
+	writeSessionLog(t, inst, base.Add(time.Hour), "new\n")
+	m := NewLogsModel(inst, older)
+	m.SetSize(80, 30)
+	runLogsCmd(m, m.Init())
+	if m.sessionIdx != 1 || len(m.lines) != 1 || m.lines[0] != "old" {
+		t.Errorf("sessionIdx=%d lines=%q, want the requested older session", m.sessionIdx, m.lines)
+	}
+}
+
+func TestLogs_ShowsExitStatus(t *testing.T) {
+	m, inst := newTestLogsModel(t, "")
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local)
+	writeSessionLog(t, inst, base, "old\n")
+	writeSessionLog(t, inst, base.Add(time.Hour), "new\n")
+	for _, s := range []core.Session{
+		core.NewSession(base.Add(300*time.Millisecond), base.Add(time.Minute), 1, "Steve", false),
+		core.NewUnobservedSession(base.Add(time.Hour), base.Add(2*time.Hour), "Steve", false),
+	} {
+		if err := core.AppendSession(inst.Path, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m.SetSize(100, 30)
+	runLogsCmd(m, m.Init())
+
+	if got := m.exitStatus(); got != "exit unknown" {
+		t.Errorf("newest session exit = %q, want exit unknown", got)
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("[")})
+	runLogsCmd(m, cmd)
+	if got := m.exitStatus(); got != "exit 1" {
+		t.Errorf("older session exit = %q, want exit 1", got)
+	}
+}
+
+func TestIndexFold_NonASCII(t *testing.T) {
+	tests := []struct {
+		s, substr  string
+		start, end int
+	}{
+		{"\u212a\u212a\u212a err", "err", 10, 13}, // Kelvin signs are 3 bytes but lowercase to 1
+		{"İİ err", "err", 5, 8},                   // İ is 2 bytes but lowercases to 3
+		{"İİ ERR", "err", 5, 8},
+		{"kelvin: \u212a", "k", 0, 1},
+		{"no match", "err", -1, -1},
+		{"short", "longer than s", -1, -1},
+		{"anything", "", -1, -1},
+	}
+	for _, tt := range tests {
+		start, end := indexFold(tt.s, tt.substr)
+		if start != tt.start || end != tt.end {
+			t.Errorf("indexFold(%q, %q) = %d, %d, want %d, %d", tt.s, tt.substr, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func TestLogs_RenderLineHighlightsNonASCII(t *testing.T) {
+	m, _ := newTestLogsModel(t, "")
+	m.query = "err"
+	for _, line := range []string{"İİ err", "\u212a\u212a\u212a err", "İİİİİİİİ"} {
+		got := ansi.Strip(m.renderLine(line, 80))
+		if got != line {
+			t.Errorf("renderLine(%q) = %q, want the line unchanged apart from styling", line, got)
+		}
+	}
+}
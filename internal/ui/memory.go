@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/aayushdutt/mctui/internal/memory"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// memoryTrackMB is the slider's right end when the machine's RAM is unknown.
+	memoryTrackMB = 16384
+	// memoryBigStepMB is how far shift+←/→ moves a memory slider.
+	memoryBigStepMB = 2048
+	// memoryTrackWidth is the slider's width in cells.
+	memoryTrackWidth = 32
+)
+
+// memorySlider picks a heap size in memory.StepMB steps. 0, at the left end,
+// leaves the size to whatever is inherited.
+type memorySlider struct {
+	mb    int // 0 inherits
+	topMB int // right end of the track
+}
+
+// newMemorySlider starts at mb on a track up to the machine's RAM (totalMB,
+// 0 when unknown), stretched to fit mb when that is larger.
+func newMemorySlider(mb, totalMB int) memorySlider {
+	s := memorySlider{mb: mb}
+	s.setTotal(totalMB)
+	return s
+}
+
+// setTotal sizes the track to the machine's RAM.
+func (s *memorySlider) setTotal(totalMB int) {
+	top := memoryTrackMB
+	if totalMB > 0 {
+		top = totalMB / memory.StepMB * memory.StepMB
+	}
+	s.topMB = max(top, s.mb, memory.StepMB)
+}
+
+// step moves the size by deltaMB, stepping onto "inherit" below the smallest
+// size and stopping at the end of the track.
+func (s *memorySlider) step(deltaMB int) {
+	mb := s.mb/memory.StepMB*memory.StepMB + deltaMB
+	switch {
+	case mb < memory.StepMB && deltaMB < 0:
+		s.mb = 0
+	case mb < memory.StepMB:
+		s.mb = memory.StepMB
+	default:
+		s.mb = min(mb, s.topMB)
+	}
+}
+
+// handleKey adjusts the slider for ←/→ (a step) and shift+←/→ (a big step),
+// reporting whether key was one of them.
+func (s *memorySlider) handleKey(key string) bool {
+	switch key {
+	case "left", "h":
+		s.step(-memory.StepMB)
+	case "right", "l":
+		s.step(memory.StepMB)
+	case "shift+left", "H":
+		s.step(-memoryBigStepMB)
+	case "shift+right", "L":
+		s.step(memoryBigStepMB)
+	default:
+		return false
+	}
+	return true
+}
+
+// track draws the slider: filled up to the size, with a knob on it.
+func (s memorySlider) track(focused bool) string {
+	fill := Active.TextDim
+	if focused {
+		fill = Active.Success
+	}
+	pos := 0
+	if s.mb > 0 {
+		pos = max(1, s.mb*(memoryTrackWidth-1)/s.topMB)
+	}
+	filled := lipgloss.NewStyle().Foreground(fill).Render(strings.Repeat("━", pos))
+	knob := lipgloss.NewStyle().Bold(true).Foreground(Active.Title).Render("●")
+	rest := lipgloss.NewStyle().Foreground(Active.BorderSubtle).Render(strings.Repeat("─", memoryTrackWidth-1-pos))
+	return filled + knob + rest
+}
+
+// memorySliderRow renders a titled memory slider with its value, a hint, and a
+// warning when the size may not fit in the machine's memory.
+func memorySliderRow(title, value, hint, warning string, s memorySlider, focused bool) string {
+	dim := lipgloss.NewStyle().Foreground(Active.TextDim)
+	head := lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Foreground(Active.Title).Render(title),
+		"  ",
+		lipgloss.NewStyle().Bold(true).Foreground(Active.Title).Render(value),
+	)
+	rows := []string{head, s.track(focused) + dim.Render("  "+memory.Format(s.topMB)), dim.Render(hint)}
+	if warning != "" {
+		rows = append(rows, lipgloss.NewStyle().Foreground(Active.Warning).Render(GlyphPointer+" "+warning))
+	}
+	rowStyle := lipgloss.NewStyle().PaddingLeft(2)
+	if focused {
+		rowStyle = lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(Active.Success).
+			Background(Active.BorderFaint).
+			PaddingLeft(1).
+			PaddingRight(1)
+	}
+	return rowStyle.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// memorySliderKeys describes the slider keys, for the hint under one.
+var memorySliderKeys = "←/→ " + memory.Format(memory.StepMB) + " · shift+←/→ " + memory.Format(memoryBigStepMB)
@@ -2,7 +2,10 @@
 package ui
 
 import (
+	"time"
+
 	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/java"
 	"github.com/aayushdutt/mctui/internal/launch"
 	"github.com/aayushdutt/mctui/internal/mods"
 )
@@ -28,15 +31,47 @@ type (
 	// NavigateToSettings opens settings
 	NavigateToSettings struct{}
 
+	// NavigateToInstanceSettings opens the per-instance settings editor
+	NavigateToInstanceSettings struct {
+		Instance *core.Instance
+	}
+
+	// NavigateToLogs opens the session log viewer for an instance
+	NavigateToLogs struct {
+		Instance *core.Instance
+		Path     string // session log to open first; empty opens the newest
+	}
+
+	// CloseLogs leaves the session log viewer
+	CloseLogs struct{}
+
 	// NavigateToLaunch starts the launch view
 	NavigateToLaunch struct {
-		Instance *core.Instance
-		Offline  bool
+		Instance  *core.Instance
+		Offline   bool
+		QuickPlay bool // join the instance's saved Quick Play target
 	}
 
 	// NavigateToAuth opens the authentication screen
 	NavigateToAuth struct{}
 
+	// NavigateToRepair re-verifies an instance's game files and repairs what's broken
+	NavigateToRepair struct {
+		Instance *core.Instance
+	}
+
+	// NavigateToJava opens the Java runtime manager; Instance, when set, is the
+	// instance a runtime can be picked for
+	NavigateToJava struct {
+		Instance *core.Instance
+	}
+
+	// SaveQuickPlay sets (or, when QuickPlay is nil, clears) an instance's Quick Play target
+	SaveQuickPlay struct {
+		Instance  *core.Instance
+		QuickPlay *core.QuickPlay
+	}
+
 	// DeleteInstance requests instance deletion
 	DeleteInstance struct {
 		Instance *core.Instance
@@ -57,6 +92,8 @@ type (
 		Error     error
 		// SelectID, if set, moves the home list cursor to that instance after refresh (e.g. newly created).
 		SelectID string
+		// RecentPlayTime is each instance's playtime over the last week, keyed by instance ID.
+		RecentPlayTime map[string]time.Duration
 	}
 
 	// VersionsLoaded is sent when version manifest is fetched
@@ -74,20 +111,103 @@ type (
 
 	// LaunchStatusUpdate is sent during launch
 	LaunchStatusUpdate struct {
-		Status launch.Status
+		InstanceID string
+		Status     launch.Status
 	}
 
 	// LaunchComplete is sent when launch finishes
 	LaunchComplete struct {
-		Error error
+		InstanceID string
+		Error      error
 	}
 
-	// CancelLaunch is sent when user cancels launch
-	CancelLaunch struct{}
+	// CancelLaunch is sent when user cancels a launch that hasn't started the game yet
+	CancelLaunch struct {
+		InstanceID string
+	}
 
 	// RetryLaunch is sent when user retries launch (generic or offline)
 	RetryLaunch struct {
-		Offline bool
+		InstanceID string
+		Offline    bool
+	}
+
+	// SwitchJava pins an instance to the Java at Path and launches it again;
+	// an empty Path clears the pin so a compatible one is picked or downloaded.
+	SwitchJava struct {
+		InstanceID string
+		Path       string
+	}
+
+	// RepairStatusUpdate is sent while an instance's files are verified
+	RepairStatusUpdate struct {
+		InstanceID string
+		Status     launch.Status
+	}
+
+	// RepairComplete is sent when verify & repair finishes; Report is set even
+	// when Err is, as far as the run got
+	RepairComplete struct {
+		InstanceID string
+		Report     *launch.RepairReport
+		Edit       func(*core.Instance) // what the run changed on its copy of the instance, to make to the stored one
+		Err        error
+	}
+
+	// CancelRepair stops a verify & repair run
+	CancelRepair struct {
+		InstanceID string
+	}
+
+	// JavaRuntimesLoaded carries the managed and detected Java runtimes
+	JavaRuntimesLoaded struct {
+		Runtimes []java.Runtime
+	}
+
+	// InstallJava downloads a managed runtime for a Java major version
+	InstallJava struct {
+		Major int
+	}
+
+	// JavaInstallStatus reports progress of a runtime install
+	JavaInstallStatus struct {
+		Message string
+	}
+
+	// JavaInstallDone is sent when a runtime install finishes
+	JavaInstallDone struct {
+		Major int
+		Err   error
+	}
+
+	// DeleteJava removes a managed runtime; instances using it go back to automatic
+	DeleteJava struct {
+		Runtime java.Runtime
+	}
+
+	// SetInstanceJava pins an instance to a Java executable, or with an empty
+	// Path, lets it pick automatically again
+	SetInstanceJava struct {
+		Instance *core.Instance
+		Path     string
+	}
+
+	// SetDefaultJava sets (or, when Path is empty, clears) the Java executable
+	// instances without their own use
+	SetDefaultJava struct {
+		Path string
+	}
+
+	// FocusLaunch shows the launch screen (progress and live log) of an instance
+	// that is launching or running in the background
+	FocusLaunch struct {
+		Instance *core.Instance
+	}
+
+	// StopInstance asks a running game to exit; Force kills it outright
+	StopInstance struct {
+		Instance *core.Instance
+		Force    bool
 	}
 
 	// ProceedWithLaunch continues to the launch view after online session checks pass.
@@ -127,14 +247,47 @@ type (
 		Value bool
 	}
 
+	// PersistHomeSort persists the home list's sort order to config.
+	PersistHomeSort struct {
+		Value string
+	}
+
 	// SettingsSaved carries the edited settings back to the app to apply and persist.
 	SettingsSaved struct {
 		JavaPath      string
+		MinMemoryMB   int // 0 uses memory.DefaultMinMB
+		MaxMemoryMB   int // 0 recommends per instance
 		JVMArgs       []string
+		Env           map[string]string
+		UnsetEnv      []string
+		WindowWidth   int // 0 keeps the game's default
+		WindowHeight  int
+		Fullscreen    bool
+		DetachGames   bool
 		ShowSnapshots bool
 		MSAClientID   string
 		Theme         string
 	}
+
+	// InstanceSettingsSaved carries an instance's edited overrides back to the app to apply and persist.
+	InstanceSettingsSaved struct {
+		Instance     *core.Instance
+		WindowWidth  int // 0 inherits the global window size
+		WindowHeight int
+		Fullscreen   *bool // nil inherits the global fullscreen preference
+		Detach       *bool // nil inherits config.DetachGames
+		MinMemoryMB  int   // 0 inherits the global memory settings
+		MaxMemoryMB  int
+		JVMPreset    string   // launch.JVMPreset ID; empty for none
+		JVMArgs      []string // empty inherits the global JVM arguments
+
+		PreLaunchCommand string
+		WrapperCommand   string
+		PostExitCommand  string
+
+		Env      map[string]string
+		UnsetEnv []string
+	}
 )
 
 // ActiveSessionCheckStatus is the outcome of validating the active account against Minecraft Services.
@@ -51,6 +51,8 @@ type ModsModel struct {
 	installing bool
 	installErr string
 	installOK  string
+	// failedInstall is the last install whose jars partly failed; [f] retries just those.
+	failedInstall *ModInstallDoneMsg
 
 	modsDialog         modsDialogKind
 	modsDialogJar      string
@@ -354,19 +356,39 @@ func (m *ModsModel) runSearchQuery() tea.Cmd {
 }
 
 func (m *ModsModel) installModCmd(projectID, title string) tea.Cmd {
+	inst := m.inst
+	svc := m.svc
+	return m.runInstall(projectID, title, func(ctx context.Context) (*mods.InstallReport, error) {
+		return svc.InstallFabricModWithDeps(ctx, inst, projectID)
+	})
+}
+
+// retryFailedCmd downloads again only the jars that failed in the last install.
+func (m *ModsModel) retryFailedCmd() tea.Cmd {
+	prev := m.failedInstall
+	inst := m.inst
+	svc := m.svc
+	m.installingProjectID = prev.ProjectID
+	m.rebuildBrowseBadges()
+	return m.runInstall(prev.ProjectID, prev.Title, func(ctx context.Context) (*mods.InstallReport, error) {
+		return svc.RetryFailedMods(ctx, inst, prev.Report.Failed)
+	})
+}
+
+// runInstall runs install off the event loop, reporting via ModInstallDoneMsg.
+func (m *ModsModel) runInstall(projectID, title string, install func(context.Context) (*mods.InstallReport, error)) tea.Cmd {
 	m.cancelInstallDownload()
 	m.searchNotice = ""
 	m.installing = true
 	m.installErr = ""
 	m.installOK = ""
-	inst := m.inst
-	svc := m.svc
+	m.failedInstall = nil
 	ctx, cancel := context.WithCancel(context.Background())
 	m.installCancel = cancel
 	ch := make(chan ModInstallDoneMsg, 1)
 	go func() {
 		defer cancel()
-		report, err := svc.InstallFabricModWithDeps(ctx, inst, projectID)
+		report, err := install(ctx)
 		ch <- ModInstallDoneMsg{
 			ProjectID: projectID,
 			Title:     title,
@@ -132,6 +132,11 @@ func (m *ModsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.refreshInstalled()
 				return m, nil
 			}
+		case "f":
+			// Let query field receive "f" (e.g. ferritecore).
+			if m.modsFocus != panelQuery && m.failedInstall != nil && !m.installing {
+				return m, m.retryFailedCmd()
+			}
 		case "d":
 			if m.modsFocus == panelInstalled && m.openRemoveConfirmDialog() {
 				return m, nil
@@ -398,9 +403,11 @@ func (m *ModsModel) applyInstallReport(msg ModInstallDoneMsg) {
 	if len(rep.Failed) > 0 {
 		names := make([]string, 0, len(rep.Failed))
 		for _, mod := range rep.Failed {
-			names = append(names, modReportName(mod))
+			names = append(names, fmt.Sprintf("%s (%s)", modReportName(mod.ResolvedMod), mod.Download.Kind))
 		}
-		m.installErr = fmt.Sprintf("Failed to download: %s", strings.Join(names, ", "))
+		m.installErr = fmt.Sprintf("Failed to download: %s  — [f] retry failed", strings.Join(names, ", "))
+		failed := msg
+		m.failedInstall = &failed
 	}
 
 	incompatible := 0
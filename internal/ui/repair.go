@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/download"
+	"github.com/aayushdutt/mctui/internal/launch"
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// RepairStepResolve is the first verify & repair step, which re-resolves the
+// version metadata (and for Fabric, the merged profile) before any file is checked.
+const RepairStepResolve = "Checking version metadata"
+
+// RepairModel shows a verify & repair run and, once it finishes, its report.
+type RepairModel struct {
+	instance *core.Instance
+	width    int
+	height   int
+
+	progress progress.Model
+	status   launch.Status
+	steps    []stepInfo
+	done     bool
+	err      error
+	report   *launch.RepairReport
+}
+
+// NewRepairModel creates the verify & repair view for inst.
+func NewRepairModel(inst *core.Instance) *RepairModel {
+	return &RepairModel{
+		instance: inst,
+		progress: ThemeProgress(50),
+		steps: []stepInfo{
+			{name: RepairStepResolve, status: "pending"},
+			{name: "Verifying libraries", status: "pending"},
+			{name: "Verifying assets", status: "pending"},
+		},
+	}
+}
+
+// SetSize updates dimensions
+func (m *RepairModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.progress.Width = width - 10
+}
+
+// Done reports whether the run has finished, successfully or not.
+func (m *RepairModel) Done() bool {
+	return m.done
+}
+
+// Init implements tea.Model
+func (m *RepairModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (m *RepairModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case RepairStatusUpdate:
+		m.status = msg.Status
+		for i := range m.steps {
+			if m.steps[i].name == m.status.Step {
+				m.steps[i].status = "running"
+			} else if m.steps[i].status == "running" {
+				m.steps[i].status = "done"
+			}
+		}
+		return m, m.progress.SetPercent(msg.Status.Progress)
+
+	case RepairComplete:
+		m.done = true
+		m.err = msg.Err
+		m.report = msg.Report
+		for i := range m.steps {
+			if m.steps[i].status != "running" {
+				continue
+			}
+			m.steps[i].status = "done"
+			if msg.Err != nil {
+				m.steps[i].status = "error"
+			}
+		}
+		if msg.Err == nil {
+			m.status.Message = ""
+			return m, m.progress.SetPercent(1)
+		}
+		return m, nil
+
+	case progress.FrameMsg:
+		progressModel, cmd := m.progress.Update(msg)
+		m.progress = progressModel.(progress.Model)
+		return m, cmd
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			if m.done {
+				return m, func() tea.Msg { return NavigateToHome{} }
+			}
+			m.status.Message = "Cancelling..."
+			id := m.instance.ID
+			return m, func() tea.Msg { return CancelRepair{InstanceID: id} }
+		case "enter":
+			if m.done {
+				return m, func() tea.Msg { return NavigateToHome{} }
+			}
+		case "r":
+			if m.done {
+				inst := m.instance
+				return m, func() tea.Msg { return NavigateToRepair{Instance: inst} }
+			}
+		}
+	}
+	return m, nil
+}
+
+// View implements tea.Model
+func (m *RepairModel) View() string {
+	panelW := min(max(m.width, 54), 60)
+
+	header := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(OnColor(Active.Primary)).
+		Background(Active.Primary).
+		Padding(0, 1).
+		Render(fmt.Sprintf("Verify & repair: %s", m.instance.Name))
+	info := lipgloss.NewStyle().
+		Foreground(Active.TextSubtle).
+		Render(fmt.Sprintf("Minecraft %s  %s  %s", m.instance.Version, GlyphDot, m.instance.Loader))
+
+	parts := []string{
+		header,
+		info,
+		"",
+		m.progress.View(),
+		"",
+		stepsPanel(m.steps, panelW),
+	}
+	if m.status.Message != "" {
+		parts = append(parts, "", lipgloss.NewStyle().Foreground(Active.TextSubtle).Render(m.status.Message))
+	}
+	if d := m.status.Downloads; d != nil && !m.done && len(d.Active) > 0 {
+		parts = append(parts, "", transfersPanel(d, panelW))
+	}
+
+	var footer string
+	if m.done {
+		if m.report != nil {
+			parts = append(parts, "", repairReportPanel(m.report, panelW))
+			if len(m.report.Failed) > 0 {
+				parts = append(parts, "", failedDownloadsPanel(&download.BatchError{Failures: m.report.Failed}, panelW))
+			}
+		}
+		if m.err != nil {
+			parts = append(parts, "", lipgloss.NewStyle().
+				Bold(true).
+				Foreground(Active.Error).
+				Render(fmt.Sprintf("%s Failed: %v", GlyphFail, m.err)))
+		}
+		footer = KeyHints(panelW,
+			KeyHint{"r", "verify again"},
+			KeyHint{"enter", "home"},
+		)
+	} else {
+		footer = KeyHints(panelW,
+			KeyHint{"esc", "cancel"},
+			KeyHint{"ctrl+c", "quit"},
+		)
+	}
+
+	parts = append(parts, "", footer)
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+// repairReportPanel summarizes a verify & repair run and lists the files it
+// replaced, each marked missing or corrupt.
+func repairReportPanel(r *launch.RepairReport, width int) string {
+	text := lipgloss.NewStyle().Foreground(Active.Text)
+	dim := lipgloss.NewStyle().Foreground(Active.TextSubtle)
+	contentW := width - 4
+
+	border := Active.Success
+	if len(r.Failed) > 0 {
+		border = Active.Error
+	} else if r.Repaired() > 0 {
+		border = Active.Warning
+	}
+
+	rows := []string{text.Render(r.Summary())}
+	if r.Profile != "" {
+		rows = append(rows, dim.Render(r.Profile))
+	}
+	var repaired []string
+	for _, p := range r.Missing {
+		repaired = append(repaired, fmt.Sprintf("%s  (missing)", filepath.Base(p)))
+	}
+	for _, p := range r.Corrupt {
+		repaired = append(repaired, fmt.Sprintf("%s  (corrupt)", filepath.Base(p)))
+	}
+	for i, line := range repaired {
+		if i == transferRows {
+			rows = append(rows, dim.Render(fmt.Sprintf("… %d more", len(repaired)-transferRows)))
+			break
+		}
+		rows = append(rows, dim.Render(ansi.Truncate(line, contentW, titleEllipsis)))
+	}
+	return Panel("Report", strings.Join(rows, "\n"), width, border)
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aayushdutt/mctui/internal/core"
+	"github.com/aayushdutt/mctui/internal/download"
+	"github.com/aayushdutt/mctui/internal/launch"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRepair_ShowsReport(t *testing.T) {
+	m := NewRepairModel(&core.Instance{ID: "s", Name: "Survival", Version: "1.21.4", Loader: "fabric"})
+	m.SetSize(80, 40)
+	m.Update(RepairStatusUpdate{InstanceID: "s", Status: launch.Status{Step: "Verifying assets"}})
+	m.Update(RepairComplete{InstanceID: "s", Report: &launch.RepairReport{
+		Checked: 3400,
+		Missing: []string{"/libs/lwjgl-3.3.3.jar"},
+		Corrupt: []string{"/assets/objects/ab/abcdef"},
+		Failed: []download.Outcome{
+			{Item: download.Item{Path: "/assets/objects/cd/cdef01"}, Kind: download.FailNetwork, Attempts: 4, Err: fmt.Errorf("connection reset by peer")},
+		},
+		Profile: "Fabric profile corrupt, rewritten",
+	}})
+
+	view := m.View()
+	for _, want := range []string{
+		"Checked 3400 files, repaired 2",
+		"Fabric profile corrupt, rewritten",
+		"lwjgl-3.3.3.jar  (missing)",
+		"abcdef  (corrupt)",
+		"1 failed download", "cdef01", "network, 4 attempts",
+		"verify again",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
+
+func TestRepair_EscCancelsWhileRunning(t *testing.T) {
+	m := NewRepairModel(&core.Instance{ID: "s", Name: "Survival"})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if msg, ok := cmd().(CancelRepair); !ok || msg.InstanceID != "s" {
+		t.Fatalf("esc while running = %#v, want CancelRepair", cmd())
+	}
+
+	m.Update(RepairComplete{InstanceID: "s", Report: &launch.RepairReport{Checked: 1}})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, ok := cmd().(NavigateToHome); !ok {
+		t.Fatalf("esc when done = %#v, want NavigateToHome", cmd())
+	}
+}
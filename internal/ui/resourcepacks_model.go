@@ -66,6 +66,8 @@ type ResourcePacksModel struct {
 	applyErr  string
 	applyOK   string
 	statusMsg string
+	// failedDownload is a built pack that didn't download; [r] fetches it again.
+	failedDownload *resourcepacks.DownloadError
 	// noticeToken sequences transient-notice timers so a stale clear can't wipe a
 	// fresher message.
 	noticeToken int
@@ -280,6 +282,26 @@ func (m *ResourcePacksModel) loadCatalogCmd() tea.Cmd {
 // applyCmd builds the merged pack and applies it (writes zip + enables in
 // options.txt) off the event loop, reporting via rpApplyDoneMsg.
 func (m *ResourcePacksModel) applyCmd() tea.Cmd {
+	svc := m.svc
+	inst := m.inst
+	return m.runApply(func(ctx context.Context, sel *resourcepacks.Selection) (*resourcepacks.ApplyResult, error) {
+		return svc.BuildAndApply(ctx, inst, sel)
+	})
+}
+
+// retryDownloadCmd fetches the last built pack again without rebuilding it.
+func (m *ResourcePacksModel) retryDownloadCmd() tea.Cmd {
+	svc := m.svc
+	inst := m.inst
+	failed := m.failedDownload
+	return m.runApply(func(ctx context.Context, sel *resourcepacks.Selection) (*resourcepacks.ApplyResult, error) {
+		return svc.RetryDownload(ctx, inst, sel, failed)
+	})
+}
+
+// runApply runs apply off the event loop on a copy of the cart, reporting via
+// rpApplyDoneMsg.
+func (m *ResourcePacksModel) runApply(apply func(context.Context, *resourcepacks.Selection) (*resourcepacks.ApplyResult, error)) tea.Cmd {
 	if m.applyCancel != nil {
 		m.applyCancel()
 		m.applyCancel = nil
@@ -287,8 +309,7 @@ func (m *ResourcePacksModel) applyCmd() tea.Cmd {
 	m.applying = true
 	m.applyErr = ""
 	m.applyOK = ""
-	svc := m.svc
-	inst := m.inst
+	m.failedDownload = nil
 	// Hand the goroutine its OWN deep copy of the selection. BuildAndApply mutates
 	// (MarkApplied) and persists the cart; mutating the live m.sel here would race
 	// View()/headerBlock(), which read m.sel concurrently while m.applying is true.
@@ -301,7 +322,7 @@ func (m *ResourcePacksModel) applyCmd() tea.Cmd {
 	ch := make(chan rpApplyDoneMsg, 1)
 	go func() {
 		defer cancel()
-		res, err := svc.BuildAndApply(ctx, inst, sel)
+		res, err := apply(ctx, sel)
 		ch <- rpApplyDoneMsg{result: res, err: err, seq: seq}
 	}()
 	return func() tea.Msg {
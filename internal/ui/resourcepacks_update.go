@@ -98,6 +98,9 @@ func (m *ResourcePacksModel) handleApplyDone(msg rpApplyDoneMsg) (tea.Model, tea
 			return m, nil
 		}
 		m.applyErr = msg.err.Error()
+		if errors.As(msg.err, &m.failedDownload) {
+			m.applyErr += "  — [r] retry download"
+		}
 		return m, nil
 	}
 	m.applyErr = ""
@@ -221,6 +224,12 @@ func (m *ResourcePacksModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "b", "B":
 		return m, m.startApply()
 
+	case "r", "R":
+		if m.failedDownload != nil {
+			m.applyErr = ""
+			return m, tea.Batch(m.spinner.Tick, m.retryDownloadCmd())
+		}
+
 	case "c", "C":
 		if m.sel.Count() > 0 {
 			m.rpDialog = rpDialogConfirmClear
@@ -2,10 +2,16 @@
 package ui
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/aayushdutt/mctui/internal/config"
+	"github.com/aayushdutt/mctui/internal/launch"
+	"github.com/aayushdutt/mctui/internal/memory"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -16,7 +22,13 @@ type settingsFocus int
 
 const (
 	focusSettingsJavaPath settingsFocus = iota
+	focusSettingsMaxMemory
+	focusSettingsMinMemory
 	focusSettingsJVMArgs
+	focusSettingsEnv
+	focusSettingsWindowSize
+	focusSettingsFullscreen
+	focusSettingsDetach
 	focusSettingsSnapshots
 	focusSettingsTheme
 	focusSettingsMSAClientID
@@ -26,7 +38,13 @@ const (
 // settingsFocusOrder is the fixed Tab order of the form.
 var settingsFocusOrder = []settingsFocus{
 	focusSettingsJavaPath,
+	focusSettingsMaxMemory,
+	focusSettingsMinMemory,
 	focusSettingsJVMArgs,
+	focusSettingsEnv,
+	focusSettingsWindowSize,
+	focusSettingsFullscreen,
+	focusSettingsDetach,
 	focusSettingsSnapshots,
 	focusSettingsTheme,
 	focusSettingsMSAClientID,
@@ -43,8 +61,15 @@ type SettingsModel struct {
 
 	javaPath    textinput.Model
 	jvmArgs     textinput.Model
+	env         textinput.Model
+	windowSize  textinput.Model
 	msaClientID textinput.Model
+	fullscreen  bool
+	detach      bool
 	snapshots   bool
+	maxMemory   memorySlider
+	minMemory   memorySlider
+	system      memory.System // the machine's RAM, for the sliders and warnings
 
 	themeNames []string // registered theme names, in order
 	themeIdx   int      // index of the previewed/selected theme
@@ -77,9 +102,15 @@ func NewSettingsModel(cfg *config.Config) *SettingsModel {
 	m := &SettingsModel{
 		focus:       focusSettingsJavaPath,
 		javaPath:    mk(cfg.JavaPath, "Auto-detect (leave empty)", 48),
-		jvmArgs:     mk(strings.Join(cfg.JVMArgs, " "), strings.Join(config.DefaultJVMArgs(), " "), 48),
+		jvmArgs:     mk(strings.Join(cfg.JVMArgs, " "), "e.g. -XX:+UseG1GC", 48),
+		env:         mk(formatEnvVars(cfg.Env, cfg.UnsetEnv), "e.g. __GL_THREADED_OPTIMIZATIONS=1 -_JAVA_OPTIONS", 48),
+		windowSize:  mk(formatWindowSize(cfg.WindowWidth, cfg.WindowHeight), "Game default, e.g. 1280x720", 48),
 		msaClientID: mk(cfg.MSAClientID, config.DefaultMSAClientID, 48),
+		fullscreen:  cfg.Fullscreen,
+		detach:      cfg.DetachGames,
 		snapshots:   cfg.ShowSnapshots,
+		maxMemory:   newMemorySlider(cfg.MaxMemoryMB, 0),
+		minMemory:   newMemorySlider(cfg.MinMemoryMB, 0),
 		themeNames:  themeNames,
 		themeIdx:    themeIdx,
 		origTheme:   ActiveName(),
@@ -95,9 +126,19 @@ func (m *SettingsModel) SetSize(width, height int) {
 	w := min(60, max(24, width-6))
 	m.javaPath.Width = w
 	m.jvmArgs.Width = w
+	m.env.Width = w
+	m.windowSize.Width = w
 	m.msaClientID.Width = w
 }
 
+// SetSystemMemory sizes the memory sliders to the machine's RAM and enables
+// the warnings and recommendation that need it.
+func (m *SettingsModel) SetSystemMemory(sys memory.System) {
+	m.system = sys
+	m.maxMemory.setTotal(sys.TotalMB)
+	m.minMemory.setTotal(sys.TotalMB)
+}
+
 // Init implements tea.Model.
 func (m *SettingsModel) Init() tea.Cmd {
 	return textinput.Blink
@@ -109,6 +150,10 @@ func (m *SettingsModel) focusedInput() *textinput.Model {
 		return &m.javaPath
 	case focusSettingsJVMArgs:
 		return &m.jvmArgs
+	case focusSettingsEnv:
+		return &m.env
+	case focusSettingsWindowSize:
+		return &m.windowSize
 	case focusSettingsMSAClientID:
 		return &m.msaClientID
 	}
@@ -119,6 +164,8 @@ func (m *SettingsModel) applyFocus(f settingsFocus) {
 	m.focus = f
 	m.javaPath.Blur()
 	m.jvmArgs.Blur()
+	m.env.Blur()
+	m.windowSize.Blur()
 	m.msaClientID.Blur()
 	if in := m.focusedInput(); in != nil {
 		in.Focus()
@@ -152,10 +199,13 @@ func (m *SettingsModel) cycleTheme(delta int) {
 func (m *SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		// Space toggles the snapshots checkbox when focused.
-		if m.focus == focusSettingsSnapshots &&
-			(msg.Type == tea.KeySpace || msg.String() == " " || msg.String() == "space") {
-			m.snapshots = !m.snapshots
+		// Space toggles the focused checkbox.
+		if msg.Type == tea.KeySpace || msg.String() == " " || msg.String() == "space" {
+			if m.toggleFocusedCheckbox() {
+				return m, nil
+			}
+		}
+		if s := m.focusedSlider(); s != nil && s.handleKey(msg.String()) {
 			return m, nil
 		}
 		// Left/right cycles the theme selector with live preview when focused.
@@ -182,8 +232,7 @@ func (m *SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.cycleFocus(-1)
 			return m, textinput.Blink
 		case "enter":
-			if m.focus == focusSettingsSnapshots {
-				m.snapshots = !m.snapshots
+			if m.toggleFocusedCheckbox() {
 				return m, nil
 			}
 			return m.submit()
@@ -198,6 +247,32 @@ func (m *SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// focusedSlider returns the focused memory slider, if any.
+func (m *SettingsModel) focusedSlider() *memorySlider {
+	switch m.focus {
+	case focusSettingsMaxMemory:
+		return &m.maxMemory
+	case focusSettingsMinMemory:
+		return &m.minMemory
+	}
+	return nil
+}
+
+// toggleFocusedCheckbox flips the focused checkbox, reporting false when focus is elsewhere.
+func (m *SettingsModel) toggleFocusedCheckbox() bool {
+	switch m.focus {
+	case focusSettingsSnapshots:
+		m.snapshots = !m.snapshots
+	case focusSettingsFullscreen:
+		m.fullscreen = !m.fullscreen
+	case focusSettingsDetach:
+		m.detach = !m.detach
+	default:
+		return false
+	}
+	return true
+}
+
 func (m *SettingsModel) submit() (*SettingsModel, tea.Cmd) {
 	javaPath := strings.TrimSpace(m.javaPath.Value())
 	if javaPath != "" {
@@ -207,11 +282,36 @@ func (m *SettingsModel) submit() (*SettingsModel, tea.Cmd) {
 			return m, textinput.Blink
 		}
 	}
+	env, unsetEnv, err := parseEnvVars(m.env.Value())
+	if err != nil {
+		m.saveErr = "Environment variables: " + err.Error()
+		m.applyFocus(focusSettingsEnv)
+		return m, textinput.Blink
+	}
+	width, height, err := parseWindowSize(m.windowSize.Value())
+	if err != nil {
+		m.saveErr = "Window size: " + err.Error()
+		m.applyFocus(focusSettingsWindowSize)
+		return m, textinput.Blink
+	}
+	if m.maxMemory.mb > 0 && m.minMemory.mb > m.maxMemory.mb {
+		m.saveErr = "Memory: the minimum is above the maximum"
+		m.applyFocus(focusSettingsMinMemory)
+		return m, nil
+	}
 	m.saveErr = ""
 
 	saved := SettingsSaved{
 		JavaPath:      javaPath,
+		MinMemoryMB:   m.minMemory.mb,
+		MaxMemoryMB:   m.maxMemory.mb,
 		JVMArgs:       strings.Fields(m.jvmArgs.Value()),
+		Env:           env,
+		UnsetEnv:      unsetEnv,
+		WindowWidth:   width,
+		WindowHeight:  height,
+		Fullscreen:    m.fullscreen,
+		DetachGames:   m.detach,
 		ShowSnapshots: m.snapshots,
 		MSAClientID:   strings.TrimSpace(m.msaClientID.Value()),
 		Theme:         m.themeNames[m.themeIdx],
@@ -238,27 +338,16 @@ func (m *SettingsModel) View() string {
 		return lipgloss.JoinVertical(lipgloss.Left, lbl, box, hintLine)
 	}
 
-	javaBlock := field("Java path", "Leave empty to auto-detect or download.", m.javaPath, m.focus == focusSettingsJavaPath)
-	jvmBlock := field("JVM arguments", "Space-separated. Empty falls back to the default.", m.jvmArgs, m.focus == focusSettingsJVMArgs)
+	javaBlock := field("Java path", "Leave empty to auto-detect or download; J on home picks from installed runtimes.", m.javaPath, m.focus == focusSettingsJavaPath)
+	jvmBlock := field("JVM arguments", "Space-separated. An -Xmx or -Xms here overrides the memory settings.", m.jvmArgs, m.focus == focusSettingsJVMArgs)
+	maxMemoryBlock, minMemoryBlock := m.memoryBlocks()
+	envBlock := field("Environment variables", "KEY=VALUE sets, -KEY unsets; quote values with spaces. Instances can override.", m.env, m.focus == focusSettingsEnv)
 	msaBlock := field("Microsoft client ID", "Advanced. Empty uses the built-in default. Changing this may require signing in again.", m.msaClientID, m.focus == focusSettingsMSAClientID)
 
-	// Snapshots checkbox row, styled to match the wizard's starter-mods row.
-	cbFocused := m.focus == focusSettingsSnapshots
-	mark := wizardCheckboxGlyph(m.snapshots, cbFocused)
-	cbTitle := lipgloss.NewStyle().Foreground(Active.Title).Render("Show snapshots in the version list")
-	cbSub := lipgloss.NewStyle().Foreground(Active.TextDim).Render("Includes pre-releases and weekly snapshots")
-	cbLabel := lipgloss.JoinVertical(lipgloss.Left, cbTitle, cbSub)
-	cbRow := lipgloss.JoinHorizontal(lipgloss.Top, mark, "  ", cbLabel)
-	rowStyle := lipgloss.NewStyle().PaddingLeft(2)
-	if cbFocused {
-		rowStyle = lipgloss.NewStyle().
-			Border(lipgloss.NormalBorder(), false, false, false, true).
-			BorderForeground(Active.Success).
-			Background(Active.BorderFaint).
-			PaddingLeft(1).
-			PaddingRight(1)
-	}
-	snapshotsBlock := rowStyle.Render(cbRow)
+	windowBlock := field("Window size", "WIDTHxHEIGHT. Empty keeps the game's default. Instances can override.", m.windowSize, m.focus == focusSettingsWindowSize)
+	fullscreenBlock := settingsCheckboxRow("Start in fullscreen", "Instances can override this", m.fullscreen, m.focus == focusSettingsFullscreen)
+	detachBlock := settingsCheckboxRow("Keep games running after quitting mctui", "Output goes to the session log; mctui reattaches on restart. Instances can override this", m.detach, m.focus == focusSettingsDetach)
+	snapshotsBlock := settingsCheckboxRow("Show snapshots in the version list", "Includes pre-releases and weekly snapshots", m.snapshots, m.focus == focusSettingsSnapshots)
 
 	// Theme selector row, styled to match the snapshots checkbox row.
 	themeFocused := m.focus == focusSettingsTheme
@@ -307,22 +396,153 @@ func (m *SettingsModel) View() string {
 	help := lipgloss.NewStyle().MarginTop(1).Render(KeyHints(max(40, m.width-4),
 		KeyHint{"tab", "move"},
 		KeyHint{"space", "toggle"},
-		KeyHint{"←→", "theme"},
+		KeyHint{"←→", "theme / memory"},
 		KeyHint{"enter", "save"},
 		KeyHint{"esc", "cancel"},
 	))
 
+	// Blocks follow settingsFocusOrder so the focused one can be kept on screen.
+	blocks := []string{javaBlock, maxMemoryBlock, minMemoryBlock, jvmBlock, envBlock, windowBlock, fullscreenBlock, detachBlock, snapshotsBlock, themeBlock, msaBlock, saveBtn}
+	focused := 0
+	for i, f := range settingsFocusOrder {
+		if f == m.focus {
+			focused = i
+		}
+	}
+	bodyH := m.height - lipgloss.Height(header) - 1 - lipgloss.Height(errBlock) - lipgloss.Height(help)
+
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		header,
 		"",
-		javaBlock,
-		jvmBlock,
-		snapshotsBlock,
-		themeBlock,
-		msaBlock,
-		saveBtn,
+		FormWindow(blocks, focused, bodyH),
 		errBlock,
 		help,
 	)
 }
+
+// memoryBlocks renders the maximum and minimum memory sliders.
+func (m *SettingsModel) memoryBlocks() (maxBlock, minBlock string) {
+	maxValue := "Auto"
+	maxHint := "Auto recommends per instance: " + memory.Format(memory.Recommend(m.system.TotalMB, 0).MaxMB) + " for vanilla, more with mods"
+	if m.maxMemory.mb > 0 {
+		maxValue = memory.Format(m.maxMemory.mb)
+		maxHint = "Instances can override this"
+	}
+	maxBlock = memorySliderRow("Maximum memory", maxValue, maxHint+" · "+memorySliderKeys,
+		memory.Warning(m.maxMemory.mb, m.system), m.maxMemory, m.focus == focusSettingsMaxMemory)
+
+	minValue := "Default (" + memory.Format(memory.DefaultMinMB) + ")"
+	if m.minMemory.mb > 0 {
+		minValue = memory.Format(m.minMemory.mb)
+	}
+	minBlock = memorySliderRow("Minimum memory", minValue, "Heap the game starts with · "+memorySliderKeys,
+		"", m.minMemory, m.focus == focusSettingsMinMemory)
+	return maxBlock, minBlock
+}
+
+// settingsCheckboxRow renders a titled checkbox row, styled to match the wizard's starter-mods row.
+func settingsCheckboxRow(title, sub string, checked, focused bool) string {
+	mark := wizardCheckboxGlyph(checked, focused)
+	label := lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.NewStyle().Foreground(Active.Title).Render(title),
+		lipgloss.NewStyle().Foreground(Active.TextDim).Render(sub),
+	)
+	row := lipgloss.JoinHorizontal(lipgloss.Top, mark, "  ", label)
+	rowStyle := lipgloss.NewStyle().PaddingLeft(2)
+	if focused {
+		rowStyle = lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(Active.Success).
+			Background(Active.BorderFaint).
+			PaddingLeft(1).
+			PaddingRight(1)
+	}
+	return rowStyle.Render(row)
+}
+
+// parseWindowSize parses a "WIDTHxHEIGHT" window size. Empty input means "use the
+// default" and returns zeros.
+func parseWindowSize(s string) (width, height int, err error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, 0, nil
+	}
+	w, h, ok := strings.Cut(strings.ToLower(strings.ReplaceAll(s, "×", "x")), "x")
+	if !ok {
+		return 0, 0, errors.New("expected WIDTHxHEIGHT, e.g. 1280x720")
+	}
+	width, errW := strconv.Atoi(strings.TrimSpace(w))
+	height, errH := strconv.Atoi(strings.TrimSpace(h))
+	if errW != nil || errH != nil {
+		return 0, 0, errors.New("expected WIDTHxHEIGHT, e.g. 1280x720")
+	}
+	if width <= 0 || height <= 0 {
+		return 0, 0, fmt.Errorf("dimensions must be positive, got %dx%d", width, height)
+	}
+	return width, height, nil
+}
+
+// formatWindowSize is the inverse of [parseWindowSize]; zero dimensions format as empty.
+func formatWindowSize(width, height int) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%dx%d", width, height)
+}
+
+// parseEnvVars parses space-separated environment overrides: KEY=VALUE sets a
+// variable and -KEY unsets an inherited one. Values containing spaces can be
+// wrapped in single or double quotes; inside double quotes, \" and \\ escape.
+func parseEnvVars(s string) (set map[string]string, unset []string, err error) {
+	words, err := launch.SplitWords(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, tok := range words {
+		if name, ok := strings.CutPrefix(tok.Text, "-"); ok && !tok.Quoted && !strings.Contains(name, "=") {
+			if !validEnvName(name) {
+				return nil, nil, fmt.Errorf("invalid variable name %q", name)
+			}
+			unset = append(unset, name)
+			continue
+		}
+		name, value, ok := strings.Cut(tok.Text, "=")
+		if !ok {
+			return nil, nil, fmt.Errorf("expected KEY=VALUE or -KEY, got %q", tok.Text)
+		}
+		if !validEnvName(name) {
+			return nil, nil, fmt.Errorf("invalid variable name %q", name)
+		}
+		if set == nil {
+			set = map[string]string{}
+		}
+		set[name] = value
+	}
+	return set, unset, nil
+}
+
+func validEnvName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, "= \t\"'")
+}
+
+// formatEnvVars is the inverse of [parseEnvVars]: sets sorted by name, then unsets.
+func formatEnvVars(set map[string]string, unset []string) string {
+	names := make([]string, 0, len(set))
+	for k := range set {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(set)+len(unset))
+	for _, k := range names {
+		v := set[k]
+		if v == "" || strings.ContainsAny(v, " \t\"'\\") {
+			v = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v) + `"`
+		}
+		parts = append(parts, k+"="+v)
+	}
+	for _, k := range unset {
+		parts = append(parts, "-"+k)
+	}
+	return strings.Join(parts, " ")
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/aayushdutt/mctui/internal/config"
+	"github.com/aayushdutt/mctui/internal/memory"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -182,3 +183,111 @@ func TestSettings_FocusCycleWraps(t *testing.T) {
 		t.Fatalf("forward from Save should wrap to the first field, got %v", m.focus)
 	}
 }
+
+func TestParseWindowSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		w, h    int
+		wantErr bool
+	}{
+		{"", 0, 0, false},
+		{"  ", 0, 0, false},
+		{"1280x720", 1280, 720, false},
+		{" 1920 X 1080 ", 1920, 1080, false},
+		{"854×480", 854, 480, false},
+		{"1280", 0, 0, true},
+		{"wide x tall", 0, 0, true},
+		{"0x720", 0, 0, true},
+	}
+	for _, tt := range tests {
+		w, h, err := parseWindowSize(tt.in)
+		if (err != nil) != tt.wantErr || w != tt.w || h != tt.h {
+			t.Errorf("parseWindowSize(%q) = %d, %d, %v; want %d, %d, err=%v", tt.in, w, h, err, tt.w, tt.h, tt.wantErr)
+		}
+	}
+}
+
+func TestSettings_SubmitWindowSettings(t *testing.T) {
+	m := NewSettingsModel(&config.Config{WindowWidth: 1280, WindowHeight: 720})
+	if got := m.windowSize.Value(); got != "1280x720" {
+		t.Fatalf("windowSize seed = %q, want 1280x720", got)
+	}
+	m.applyFocus(focusSettingsFullscreen)
+	m.Update(tea.KeyMsg{Type: tea.KeySpace})
+	m.windowSize.SetValue("1920x1080")
+	m.applyFocus(focusSettingsSave)
+
+	_, cmd := m.Update(keyEnter())
+	saved, ok := cmd().(SettingsSaved)
+	if !ok {
+		t.Fatalf("expected SettingsSaved, got %T", cmd())
+	}
+	if saved.WindowWidth != 1920 || saved.WindowHeight != 1080 || !saved.Fullscreen {
+		t.Fatalf("saved window = %dx%d fullscreen=%v, want 1920x1080 fullscreen", saved.WindowWidth, saved.WindowHeight, saved.Fullscreen)
+	}
+}
+
+func TestSettings_InvalidWindowSizeBlocksSave(t *testing.T) {
+	m := NewSettingsModel(&config.Config{})
+	m.windowSize.SetValue("big")
+	m.applyFocus(focusSettingsSave)
+
+	_, cmd := m.Update(keyEnter())
+	if cmd != nil {
+		if _, ok := cmd().(SettingsSaved); ok {
+			t.Fatal("invalid window size should not save")
+		}
+	}
+	if m.saveErr == "" || m.focus != focusSettingsWindowSize {
+		t.Fatalf("expected error and focus on window size, got %q focus=%v", m.saveErr, m.focus)
+	}
+}
+
+func TestParseEnvVars(t *testing.T) {
+	set, unset, err := parseEnvVars(`__GL_THREADED_OPTIMIZATIONS=1 _JAVA_OPTIONS="-Xmx4G -Dfoo=bar" EMPTY= -WAYLAND_DISPLAY`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSet := map[string]string{"__GL_THREADED_OPTIMIZATIONS": "1", "_JAVA_OPTIONS": "-Xmx4G -Dfoo=bar", "EMPTY": ""}
+	if !reflect.DeepEqual(set, wantSet) || !reflect.DeepEqual(unset, []string{"WAYLAND_DISPLAY"}) {
+		t.Fatalf("set=%q unset=%q", set, unset)
+	}
+
+	// formatEnvVars round-trips, quoting where needed.
+	formatted := formatEnvVars(set, unset)
+	if formatted != `EMPTY="" _JAVA_OPTIONS="-Xmx4G -Dfoo=bar" __GL_THREADED_OPTIMIZATIONS=1 -WAYLAND_DISPLAY` {
+		t.Errorf("formatEnvVars = %s", formatted)
+	}
+	set2, unset2, err := parseEnvVars(formatted)
+	if err != nil || !reflect.DeepEqual(set2, set) || !reflect.DeepEqual(unset2, unset) {
+		t.Errorf("round trip = %q %q %v", set2, unset2, err)
+	}
+
+	for _, bad := range []string{"NOEQUALS", `A="unterminated`, "=value", "-"} {
+		if _, _, err := parseEnvVars(bad); err == nil {
+			t.Errorf("parseEnvVars(%q) should fail", bad)
+		}
+	}
+}
+
+func TestSettings_MemorySliders(t *testing.T) {
+	m := NewSettingsModel(&config.Config{MaxMemoryMB: 4096})
+	m.SetSystemMemory(memory.System{TotalMB: 8192, FreeMB: 2048})
+	if m.maxMemory.topMB != 8192 {
+		t.Fatalf("track ends at %d, want the machine's RAM", m.maxMemory.topMB)
+	}
+	m.applyFocus(focusSettingsMaxMemory)
+	m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if m.maxMemory.mb != 3584 {
+		t.Fatalf("maxMemory = %d, want one step down", m.maxMemory.mb)
+	}
+	m.applyFocus(focusSettingsMinMemory)
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+
+	m.applyFocus(focusSettingsSave)
+	_, cmd := m.Update(keyEnter())
+	saved := cmd().(SettingsSaved)
+	if saved.MaxMemoryMB != 3584 || saved.MinMemoryMB != memory.StepMB {
+		t.Fatalf("saved memory = %d-%d MB", saved.MinMemoryMB, saved.MaxMemoryMB)
+	}
+}
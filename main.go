@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 
 	"github.com/aayushdutt/mctui/internal/app"
+	"github.com/aayushdutt/mctui/internal/launch"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -17,9 +20,13 @@ func main() {
 		case "--version", "-v", "version":
 			fmt.Printf("mctui %s\n", version)
 			return
+		case "verify":
+			os.Exit(verify(os.Args[2:]))
 		}
 	}
 
+	launch.LauncherVersion = version
+
 	// Initialize the app
 	model := app.New()
 
@@ -36,3 +43,18 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// verify runs "mctui verify <instance>": verify & repair without the TUI.
+func verify(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: mctui verify <instance name or id>")
+		return 2
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := app.Verify(ctx, args[0], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}